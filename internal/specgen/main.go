@@ -0,0 +1,184 @@
+// Command specgen generates the CLI's operation table from the Leaseweb
+// OpenAPI spec and reports which operations have no handwritten command.
+//
+// It is run through go generate in pkg/cmd.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+)
+
+func main() {
+	specPath := flag.String("spec", "docs/leaseweb-openapi.json", "OpenAPI spec")
+	out := flag.String("out", "pkg/cmd/spec_gen.go", "generated Go file")
+	cmdDir := flag.String("cmd", "pkg/cmd", "package with the handwritten commands")
+	sdkDir := flag.String("sdk", "pkg/leaseweb", "SDK package")
+	coverage := flag.String("coverage", "docs/api-coverage.md", "coverage report")
+	flag.Parse()
+
+	if err := run(*specPath, *out, *cmdDir, *sdkDir, *coverage); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(specPath, out, cmdDir, sdkDir, coverage string) error {
+	s, err := loadSpec(specPath)
+	if err != nil {
+		return err
+	}
+	ops, err := s.operations()
+	if err != nil {
+		return err
+	}
+
+	sdk, err := scanDir(sdkDir, nil)
+	if err != nil {
+		return err
+	}
+	cmds, err := scanDir(cmdDir, sdk.sdkEndpoints())
+	if err != nil {
+		return err
+	}
+
+	m := &matcher{ops: ops}
+	covered := map[string][]string{}
+	commandOps := map[string][]string{}
+	var unknown []endpoint
+	for _, name := range sortedKeys(cmds.commands) {
+		for _, ep := range cmds.reachable(cmds.commands[name]) {
+			matched := m.match(ep)
+			if len(matched) == 0 {
+				unknown = append(unknown, ep)
+			}
+			for _, o := range matched {
+				if !slices.Contains(commandOps[name], o.ID) {
+					commandOps[name] = append(commandOps[name], o.ID)
+				}
+				covered[o.ID] = append(covered[o.ID], name)
+			}
+		}
+	}
+
+	src, err := format.Source(generate(ops, commandOps))
+	if err != nil {
+		return fmt.Errorf("formatting generated code: %w", err)
+	}
+	if err := os.WriteFile(out, src, 0644); err != nil {
+		return err
+	}
+	return os.WriteFile(coverage, report(ops, covered, unknown), 0644)
+}
+
+func generate(ops []*op, commandOps map[string][]string) []byte {
+	var b bytes.Buffer
+	b.WriteString("// Code generated by specgen from docs/leaseweb-openapi.json. DO NOT EDIT.\n\n")
+	b.WriteString("package cmd\n\nimport \"github.com/urfave/cli/v3\"\n\n")
+
+	b.WriteString("var specOperations = []*specOperation{\n")
+	for _, o := range ops {
+		b.WriteString("{\n")
+		fmt.Fprintf(&b, "ID: %q,\nTag: %q,\nGroup: %q,\nName: %q,\n", o.ID, o.Tag, o.Group, o.Name)
+		fmt.Fprintf(&b, "Summary: %q,\nMethod: %q,\nPath: %q,\n", o.Summary, o.Method, o.Path)
+		if len(o.PathParams) > 0 {
+			fmt.Fprintf(&b, "PathParams: %#v,\n", o.PathParams)
+		}
+		if len(o.Query) > 0 {
+			b.WriteString("Query: []specParam{\n")
+			for _, q := range o.Query {
+				fmt.Fprintf(&b, "{Name: %q, Flag: %q, Type: %q, Description: %q", q.Name, q.Flag, q.Type, q.Description)
+				if q.Required {
+					b.WriteString(", Required: true")
+				}
+				if len(q.Enum) > 0 {
+					fmt.Fprintf(&b, ", Enum: %#v", q.Enum)
+				}
+				b.WriteString("},\n")
+			}
+			b.WriteString("},\n")
+		}
+		if o.Body {
+			b.WriteString("Body: true,\n")
+		}
+		if o.BodyRequired {
+			b.WriteString("BodyRequired: true,\n")
+		}
+		if o.Schema != "" {
+			fmt.Fprintf(&b, "Schema: %q,\n", o.Schema)
+		}
+		if o.Example != "" {
+			fmt.Fprintf(&b, "Example: %q,\n", o.Example)
+		}
+		if o.Status != 0 {
+			fmt.Fprintf(&b, "Status: %d,\n", o.Status)
+		}
+		if o.Response != "" {
+			fmt.Fprintf(&b, "Response: %q,\n", o.Response)
+		}
+		b.WriteString("},\n")
+	}
+	b.WriteString("}\n\n")
+
+	b.WriteString("// commandOperations links handwritten commands to the operations they call.\n")
+	b.WriteString("var commandOperations = map[*cli.Command][]string{\n")
+	for _, name := range sortedKeys(commandOps) {
+		fmt.Fprintf(&b, "&%s: %#v,\n", name, commandOps[name])
+	}
+	b.WriteString("}\n")
+	return b.Bytes()
+}
+
+func report(ops []*op, covered map[string][]string, unknown []endpoint) []byte {
+	var missing []*op
+	for _, o := range ops {
+		if len(covered[o.ID]) == 0 {
+			missing = append(missing, o)
+		}
+	}
+
+	var b bytes.Buffer
+	b.WriteString("# API coverage\n\n")
+	b.WriteString("Generated by `go generate ./pkg/cmd` from `docs/leaseweb-openapi.json`. Do not edit.\n\n")
+	fmt.Fprintf(&b, "%d of %d operations have a handwritten command. The remaining %d are available only as commands generated from the spec.\n\n",
+		len(ops)-len(missing), len(ops), len(missing))
+
+	b.WriteString("## Operations without a handwritten command\n\n")
+	b.WriteString("| Command | Method | Path | Operation |\n|---|---|---|---|\n")
+	for _, o := range missing {
+		fmt.Fprintf(&b, "| `lw %s %s` | %s | `%s` | %s |\n", o.Group, o.Name, o.Method, o.Path, o.ID)
+	}
+
+	b.WriteString("\n## Handwritten endpoints not in the spec\n\n")
+	if len(unknown) == 0 {
+		b.WriteString("None.\n")
+		return b.Bytes()
+	}
+	sort.Slice(unknown, func(i, j int) bool {
+		if unknown[i].Path != unknown[j].Path {
+			return unknown[i].Path < unknown[j].Path
+		}
+		return unknown[i].Method < unknown[j].Method
+	})
+	b.WriteString("| Method | Path | Source |\n|---|---|---|\n")
+	seen := map[string]bool{}
+	for _, ep := range unknown {
+		key := ep.Method + " " + ep.Path
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		method := ep.Method
+		if method == "" {
+			method = "*"
+		}
+		fmt.Fprintf(&b, "| %s | `%s` | %s |\n", method, strings.ReplaceAll(ep.Path, "{}", "{…}"), ep.Pos)
+	}
+	return b.Bytes()
+}
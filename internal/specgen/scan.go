@@ -0,0 +1,406 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// endpoint is an API path found in handwritten code. Method is empty when
+// it cannot be told from the call site.
+type endpoint struct {
+	Method string
+	Path   string
+	Pos    string
+}
+
+// sourceScan records which endpoints each function of a package calls.
+type sourceScan struct {
+	fset  *token.FileSet
+	funcs map[string][]endpoint
+	calls map[string][]string
+	// commands maps command variables to the handler in their Action.
+	commands map[string]string
+}
+
+var apiPathRe = regexp.MustCompile(`^/[A-Za-z0-9]+/(v\d+|ec2)(/|$)`)
+
+var callMethods = map[string]string{
+	"Get": "GET", "get": "GET", "GetList": "GET", "fetchAllPages": "GET",
+	"listPage": "GET", "paginate": "GET",
+	"Post": "POST", "PostJSON": "POST",
+	"Put": "PUT", "PutJSON": "PUT",
+	"PatchJSON": "PATCH",
+	"Delete":    "DELETE", "DeleteWithBody": "DELETE",
+}
+
+// scanDir parses the non-generated, non-test Go files of dir. SDK method
+// calls are resolved through sdk, which may be nil.
+func scanDir(dir string, sdk map[string][]endpoint) (*sourceScan, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	sc := &sourceScan{
+		fset:     token.NewFileSet(),
+		funcs:    map[string][]endpoint{},
+		calls:    map[string][]string{},
+		commands: map[string]string{},
+	}
+	for _, name := range files {
+		if strings.HasSuffix(name, "_test.go") || strings.HasSuffix(name, "_gen.go") {
+			continue
+		}
+		f, err := parser.ParseFile(sc.fset, name, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, decl := range f.Decls {
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				if d.Body != nil {
+					sc.scanFunc(funcKey(d), d.Body, sdk)
+				}
+			case *ast.GenDecl:
+				sc.scanCommands(d, sdk)
+			}
+		}
+	}
+	return sc, nil
+}
+
+// funcKey names methods Type.Method and functions by their name.
+func funcKey(d *ast.FuncDecl) string {
+	if d.Recv == nil || len(d.Recv.List) == 0 {
+		return d.Name.Name
+	}
+	t := d.Recv.List[0].Type
+	if star, ok := t.(*ast.StarExpr); ok {
+		t = star.X
+	}
+	if id, ok := t.(*ast.Ident); ok {
+		return id.Name + "." + d.Name.Name
+	}
+	return d.Name.Name
+}
+
+// scanCommands records the handler of every cli.Command variable. Inline
+// actions are scanned as functions named after the variable.
+func (sc *sourceScan) scanCommands(d *ast.GenDecl, sdk map[string][]endpoint) {
+	for _, spec := range d.Specs {
+		vs, ok := spec.(*ast.ValueSpec)
+		if !ok || len(vs.Names) != 1 || len(vs.Values) != 1 {
+			continue
+		}
+		lit, ok := vs.Values[0].(*ast.CompositeLit)
+		if !ok {
+			continue
+		}
+		if sel, ok := lit.Type.(*ast.SelectorExpr); !ok || sel.Sel.Name != "Command" {
+			continue
+		}
+		for _, elt := range lit.Elts {
+			kv, ok := elt.(*ast.KeyValueExpr)
+			if !ok {
+				continue
+			}
+			if key, ok := kv.Key.(*ast.Ident); ok && key.Name == "Action" {
+				handler := kv.Value
+				// Wrappers such as confirm take the handler last.
+				if call, ok := handler.(*ast.CallExpr); ok && len(call.Args) > 0 {
+					handler = call.Args[len(call.Args)-1]
+				}
+				switch handler := handler.(type) {
+				case *ast.Ident:
+					sc.commands[vs.Names[0].Name] = handler.Name
+				case *ast.FuncLit:
+					name := vs.Names[0].Name + ".Action"
+					sc.scanFunc(name, handler.Body, sdk)
+					sc.commands[vs.Names[0].Name] = name
+				}
+			}
+		}
+	}
+}
+
+func (sc *sourceScan) scanFunc(name string, body *ast.BlockStmt, sdk map[string][]endpoint) {
+	seen := map[ast.Expr]bool{}
+	add := func(method string, e ast.Expr) {
+		if seen[e] {
+			return
+		}
+		if p, ok := pathOf(e); ok {
+			seen[e] = true
+			sc.funcs[name] = append(sc.funcs[name], endpoint{Method: method, Path: p, Pos: sc.pos(e)})
+		}
+	}
+
+	// Paths passed straight to a request helper carry its method.
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.CallExpr:
+			fn := calleeName(n.Fun)
+			if fn == ".Sprintf" {
+				return true
+			}
+			if fn != "" && !strings.Contains(fn, ".") {
+				sc.calls[name] = append(sc.calls[name], fn)
+			}
+			if eps, ok := sdk[fn]; ok {
+				sc.funcs[name] = append(sc.funcs[name], eps...)
+			}
+			method := callMethods[lastPart(fn)]
+			if method == "" {
+				method = methodArg(n.Args)
+			}
+			for _, arg := range n.Args {
+				add(method, arg)
+			}
+		case *ast.CompositeLit:
+			var method string
+			var path ast.Expr
+			for _, elt := range n.Elts {
+				kv, ok := elt.(*ast.KeyValueExpr)
+				if !ok {
+					continue
+				}
+				switch key, _ := kv.Key.(*ast.Ident); {
+				case key == nil:
+				case key.Name == "Method":
+					method = methodArg([]ast.Expr{kv.Value})
+				case key.Name == "Path":
+					path = kv.Value
+				}
+			}
+			if path != nil {
+				add(method, path)
+			}
+		}
+		return true
+	})
+
+	// Anything else, e.g. a path assigned to a variable first, matches any
+	// method.
+	ast.Inspect(body, func(n ast.Node) bool {
+		e, ok := n.(ast.Expr)
+		if !ok {
+			return true
+		}
+		if _, ok := pathOf(e); ok {
+			add("", e)
+			return false
+		}
+		return true
+	})
+}
+
+func (sc *sourceScan) pos(n ast.Node) string {
+	p := sc.fset.Position(n.Pos())
+	return fmt.Sprintf("%s:%d", filepath.Base(p.Filename), p.Line)
+}
+
+// calleeName returns "Name" for package-level calls and "Type.Name" style
+// keys for SDK service calls such as client.API().BareMetal.ListServers.
+func calleeName(fun ast.Expr) string {
+	switch f := fun.(type) {
+	case *ast.Ident:
+		return f.Name
+	case *ast.IndexExpr:
+		return calleeName(f.X)
+	case *ast.IndexListExpr:
+		return calleeName(f.X)
+	case *ast.SelectorExpr:
+		if inner, ok := f.X.(*ast.SelectorExpr); ok {
+			return inner.Sel.Name + "Service." + f.Sel.Name
+		}
+		return "." + f.Sel.Name
+	}
+	return ""
+}
+
+func lastPart(name string) string {
+	return name[strings.LastIndexByte(name, '.')+1:]
+}
+
+// methodArg finds an HTTP method given as a string literal or an
+// http.MethodX constant.
+func methodArg(args []ast.Expr) string {
+	for _, arg := range args {
+		switch a := arg.(type) {
+		case *ast.BasicLit:
+			if s, err := strconv.Unquote(a.Value); err == nil {
+				switch s {
+				case "GET", "POST", "PUT", "PATCH", "DELETE":
+					return s
+				}
+			}
+		case *ast.SelectorExpr:
+			if x, ok := a.X.(*ast.Ident); ok && x.Name == "http" && strings.HasPrefix(a.Sel.Name, "Method") {
+				return strings.ToUpper(strings.TrimPrefix(a.Sel.Name, "Method"))
+			}
+		}
+	}
+	return ""
+}
+
+// pathOf renders a string concatenation or fmt.Sprintf call building an API
+// path as a template with {} for every dynamic part. Query strings are
+// dropped.
+func pathOf(e ast.Expr) (string, bool) {
+	var parts []string
+	switch e := e.(type) {
+	case *ast.BasicLit:
+		parts = append(parts, literal(e))
+	case *ast.BinaryExpr:
+		if e.Op != token.ADD {
+			return "", false
+		}
+		parts = flatten(e)
+	case *ast.CallExpr:
+		sel, ok := e.Fun.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != "Sprintf" || len(e.Args) == 0 {
+			return "", false
+		}
+		lit, ok := e.Args[0].(*ast.BasicLit)
+		if !ok {
+			return "", false
+		}
+		format := literal(lit)
+		for _, verb := range []string{"%s", "%d", "%v"} {
+			format = strings.ReplaceAll(format, verb, "{}")
+		}
+		parts = append(parts, format)
+	default:
+		return "", false
+	}
+
+	p := strings.Join(parts, "")
+	if i := strings.IndexByte(p, '?'); i >= 0 {
+		p = p[:i]
+	}
+	// A trailing dynamic part glued to a segment is a query string.
+	for strings.HasSuffix(p, "{}") && !strings.HasSuffix(p, "/{}") {
+		p = strings.TrimSuffix(p, "{}")
+	}
+	p = strings.TrimSuffix(p, "/")
+	if !apiPathRe.MatchString(p) {
+		return "", false
+	}
+	return p, true
+}
+
+func flatten(e ast.Expr) []string {
+	switch e := e.(type) {
+	case *ast.BinaryExpr:
+		if e.Op == token.ADD {
+			return append(flatten(e.X), flatten(e.Y)...)
+		}
+	case *ast.BasicLit:
+		if e.Kind == token.STRING {
+			return []string{literal(e)}
+		}
+	case *ast.ParenExpr:
+		return flatten(e.X)
+	}
+	return []string{"{}"}
+}
+
+func literal(lit *ast.BasicLit) string {
+	if lit.Kind != token.STRING {
+		return ""
+	}
+	s, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		return ""
+	}
+	return s
+}
+
+// reachable returns the endpoints called by fn and, transitively, by the
+// package functions it calls.
+func (sc *sourceScan) reachable(fn string) []endpoint {
+	var eps []endpoint
+	visited := map[string]bool{}
+	var visit func(string)
+	visit = func(name string) {
+		if visited[name] {
+			return
+		}
+		visited[name] = true
+		eps = append(eps, sc.funcs[name]...)
+		for _, callee := range sc.calls[name] {
+			visit(callee)
+		}
+	}
+	visit(fn)
+	return eps
+}
+
+// sdkEndpoints returns the endpoints of every exported SDK method, keyed by
+// Type.Method.
+func (sc *sourceScan) sdkEndpoints() map[string][]endpoint {
+	res := map[string][]endpoint{}
+	for name := range sc.funcs {
+		if i := strings.IndexByte(name, '.'); i > 0 && ast.IsExported(name[i+1:]) {
+			res[name] = sc.reachable(name)
+		}
+	}
+	return res
+}
+
+// matcher finds the spec operations for handwritten endpoints.
+type matcher struct {
+	ops []*op
+}
+
+func normalize(path string) string {
+	return strings.TrimSuffix(pathParamRe.ReplaceAllString(path, "{}"), "/")
+}
+
+// match returns the operations for ep, preferring an exact template match
+// over one where parameters on either side match any segment.
+func (m *matcher) match(ep endpoint) []*op {
+	var exact, loose []*op
+	for _, o := range m.ops {
+		if ep.Method != "" && ep.Method != o.Method {
+			continue
+		}
+		spec := normalize(o.Path)
+		if spec == ep.Path {
+			exact = append(exact, o)
+		} else if segmentsMatch(strings.Split(spec, "/"), strings.Split(ep.Path, "/")) {
+			loose = append(loose, o)
+		}
+	}
+	if len(exact) > 0 {
+		return exact
+	}
+	return loose
+}
+
+func segmentsMatch(spec, path []string) bool {
+	if len(spec) != len(path) {
+		return false
+	}
+	for i := range spec {
+		if spec[i] != path[i] && !strings.Contains(spec[i], "{}") && path[i] != "{}" {
+			return false
+		}
+	}
+	return true
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,365 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type spec struct {
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Components struct {
+		Schemas    map[string]any       `json:"schemas"`
+		Parameters map[string]parameter `json:"parameters"`
+		Responses  map[string]response  `json:"responses"`
+	} `json:"components"`
+}
+
+type operation struct {
+	OperationID string      `json:"operationId"`
+	Summary     string      `json:"summary"`
+	Tags        []string    `json:"tags"`
+	Parameters  []parameter `json:"parameters"`
+	RequestBody *struct {
+		Required bool                      `json:"required"`
+		Content  map[string]map[string]any `json:"content"`
+	} `json:"requestBody"`
+	Responses map[string]response `json:"responses"`
+}
+
+type response struct {
+	Ref     string                    `json:"$ref"`
+	Content map[string]map[string]any `json:"content"`
+}
+
+type parameter struct {
+	Ref         string `json:"$ref"`
+	Name        string `json:"name"`
+	In          string `json:"in"`
+	Description string `json:"description"`
+	Required    bool   `json:"required"`
+	Schema      struct {
+		Type string `json:"type"`
+		Enum []any  `json:"enum"`
+	} `json:"schema"`
+}
+
+// op is a spec operation flattened into what the CLI needs.
+type op struct {
+	ID           string
+	Tag          string
+	Group        string
+	Name         string
+	Summary      string
+	Method       string
+	Path         string
+	PathParams   []string
+	Query        []queryParam
+	Body         bool
+	BodyRequired bool
+	Schema       string
+	Example      string
+	// Status is the first success status of the operation and Response
+	// the spec's example body for it.
+	Status   int
+	Response string
+}
+
+type queryParam struct {
+	Name        string
+	Flag        string
+	Type        string
+	Description string
+	Required    bool
+	Enum        []string
+}
+
+var methods = []string{"get", "post", "put", "patch", "delete"}
+
+// groupNames maps spec tags onto the existing top-level command names where
+// the kebab-cased tag would differ.
+var groupNames = map[string]string{
+	"Api Key management":           "api-keys",
+	"Aws Ec2 Wrapper":              "aws-ec2",
+	"Dedicated Network Equipments": "network-equipment",
+	"Floating IPs":                 "floating-ips",
+	"IP management":                "ips",
+	"Ordering":                     "orders",
+	"Public Clouds":                "public-cloud",
+	"Web hosting":                  "webhosting",
+}
+
+// reservedFlags are flag names taken by global or generated flags; query
+// parameters with these names get a "query-" prefix.
+var reservedFlags = []string{
+	"all", "data", "debug", "dry-run", "help", "max-retries", "output", "page-size",
+	"profile", "record", "replay", "retry-wait", "set", "set-json", "skip-validation", "timeout", "transform", "yes",
+}
+
+var pathParamRe = regexp.MustCompile(`\{([^}]+)\}`)
+
+func loadSpec(path string) (*spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var s spec
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return &s, nil
+}
+
+// operations returns every operation of the spec, sorted by group and name.
+func (s *spec) operations() ([]*op, error) {
+	var ops []*op
+	for path, item := range s.Paths {
+		var shared []parameter
+		if raw, ok := item["parameters"]; ok {
+			if err := json.Unmarshal(raw, &shared); err != nil {
+				return nil, fmt.Errorf("%s: %w", path, err)
+			}
+		}
+		for _, method := range methods {
+			raw, ok := item[method]
+			if !ok {
+				continue
+			}
+			var o operation
+			if err := json.Unmarshal(raw, &o); err != nil {
+				return nil, fmt.Errorf("%s %s: %w", method, path, err)
+			}
+			res, err := s.convert(strings.ToUpper(method), path, &o, shared)
+			if err != nil {
+				return nil, err
+			}
+			ops = append(ops, res)
+		}
+	}
+	assignNames(ops)
+	sort.Slice(ops, func(i, j int) bool {
+		if ops[i].Group != ops[j].Group {
+			return ops[i].Group < ops[j].Group
+		}
+		return ops[i].Name < ops[j].Name
+	})
+	return ops, nil
+}
+
+func (s *spec) convert(method, path string, o *operation, shared []parameter) (*op, error) {
+	res := &op{
+		ID:      o.OperationID,
+		Summary: strings.TrimSpace(o.Summary),
+		Method:  method,
+		Path:    path,
+	}
+	if len(o.Tags) > 0 {
+		res.Tag = o.Tags[0]
+	}
+	res.Group = groupNames[res.Tag]
+	if res.Group == "" {
+		res.Group = kebab(res.Tag)
+	}
+	for _, m := range pathParamRe.FindAllStringSubmatch(path, -1) {
+		res.PathParams = append(res.PathParams, m[1])
+	}
+
+	flags := map[string]bool{}
+	for _, p := range append(slices.Clone(shared), o.Parameters...) {
+		if p.Ref != "" {
+			name := strings.TrimPrefix(p.Ref, "#/components/parameters/")
+			resolved, ok := s.Components.Parameters[name]
+			if !ok {
+				return nil, fmt.Errorf("%s %s: unknown parameter %s", method, path, p.Ref)
+			}
+			p = resolved
+		}
+		if p.In != "query" {
+			continue
+		}
+		flag := kebab(p.Name)
+		if slices.Contains(reservedFlags, flag) || flags[flag] {
+			flag = "query-" + flag
+		}
+		flags[flag] = true
+		q := queryParam{
+			Name:        p.Name,
+			Flag:        flag,
+			Type:        p.Schema.Type,
+			Description: firstLine(p.Description),
+			Required:    p.Required,
+		}
+		for _, e := range p.Schema.Enum {
+			q.Enum = append(q.Enum, fmt.Sprint(e))
+		}
+		res.Query = append(res.Query, q)
+	}
+
+	if o.RequestBody != nil {
+		res.Body = true
+		res.BodyRequired = o.RequestBody.Required
+		if media, ok := o.RequestBody.Content["application/json"]; ok && media["schema"] != nil {
+			schema, err := json.Marshal(s.resolve(media["schema"], nil))
+			if err != nil {
+				return nil, err
+			}
+			res.Schema = string(schema)
+			if media["example"] != nil {
+				example, err := json.Marshal(media["example"])
+				if err != nil {
+					return nil, err
+				}
+				res.Example = string(example)
+			}
+		}
+	}
+
+	var codes []int
+	for code := range o.Responses {
+		if n, err := strconv.Atoi(code); err == nil && n >= 200 && n < 300 {
+			codes = append(codes, n)
+		}
+	}
+	if len(codes) > 0 {
+		res.Status = slices.Min(codes)
+		r := o.Responses[strconv.Itoa(res.Status)]
+		if r.Ref != "" {
+			r = s.Components.Responses[strings.TrimPrefix(r.Ref, "#/components/responses/")]
+		}
+		if example := mediaExample(r.Content["application/json"]); example != nil {
+			b, err := json.Marshal(example)
+			if err != nil {
+				return nil, err
+			}
+			res.Response = string(b)
+		}
+	}
+	return res, nil
+}
+
+// mediaExample returns the example of a media type, or the first of its
+// named examples.
+func mediaExample(media map[string]any) any {
+	if media["example"] != nil {
+		return media["example"]
+	}
+	examples, _ := media["examples"].(map[string]any)
+	for _, name := range sortedKeys(examples) {
+		if e, ok := examples[name].(map[string]any); ok && e["value"] != nil {
+			return e["value"]
+		}
+	}
+	return nil
+}
+
+// resolve inlines $refs to component schemas and drops examples. Recursive
+// references are replaced by an empty schema.
+func (s *spec) resolve(v any, seen []string) any {
+	switch v := v.(type) {
+	case map[string]any:
+		if ref, ok := v["$ref"].(string); ok {
+			name := strings.TrimPrefix(ref, "#/components/schemas/")
+			if slices.Contains(seen, name) {
+				return map[string]any{}
+			}
+			return s.resolve(s.Components.Schemas[name], append(seen, name))
+		}
+		out := make(map[string]any, len(v))
+		for k, val := range v {
+			if k == "example" || k == "examples" {
+				continue
+			}
+			out[k] = s.resolve(val, seen)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, val := range v {
+			out[i] = s.resolve(val, seen)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// assignNames derives subcommand names from operation IDs, dropping the
+// numeric suffixes the spec uses to keep IDs unique unless that would clash
+// within the group.
+func assignNames(ops []*op) {
+	byGroup := map[string][]*op{}
+	for _, o := range ops {
+		byGroup[o.Group] = append(byGroup[o.Group], o)
+	}
+	for _, group := range byGroup {
+		count := map[string]int{}
+		for _, o := range group {
+			o.Name = commandName(o, true)
+			count[o.Name]++
+		}
+		for _, o := range group {
+			if count[o.Name] > 1 {
+				o.Name = commandName(o, false)
+			}
+		}
+	}
+}
+
+var versionSuffixRe = regexp.MustCompile(`_v\d+_.*$`)
+
+// commandName turns an operation ID such as getServerList or
+// get_all_origins_v1_origins_get into a kebab-case command name. IDs
+// spelling out the whole path fall back to the summary.
+func commandName(o *op, trimDigits bool) string {
+	if strings.ContainsAny(o.ID, "/{") && o.Summary != "" {
+		var words []string
+		for _, w := range strings.Fields(o.Summary) {
+			if w != "a" && w != "an" && w != "the" {
+				words = append(words, w)
+			}
+		}
+		return kebab(strings.Join(words, " "))
+	}
+	id := versionSuffixRe.ReplaceAllString(o.ID, "")
+	if trimDigits {
+		id = strings.TrimRight(id, "0123456789")
+	}
+	return kebab(id)
+}
+
+// kebab converts camelCase, snake_case and space separated words to
+// kebab-case.
+func kebab(s string) string {
+	var b strings.Builder
+	runes := []rune(s)
+	for i, r := range runes {
+		switch {
+		case r >= 'A' && r <= 'Z':
+			prevLower := i > 0 && (runes[i-1] >= 'a' && runes[i-1] <= 'z' || runes[i-1] >= '0' && runes[i-1] <= '9')
+			nextLower := i > 0 && i+1 < len(runes) && runes[i+1] >= 'a' && runes[i+1] <= 'z' && runes[i-1] >= 'A' && runes[i-1] <= 'Z'
+			if prevLower || nextLower {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r - 'A' + 'a')
+		case r >= 'a' && r <= 'z' || r >= '0' && r <= '9':
+			b.WriteRune(r)
+		default:
+			b.WriteByte('-')
+		}
+	}
+	parts := strings.FieldsFunc(b.String(), func(r rune) bool { return r == '-' })
+	return strings.Join(parts, "-")
+}
+
+func firstLine(s string) string {
+	s = strings.TrimSpace(s)
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		s = s[:i]
+	}
+	return strings.TrimSpace(s)
+}
@@ -35,7 +35,7 @@ var abuseListCmd = cli.Command{Name: "list", Usage: "List abuse reports", Flags:
 	if s := cmd.String("sort-by"); s != "" {
 		q += "&sortBy=" + s
 	}
-	res, err := client.Get(ctx, "/abuse/v1/reports?"+q)
+	res, err := GetList(ctx, client, cmd, "/abuse/v1/reports?"+q)
 	if err != nil {
 		return err
 	}
@@ -32,7 +32,7 @@ func handleAPList(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	res, err := client.Get(ctx, "/bareMetals/v2/aggregationPacks?"+PaginationQuery(cmd))
+	res, err := GetList(ctx, client, cmd, "/bareMetals/v2/aggregationPacks?"+PaginationQuery(cmd))
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,391 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+)
+
+// Aliases are defined in the aliases section of the config file and expand
+// like git aliases: the words of the expansion take the place of the alias
+// name, $1 to $9 are replaced by the arguments after it and $@ by all of
+// them. Arguments no placeholder uses are appended. An expansion starting
+// with ! is run by sh instead, with the arguments as its positional
+// parameters.
+
+var aliasNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+var aliasPlaceholder = regexp.MustCompile(`\$(@|[1-9])`)
+
+var aliasCmd = cli.Command{
+	Name:  "alias",
+	Usage: "Manage command aliases",
+	Commands: []*cli.Command{
+		&aliasSetCmd,
+		&aliasListCmd,
+		&aliasDeleteCmd,
+	},
+	HideHelpCommand: true,
+}
+
+var aliasSetCmd = cli.Command{
+	Name:  "set",
+	Usage: "Define an alias",
+	Description: "The expansion may be given as one quoted argument or as several. $1 to $9 and $@ are\n" +
+		"replaced by the arguments given to the alias; an expansion starting with ! is a shell script:\n\n" +
+		"   lw alias set ids -o raw ds list --transform 'servers.#.id'\n" +
+		"   lw alias set srv 'ds get $1'\n" +
+		"   lw alias set ip '!lw -o raw ds get \"$1\" --transform networkInterfaces.public.ip'",
+	ArgsUsage:       "<name> <expansion>...",
+	SkipFlagParsing: true,
+	Action:          handleAliasSet,
+	HideHelpCommand: true,
+}
+
+var aliasListCmd = cli.Command{
+	Name:            "list",
+	Usage:           "List aliases",
+	Action:          handleAliasList,
+	HideHelpCommand: true,
+}
+
+var aliasDeleteCmd = cli.Command{
+	Name:            "delete",
+	Aliases:         []string{"rm"},
+	Usage:           "Delete an alias",
+	ArgsUsage:       "<name>",
+	Action:          handleAliasDelete,
+	HideHelpCommand: true,
+}
+
+func handleAliasSet(_ context.Context, cmd *cli.Command) error {
+	args := configArgs(cmd)
+	if len(args) < 2 {
+		return fmt.Errorf("usage: lw alias set <name> <expansion>...")
+	}
+	name := args[0]
+	if err := checkAliasName(name); err != nil {
+		return err
+	}
+	expansion := args[1]
+	if len(args) > 2 {
+		words := make([]string, len(args)-1)
+		for i, a := range args[1:] {
+			words[i] = shellWord(a)
+		}
+		expansion = strings.Join(words, " ")
+	}
+	if strings.TrimSpace(strings.TrimPrefix(expansion, "!")) == "" {
+		return fmt.Errorf("the expansion of alias %q is empty", name)
+	}
+	if !strings.HasPrefix(expansion, "!") {
+		if _, err := splitWords(expansion); err != nil {
+			return fmt.Errorf("expansion of alias %q: %w", name, err)
+		}
+	}
+
+	doc, err := readConfigDoc()
+	if err != nil {
+		return err
+	}
+	setNode(doc.Content[0], []string{"aliases", name}, expansion, "!!str")
+	if err := saveConfigDoc(doc); err != nil {
+		return fmt.Errorf("writing config: %w", err)
+	}
+	fmt.Printf("Alias %q expands to: %s\n", name, expansion)
+	return nil
+}
+
+func handleAliasList(_ context.Context, cmd *cli.Command) error {
+	aliases := loadConfig().Aliases
+	format := cmd.Root().String("output")
+	if format != "auto" {
+		if aliases == nil {
+			aliases = map[string]string{}
+		}
+		b, err := json.Marshal(aliases)
+		if err != nil {
+			return err
+		}
+		return ShowJSON(os.Stdout, string(b), format, cmd.Root().String("transform"))
+	}
+
+	if len(aliases) == 0 {
+		fmt.Fprintln(os.Stderr, "No aliases defined. Add one with 'lw alias set <name> <expansion>'.")
+		return nil
+	}
+	names := make([]string, 0, len(aliases))
+	for name := range aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	table := NewTableWriter(os.Stdout, "NAME", "EXPANSION")
+	table.TruncOrder = []int{1}
+	for _, name := range names {
+		table.AddRow(name, aliases[name])
+	}
+	table.Render()
+	return nil
+}
+
+func handleAliasDelete(_ context.Context, cmd *cli.Command) error {
+	args := configArgs(cmd)
+	if len(args) != 1 {
+		return fmt.Errorf("usage: lw alias delete <name>")
+	}
+	doc, err := readConfigDoc()
+	if err != nil {
+		return err
+	}
+	root := doc.Content[0]
+	if !deleteNode(root, "aliases", args[0]) {
+		return fmt.Errorf("alias %q not found", args[0])
+	}
+	if len(mappingKeys(lookupNode(root, "aliases"))) == 0 {
+		deleteNode(root, "aliases")
+	}
+	if err := saveConfigDoc(doc); err != nil {
+		return fmt.Errorf("writing config: %w", err)
+	}
+	fmt.Printf("Deleted alias %q\n", args[0])
+	return nil
+}
+
+// checkAliasName rejects names that are not plain words or that would shadow
+// a built-in command.
+func checkAliasName(name string) error {
+	if !aliasNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid alias name %q: use letters, digits, - and _", name)
+	}
+	if isBuiltinCommand(name) {
+		return fmt.Errorf("%q is a built-in command and cannot be an alias", name)
+	}
+	return nil
+}
+
+// isBuiltinCommand reports whether name is the name or an alias of a
+// top-level command.
+func isBuiltinCommand(name string) bool {
+	for _, c := range Command.Commands {
+		if c.Name == name || slices.Contains(c.Aliases, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Run runs lw with args, given like os.Args, after expanding an alias used
+// as the command.
+func Run(ctx context.Context, args []string) error {
+	return runRoot(ctx, Command, args)
+}
+
+// runRoot is Run with the command tree to run.
+func runRoot(ctx context.Context, root *cli.Command, args []string) error {
+	expanded, script, err := expandAlias(args, loadConfig().Aliases)
+	if err != nil {
+		return err
+	}
+	if script != nil {
+		return script.run()
+	}
+	return root.Run(context.WithValue(ctx, runArgsKey{}, expanded), expanded)
+}
+
+// aliasScript is a shell alias ready to run.
+type aliasScript struct {
+	name, script string
+	args         []string
+}
+
+func (s *aliasScript) run() error {
+	if runtime.GOOS == "windows" {
+		return fmt.Errorf("alias %q is a shell alias, which needs sh", s.name)
+	}
+	// The alias name becomes $0 and its arguments $1 and on.
+	c := exec.Command("sh", append([]string{"-c", s.script, s.name}, s.args...)...)
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	if err := c.Run(); err != nil {
+		return fmt.Errorf("alias %q: %w", s.name, err)
+	}
+	return nil
+}
+
+// expandAlias returns args with the alias in the command position expanded,
+// or the script to run for a shell alias. Built-in commands are never
+// expanded.
+func expandAlias(args []string, aliases map[string]string) ([]string, *aliasScript, error) {
+	if len(aliases) == 0 {
+		return args, nil, nil
+	}
+	i := commandIndex(args)
+	if i < 0 {
+		return args, nil, nil
+	}
+	name := args[i]
+	expansion, ok := aliases[name]
+	if !ok || isBuiltinCommand(name) {
+		return args, nil, nil
+	}
+	params := args[i+1:]
+
+	if script, ok := strings.CutPrefix(expansion, "!"); ok {
+		return nil, &aliasScript{name: name, script: script, args: params}, nil
+	}
+
+	words, err := splitWords(expansion)
+	if err != nil {
+		return nil, nil, fmt.Errorf("expansion of alias %q: %w", name, err)
+	}
+	used := make([]bool, len(params))
+	usedAll := false
+	var expanded []string
+	for _, w := range words {
+		if w == "$@" {
+			expanded = append(expanded, params...)
+			usedAll = true
+			continue
+		}
+		var missing int
+		w = aliasPlaceholder.ReplaceAllStringFunc(w, func(p string) string {
+			if p == "$@" {
+				usedAll = true
+				return strings.Join(params, " ")
+			}
+			n, _ := strconv.Atoi(p[1:])
+			if n > len(params) {
+				missing = max(missing, n)
+				return ""
+			}
+			used[n-1] = true
+			return params[n-1]
+		})
+		if missing > 0 {
+			return nil, nil, fmt.Errorf("alias %q needs at least %d argument(s): %s", name, missing, expansion)
+		}
+		expanded = append(expanded, w)
+	}
+	if !usedAll {
+		for j, p := range params {
+			if !used[j] {
+				expanded = append(expanded, p)
+			}
+		}
+	}
+
+	out := append(slices.Clone(args[:i]), expanded...)
+	return out, nil, nil
+}
+
+// commandIndex returns the index in args of the first argument that is not
+// a global flag or a flag value, or -1 when there is none.
+func commandIndex(args []string) int {
+	for i := 1; i < len(args); i++ {
+		a := args[i]
+		switch {
+		case a == "--":
+			return -1
+		case !strings.HasPrefix(a, "-") || a == "-":
+			return i
+		case strings.Contains(a, "="):
+			continue
+		}
+		if globalFlagTakesValue(strings.TrimLeft(a, "-")) {
+			i++
+		}
+	}
+	return -1
+}
+
+// globalFlagTakesValue reports whether the global flag name is followed by
+// a separate value.
+func globalFlagTakesValue(name string) bool {
+	for _, f := range Command.Flags {
+		if !slices.Contains(f.Names(), name) {
+			continue
+		}
+		if _, ok := f.(*dryRunFlag); ok {
+			return false
+		}
+		v, ok := f.(cli.DocGenerationFlag)
+		return ok && v.TakesValue()
+	}
+	return false
+}
+
+// splitWords splits s into words like a POSIX shell does, honouring single
+// and double quotes and backslash escapes. Nothing is expanded.
+func splitWords(s string) ([]string, error) {
+	var words []string
+	var cur strings.Builder
+	inWord := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			if inWord {
+				words = append(words, cur.String())
+				cur.Reset()
+				inWord = false
+			}
+		case c == '\'':
+			j := strings.IndexByte(s[i+1:], '\'')
+			if j < 0 {
+				return nil, fmt.Errorf("unterminated single quote")
+			}
+			cur.WriteString(s[i+1 : i+1+j])
+			i += j + 1
+			inWord = true
+		case c == '"':
+			inWord = true
+			closed := false
+			for i++; i < len(s); i++ {
+				if s[i] == '"' {
+					closed = true
+					break
+				}
+				if s[i] == '\\' && i+1 < len(s) && strings.IndexByte("\"\\$`", s[i+1]) >= 0 {
+					i++
+				}
+				cur.WriteByte(s[i])
+			}
+			if !closed {
+				return nil, fmt.Errorf("unterminated double quote")
+			}
+		case c == '\\' && i+1 < len(s):
+			i++
+			cur.WriteByte(s[i])
+			inWord = true
+		default:
+			cur.WriteByte(c)
+			inWord = true
+		}
+	}
+	if inWord {
+		words = append(words, cur.String())
+	}
+	return words, nil
+}
+
+var shellSafe = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./$-]+$`)
+
+// shellWord returns s as it is when splitWords would keep it one word,
+// and quoted otherwise.
+func shellWord(s string) string {
+	if shellSafe.MatchString(s) {
+		return s
+	}
+	return shellQuote(s)
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func TestAliases(t *testing.T) {
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"GET /bareMetals/v2/servers": func(w http.ResponseWriter, r *http.Request) {
+			jsonResponse(w, 200, map[string]any{
+				"servers":   []map[string]any{{"id": "1"}, {"id": "2"}},
+				"_metadata": map[string]any{"totalCount": 2, "limit": 20, "offset": 0},
+			})
+		},
+		"GET /bareMetals/v2/servers/{id}": func(w http.ResponseWriter, r *http.Request) {
+			jsonResponse(w, 200, map[string]any{"id": r.PathValue("id"), "reference": r.URL.Query().Get("x")})
+		},
+	})
+	defer srv.Close()
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+
+	_, _, err := runCLI(t, "", []string{"alias", "set", "ids", "-o", "raw", "ds", "list", "--transform", "servers.#.id"})
+	require.NoError(t, err)
+	_, _, err = runCLI(t, "", []string{"alias", "set", "srv", "-o json ds get $1"})
+	require.NoError(t, err)
+	_, _, err = runCLI(t, "", []string{"alias", "set", "hello", `!echo "hello $1 from $0"`})
+	require.NoError(t, err)
+
+	stdout, _, err := runCLI(t, "", []string{"-o", "json", "alias", "list"})
+	require.NoError(t, err)
+	assert.Equal(t, "-o raw ds list --transform 'servers.#.id'", gjson.Get(stdout, "ids").String())
+
+	stdout, _, err = runCLI(t, srv.URL, []string{"ids"})
+	require.NoError(t, err)
+	assert.Equal(t, `["1","2"]`, strings.TrimSpace(stdout))
+
+	stdout, _, err = runCLI(t, srv.URL, []string{"srv", "42"})
+	require.NoError(t, err)
+	assert.Equal(t, "42", gjson.Get(stdout, "id").String())
+
+	_, _, err = runCLI(t, srv.URL, []string{"srv"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "needs at least 1 argument")
+
+	stdout, _, err = runCLI(t, "", []string{"hello", "world"})
+	require.NoError(t, err)
+	assert.Equal(t, "hello world from hello\n", stdout)
+
+	// Aliases cannot shadow built-in commands or their aliases.
+	_, _, err = runCLI(t, "", []string{"alias", "set", "ds", "ds list"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "built-in command")
+
+	_, _, err = runCLI(t, "", []string{"alias", "delete", "hello"})
+	require.NoError(t, err)
+	stdout, _, err = runCLI(t, "", []string{"-o", "json", "alias", "list"})
+	require.NoError(t, err)
+	assert.False(t, gjson.Get(stdout, "hello").Exists())
+}
@@ -0,0 +1,302 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/tidwall/gjson"
+	"github.com/urfave/cli/v3"
+)
+
+var apiCmd = cli.Command{
+	Name:  "api",
+	Usage: "Make an authenticated request to any Leaseweb API endpoint",
+	Description: `Sends a request to the given API path and prints the response.
+
+The method defaults to GET, or POST when fields or --input are given.
+Fields set with -f are sent as strings; fields set with -F are converted
+to JSON true, false, null or numbers where possible, and a value starting
+with @ is read from a file (@- reads stdin). Nested keys use key[sub]=value
+and key[]=value appends to an array. For GET and DELETE requests fields are
+sent as query parameters instead of a JSON body.
+
+Examples:
+  lw api /cdn/v2/originGroups
+  lw api GET /publicCloud/v1/targetGroups --paginate
+  lw api POST /apiKeys/v1/keys/abc123/whiteListedIps -f ip=1.2.3.4
+  lw api PUT /bareMetals/v2/servers/12345 --input body.json`,
+	ArgsUsage: "[<method>] <path>",
+	Flags: []cli.Flag{
+		&repeatedFlag{
+			Name:    "raw-field",
+			Aliases: []string{"f"},
+			Usage:   "Add a string field in key=value format",
+		},
+		&repeatedFlag{
+			Name:    "field",
+			Aliases: []string{"F"},
+			Usage:   "Add a typed field in key=value format (use @file to read a file)",
+		},
+		&repeatedFlag{
+			Name:    "header",
+			Aliases: []string{"H"},
+			Usage:   "Add a request header in key:value format",
+		},
+		&cli.StringFlag{
+			Name:  "input",
+			Usage: "File to use as the request body (use - for stdin)",
+		},
+		&cli.BoolFlag{
+			Name:  "paginate",
+			Usage: "Fetch every page of a GET collection using _metadata",
+		},
+	},
+	Action:          handleAPI,
+	HideHelpCommand: true,
+}
+
+func handleAPI(ctx context.Context, cmd *cli.Command) error {
+	args := cmd.Args().Slice()
+	var method, path string
+	switch len(args) {
+	case 1:
+		path = args[0]
+	case 2:
+		method, path = strings.ToUpper(args[0]), args[1]
+	default:
+		return fmt.Errorf("path required\nUsage: lw api [<method>] <path>")
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	fields, err := parseAPIFields(cmd.StringSlice("raw-field"), cmd.StringSlice("field"), os.Stdin)
+	if err != nil {
+		return err
+	}
+	input := cmd.String("input")
+	if input != "" && len(fields) > 0 {
+		return fmt.Errorf("--input cannot be combined with -f/-F fields")
+	}
+	if method == "" {
+		method = "GET"
+		if input != "" || len(fields) > 0 {
+			method = "POST"
+		}
+	}
+
+	header, err := parseAPIHeaders(cmd.StringSlice("header"))
+	if err != nil {
+		return err
+	}
+
+	var body []byte
+	switch {
+	case input != "":
+		body, err = readInput(input, os.Stdin)
+		if err != nil {
+			return err
+		}
+	case len(fields) > 0 && (method == "GET" || method == "DELETE"):
+		path, err = addQueryFields(path, fields)
+		if err != nil {
+			return err
+		}
+	case len(fields) > 0:
+		body, err = json.Marshal(fields)
+		if err != nil {
+			return fmt.Errorf("encoding fields: %w", err)
+		}
+	}
+
+	client, err := NewClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	if !cmd.Bool("paginate") {
+		resp, err := client.send(ctx, method, path, body, header)
+		if err != nil {
+			return err
+		}
+		return showResponseBody(cmd, resp.Body)
+	}
+
+	if method != "GET" {
+		return fmt.Errorf("--paginate is only supported for GET requests")
+	}
+	progress := newPageProgress(isTerminal(os.Stderr))
+	res, err := fetchAllPages(ctx, client, path, header, cmd.Root().Int("page-size"), progress.update)
+	progress.done()
+	if err != nil {
+		return err
+	}
+	return ShowResult(os.Stdout, res, cmd.Root().String("output"), cmd.Root().String("transform"))
+}
+
+// showResponseBody prints a JSON response body in the requested output
+// format. Other bodies are written to stdout as is.
+func showResponseBody(cmd *cli.Command, body []byte) error {
+	if len(body) == 0 {
+		return nil
+	}
+	if !gjson.ValidBytes(body) {
+		_, err := os.Stdout.Write(body)
+		return err
+	}
+	res := gjson.ParseBytes(bytes.TrimSpace(body))
+	return ShowResult(os.Stdout, res, cmd.Root().String("output"), cmd.Root().String("transform"))
+}
+
+// parseAPIFields builds a request object from -f (string) and -F (typed)
+// key=value pairs.
+func parseAPIFields(rawFields, typedFields []string, stdin io.Reader) (map[string]any, error) {
+	fields := map[string]any{}
+	for _, f := range rawFields {
+		key, value, ok := strings.Cut(f, "=")
+		if !ok {
+			return nil, fmt.Errorf("field %q must be in key=value format", f)
+		}
+		if err := setAPIField(fields, key, value); err != nil {
+			return nil, err
+		}
+	}
+	for _, f := range typedFields {
+		key, value, ok := strings.Cut(f, "=")
+		if !ok {
+			return nil, fmt.Errorf("field %q must be in key=value format", f)
+		}
+		typed, err := typedFieldValue(value, stdin)
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %w", key, err)
+		}
+		if err := setAPIField(fields, key, typed); err != nil {
+			return nil, err
+		}
+	}
+	return fields, nil
+}
+
+func typedFieldValue(value string, stdin io.Reader) (any, error) {
+	if strings.HasPrefix(value, "@") {
+		data, err := readInput(value[1:], stdin)
+		if err != nil {
+			return nil, err
+		}
+		return string(data), nil
+	}
+	switch value {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+	if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return n, nil
+	}
+	if f, err := strconv.ParseFloat(value, 64); err == nil {
+		return f, nil
+	}
+	return value, nil
+}
+
+// setAPIField assigns value at key, where key may address nested objects
+// with key[sub] and append to arrays with key[].
+func setAPIField(fields map[string]any, key string, value any) error {
+	name, rest, _ := strings.Cut(key, "[")
+	if name == "" {
+		return fmt.Errorf("invalid field key %q", key)
+	}
+	var segments []string
+	for rest != "" {
+		seg, after, ok := strings.Cut(rest, "]")
+		if !ok {
+			return fmt.Errorf("invalid field key %q: missing ]", key)
+		}
+		segments = append(segments, seg)
+		rest = strings.TrimPrefix(after, "[")
+	}
+
+	current := fields
+	for i, seg := range segments {
+		if seg == "" {
+			if i != len(segments)-1 {
+				return fmt.Errorf("invalid field key %q: [] must come last", key)
+			}
+			arr, _ := current[name].([]any)
+			current[name] = append(arr, value)
+			return nil
+		}
+		next, ok := current[name].(map[string]any)
+		if !ok {
+			next = map[string]any{}
+			current[name] = next
+		}
+		current, name = next, seg
+	}
+	current[name] = value
+	return nil
+}
+
+// addQueryFields appends top-level fields to the path's query string.
+func addQueryFields(path string, fields map[string]any) (string, error) {
+	u, err := url.Parse(path)
+	if err != nil {
+		return "", fmt.Errorf("parsing path: %w", err)
+	}
+	q := u.Query()
+	for k, v := range fields {
+		switch v := v.(type) {
+		case []any:
+			for _, item := range v {
+				q.Add(k, fmt.Sprint(item))
+			}
+		case map[string]any:
+			return "", fmt.Errorf("nested field %q cannot be sent as a query parameter", k)
+		case nil:
+			q.Add(k, "")
+		default:
+			q.Add(k, fmt.Sprint(v))
+		}
+	}
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+func parseAPIHeaders(headers []string) (http.Header, error) {
+	h := http.Header{}
+	for _, line := range headers {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("header %q must be in key:value format", line)
+		}
+		h.Add(strings.TrimSpace(key), strings.TrimSpace(value))
+	}
+	return h, nil
+}
+
+// readInput reads a file, or stdin when name is "-".
+func readInput(name string, stdin io.Reader) ([]byte, error) {
+	if name == "-" {
+		data, err := io.ReadAll(stdin)
+		if err != nil {
+			return nil, fmt.Errorf("reading stdin: %w", err)
+		}
+		return data, nil
+	}
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", name, err)
+	}
+	return data, nil
+}
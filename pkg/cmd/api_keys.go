@@ -37,7 +37,11 @@ var akCreateCmd = cli.Command{Name: "create", Usage: "Create API key", Flags: pa
 	if err != nil {
 		return err
 	}
-	res, err := client.PostJSON(ctx, "/auth/v2/apiKeys", []byte(cmd.String("payload")))
+	body, err := loadPayload(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := client.PostJSON(ctx, "/auth/v2/apiKeys", body)
 	if err != nil {
 		return err
 	}
@@ -69,14 +73,18 @@ var akUpdateCmd = cli.Command{Name: "update", Usage: "Update API key", ArgsUsage
 	if err != nil {
 		return err
 	}
-	res, err := client.PutJSON(ctx, "/auth/v2/apiKeys/"+args[0], []byte(cmd.String("payload")))
+	body, err := loadPayload(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := client.PutJSON(ctx, "/auth/v2/apiKeys/"+args[0], body)
 	if err != nil {
 		return err
 	}
 	return ShowResult(os.Stdout, res, cmd.Root().String("output"), cmd.Root().String("transform"))
 }, HideHelpCommand: true}
 
-var akDeleteCmd = cli.Command{Name: "delete", Usage: "Delete API key", ArgsUsage: "<id>", Action: func(ctx context.Context, cmd *cli.Command) error {
+var akDeleteCmd = cli.Command{Name: "delete", Usage: "Delete API key", ArgsUsage: "<id>", Action: confirm(destructive{action: "delete API key %s"}, func(ctx context.Context, cmd *cli.Command) error {
 	args := cmd.Args().Slice()
 	if len(args) < 1 {
 		return fmt.Errorf("API key ID required")
@@ -91,7 +99,7 @@ var akDeleteCmd = cli.Command{Name: "delete", Usage: "Delete API key", ArgsUsage
 	}
 	fmt.Fprintf(os.Stderr, "Deleted API key %s\n", args[0])
 	return nil
-}, HideHelpCommand: true}
+}), HideHelpCommand: true}
 
 var akValidateCmd = cli.Command{Name: "validate", Usage: "Validate an API key", Flags: []cli.Flag{&cli.StringFlag{Name: "key", Required: true}}, Action: func(ctx context.Context, cmd *cli.Command) error {
 	client, err := NewClient(cmd)
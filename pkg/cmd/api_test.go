@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPICommandFields(t *testing.T) {
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"POST /apiKeys/v1/keys/abc/whiteListedIps": func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "yes", r.Header.Get("X-Custom"))
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, map[string]any{
+				"ip":      "1.2.3.4",
+				"enabled": true,
+				"port":    float64(22),
+				"tags":    []any{"a", "b"},
+				"meta":    map[string]any{"owner": "ops"},
+			}, body)
+			jsonResponse(w, 201, map[string]any{"ip": "1.2.3.4"})
+		},
+	})
+	defer srv.Close()
+
+	stdout, _, err := runCLI(t, srv.URL, []string{
+		"--output", "raw",
+		"api", "POST", "/apiKeys/v1/keys/abc/whiteListedIps",
+		"-f", "ip=1.2.3.4", "-F", "enabled=true", "-F", "port=22",
+		"-f", "tags[]=a", "-f", "tags[]=b", "-f", "meta[owner]=ops",
+		"-H", "X-Custom: yes",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `{"ip":"1.2.3.4"}`+"\n", stdout)
+
+	// Field and header values may contain commas.
+	var got map[string]any
+	var accept string
+	srv = newTestServer(t, map[string]http.HandlerFunc{
+		"POST /x": func(w http.ResponseWriter, r *http.Request) {
+			accept = r.Header.Get("Accept")
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+			jsonResponse(w, 200, got)
+		},
+	})
+	defer srv.Close()
+	_, _, err = runCLI(t, srv.URL, []string{"api", "POST", "/x", "-f", "body=hello, world", "-F", "n=1", "-H", "Accept: a, b"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"body": "hello, world", "n": float64(1)}, got)
+	assert.Equal(t, "a, b", accept)
+}
+
+func TestAPICommandGetQueryAndPaginate(t *testing.T) {
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"GET /publicCloud/v1/targetGroups": func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "eu-west-3", r.URL.Query().Get("region"))
+			assert.Equal(t, "yes", r.Header.Get("X-Custom"))
+			items := []map[string]any{{"id": "tg-1"}}
+			if r.URL.Query().Get("offset") == "1" {
+				items = []map[string]any{{"id": "tg-2"}}
+			}
+			jsonResponse(w, 200, map[string]any{
+				"targetGroups": items,
+				"_metadata":    map[string]any{"totalCount": 2},
+			})
+		},
+	})
+	defer srv.Close()
+
+	stdout, _, err := runCLI(t, srv.URL, []string{
+		"--output", "raw", "--transform", "targetGroups.#.id",
+		"api", "/publicCloud/v1/targetGroups?region=eu-west-3", "--paginate", "-H", "X-Custom: yes",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `["tg-1","tg-2"]`+"\n", stdout)
+}
@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/kernel/leaseweb-cli/pkg/leaseweb"
+	"github.com/tidwall/gjson"
+	"github.com/urfave/cli/v3"
+)
+
+var authCmd = cli.Command{
+	Name:            "auth",
+	Usage:           "Inspect the API key in use",
+	Commands:        []*cli.Command{&authStatusCmd},
+	HideHelpCommand: true,
+}
+
+var authStatusCmd = cli.Command{
+	Name:  "status",
+	Usage: "Validate the active API key and show its name, expiry and IP whitelist",
+	Description: "The validation endpoint is not part of the published API spec, so the name and expiry\n" +
+		"are read from the fields it is known to return and may be empty.",
+	Action:          handleAuthStatus,
+	HideHelpCommand: true,
+}
+
+// authStatus is what `lw auth status` reports about the active key.
+type authStatus struct {
+	Profile        string   `json:"profile"`
+	KeySource      string   `json:"keySource"`
+	BaseURL        string   `json:"baseUrl"`
+	ID             string   `json:"id,omitempty"`
+	Name           string   `json:"name"`
+	ExpiresAt      string   `json:"expiresAt"`
+	WhiteListedIps []string `json:"whiteListedIps"`
+}
+
+func handleAuthStatus(ctx context.Context, cmd *cli.Command) error {
+	profile, source := activeKeySource(cmd)
+	key, err := resolveAPIKey(cmd)
+	if err != nil {
+		return err
+	}
+	client, err := NewClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	info, err := validateAPIKey(ctx, client, key)
+	if err != nil {
+		return fmt.Errorf("API key of profile %s (%s) failed validation: %w", profile, source, err)
+	}
+	status := authStatus{
+		Profile:        profile,
+		KeySource:      source,
+		BaseURL:        resolveBaseURL(cmd),
+		ID:             firstString(info, "id", "apiKeyId"),
+		Name:           firstString(info, "name", "reference"),
+		ExpiresAt:      firstString(info, "expiresAt", "expirationDate"),
+		WhiteListedIps: jsonStrings(firstResult(info, "whiteListedIps", "ipWhitelist")),
+	}
+	if status.ExpiresAt == "" {
+		status.ExpiresAt = "never"
+	}
+	if !firstResult(info, "whiteListedIps", "ipWhitelist").Exists() && status.ID != "" {
+		ips, err := client.Get(ctx, "/apiKeys/v1/keys/"+status.ID+"/whiteListedIps")
+		if err != nil {
+			return err
+		}
+		status.WhiteListedIps = jsonStrings(ips.Get("whiteListedIps"))
+	}
+
+	b, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+	return ShowJSON(os.Stdout, string(b), cmd.Root().String("output"), cmd.Root().String("transform"))
+}
+
+// activeKeySource names the profile whose API key is used and where that key
+// comes from. LEASEWEB_API_KEY takes precedence over every profile.
+func activeKeySource(cmd *cli.Command) (profile, source string) {
+	profile = "-"
+	if name, err := resolveProfile(cmd); err == nil {
+		profile = name
+	}
+	if os.Getenv("LEASEWEB_API_KEY") != "" {
+		return profile, "env LEASEWEB_API_KEY"
+	}
+	kind, detail := describeKeySource(loadConfig().Profiles[profile])
+	if detail == "" {
+		return profile, kind
+	}
+	return profile, kind + " " + detail
+}
+
+// validateAPIKey asks the API whether key is valid and returns what it knows
+// about the key. The endpoint is not in the published spec; callers read its
+// response on a best-effort basis.
+func validateAPIKey(ctx context.Context, client *Client, key string) (gjson.Result, error) {
+	body, _ := json.Marshal(map[string]string{"apiKey": key})
+	res, err := client.PostJSON(ctx, "/auth/v2/apiKeys/validate", body)
+	if err != nil {
+		return gjson.Result{}, err
+	}
+	if valid := res.Get("valid"); valid.Exists() && !valid.Bool() {
+		return gjson.Result{}, fmt.Errorf("%w: the API reports the key as not valid", leaseweb.ErrUnauthorized)
+	}
+	return res, nil
+}
+
+// firstResult returns the first of paths that exists in res.
+func firstResult(res gjson.Result, paths ...string) gjson.Result {
+	for _, p := range paths {
+		if v := res.Get(p); v.Exists() {
+			return v
+		}
+	}
+	return gjson.Result{}
+}
+
+func firstString(res gjson.Result, paths ...string) string {
+	return firstResult(res, paths...).String()
+}
+
+// jsonStrings returns the items of a JSON array as strings.
+func jsonStrings(res gjson.Result) []string {
+	list := []string{}
+	for _, item := range res.Array() {
+		if s := item.String(); s != "" {
+			list = append(list, s)
+		}
+	}
+	return list
+}
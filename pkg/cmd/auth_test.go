@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func TestAuthStatusAndDoctor(t *testing.T) {
+	rejected := []string{"bad-key"}
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"POST /auth/v2/apiKeys/validate": func(w http.ResponseWriter, r *http.Request) {
+			var body struct {
+				APIKey string `json:"apiKey"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if slices.Contains(rejected, body.APIKey) {
+				jsonResponse(w, 401, map[string]any{"errorMessage": "Invalid API key"})
+				return
+			}
+			jsonResponse(w, 200, map[string]any{"id": "k1", "name": "ci", "expiresAt": "2030-01-01T00:00:00Z"})
+		},
+		"GET /apiKeys/v1/keys/k1/whiteListedIps": func(w http.ResponseWriter, r *http.Request) {
+			jsonResponse(w, 200, map[string]any{"whiteListedIps": []string{"192.0.2.1"}})
+		},
+		"GET /": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(404)
+		},
+	})
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".config", "lw"), 0700))
+	configPath := filepath.Join(dir, ".config", "lw", "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(`default_profile: good
+profiles:
+  good:
+    api_key: good-key
+  bad:
+    api_key: bad-key
+`), 0644))
+
+	stdout, _, err := runCLI(t, srv.URL, []string{"-o", "json", "auth", "status"})
+	require.NoError(t, err)
+	assert.Equal(t, "good", gjson.Get(stdout, "profile").String())
+	assert.Equal(t, "env LEASEWEB_API_KEY", gjson.Get(stdout, "keySource").String())
+	assert.Equal(t, "ci", gjson.Get(stdout, "name").String())
+	assert.Equal(t, "2030-01-01T00:00:00Z", gjson.Get(stdout, "expiresAt").String())
+	assert.Equal(t, "192.0.2.1", gjson.Get(stdout, "whiteListedIps.0").String())
+	assert.False(t, gjson.Get(stdout, "capabilities").Exists())
+
+	// The key sent for validation reaches neither cassettes nor dry-run
+	// output, and the recording still replays.
+	cassettes := filepath.Join(dir, "cassettes")
+	_, _, err = runCLI(t, srv.URL, []string{"--record", cassettes, "auth", "status"})
+	require.NoError(t, err)
+	entries, err := os.ReadDir(cassettes)
+	require.NoError(t, err)
+	require.NotEmpty(t, entries)
+	for _, e := range entries {
+		b, err := os.ReadFile(filepath.Join(cassettes, e.Name()))
+		require.NoError(t, err)
+		assert.NotContains(t, string(b), "test-key", e.Name())
+	}
+	stdout, _, err = runCLI(t, srv.URL, []string{"-o", "json", "--replay", cassettes, "auth", "status"})
+	require.NoError(t, err)
+	assert.Equal(t, "ci", gjson.Get(stdout, "name").String())
+	stdout, _, _ = runCLI(t, srv.URL, []string{"--dry-run=curl", "auth", "status"})
+	assert.NotContains(t, stdout, "test-key")
+	assert.Contains(t, stdout, `{"apiKey":"REDACTED"}`)
+
+	rejected = append(rejected, "test-key")
+	_, _, err = runCLI(t, srv.URL, []string{"auth", "status"})
+	require.Error(t, err)
+	assert.Equal(t, ExitAuth, ExitCode(err))
+
+	// The config file is readable by others and one profile's key is
+	// rejected.
+	stdout, _, err = runCLI(t, srv.URL, []string{"-o", "json", "config", "doctor"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "config file, profile bad")
+	assert.Contains(t, gjson.Get(stdout, `#(check=="config file").detail`).String(), "chmod 600")
+	assert.Equal(t, "ok", gjson.Get(stdout, `#(check=="base URL").status`).String())
+	assert.Equal(t, "ok", gjson.Get(stdout, `#(check=="clock skew").status`).String())
+	assert.Equal(t, "ok", gjson.Get(stdout, `#(check=="profile good").status`).String())
+	assert.Contains(t, gjson.Get(stdout, `#(check=="profile bad").detail`).String(), "Invalid API key")
+
+	require.NoError(t, os.Chmod(configPath, 0600))
+	stdout, _, err = runCLI(t, srv.URL, []string{"-o", "json", "config", "doctor"})
+	require.Error(t, err)
+	assert.Equal(t, "1 of 5 checks failed: profile bad", err.Error())
+	assert.Equal(t, "ok", gjson.Get(stdout, `#(check=="config file").status`).String())
+
+	// Profile keys are validated through the same client as other commands,
+	// so the global flags apply to them.
+	doctorCassettes := filepath.Join(dir, "doctor-cassettes")
+	_, _, err = runCLI(t, srv.URL, []string{"--record", doctorCassettes, "config", "doctor"})
+	require.Error(t, err)
+	entries, err = os.ReadDir(doctorCassettes)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+}
@@ -0,0 +1,302 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+)
+
+// Bookmarks name resources in the bookmarks section of the config file. Any
+// command taking a resource ID accepts @name for a bookmark and ref:text for
+// the resource whose reference is text.
+
+// Bookmark is a named resource.
+type Bookmark struct {
+	// Type is the top-level command managing the resource, e.g.
+	// dedicated-servers.
+	Type string `koanf:"type" json:"type"`
+	ID   string `koanf:"id" json:"id"`
+}
+
+// referenceLookup is the list endpoint used to find resources of a type by
+// their reference.
+type referenceLookup struct {
+	path       string
+	collection string
+	// field is where an item of the collection holds its reference.
+	field string
+}
+
+// referenceLookups are the resource types that ref: works for, by top-level
+// command name.
+var referenceLookups = map[string]referenceLookup{
+	"colocations":       {"/bareMetals/v2/colocations", "colocations", "contract.reference"},
+	"dedicated-racks":   {"/bareMetals/v2/privateRacks", "privateRacks", "contract.reference"},
+	"dedicated-servers": {"/bareMetals/v2/servers", "servers", "contract.reference"},
+	"instances":         {"/publicCloud/v1/instances", "instances", "reference"},
+	"load-balancers":    {"/publicCloud/v1/loadBalancers", "loadBalancers", "reference"},
+	"network-equipment": {"/bareMetals/v2/networkEquipments", "networkEquipments", "contract.reference"},
+	"services":          {"/services/v1/services", "services", "reference"},
+	"vps":               {"/publicCloud/v1/vps/", "vps", "reference"},
+}
+
+// unresolvedCommands are the top-level commands whose arguments are never
+// taken for bookmarks or references.
+var unresolvedCommands = []string{"alias", "api", "bookmark", "config", "mock-server", "schema"}
+
+var bookmarkCmd = cli.Command{
+	Name:  "bookmark",
+	Usage: "Manage named resources, usable as @name wherever an ID is expected",
+	Commands: []*cli.Command{
+		&bookmarkAddCmd,
+		&bookmarkListCmd,
+		&bookmarkDeleteCmd,
+	},
+	HideHelpCommand: true,
+}
+
+var bookmarkAddCmd = cli.Command{
+	Name:  "add",
+	Usage: "Bookmark a resource",
+	Description: "The type is the command managing the resource, or one of its aliases. The ID may be given\n" +
+		"as ref:<reference> to bookmark the resource with that reference:\n\n" +
+		"   lw bookmark add db1 ds 12490707\n" +
+		"   lw bookmark add web instances ref:web-01\n" +
+		"   lw ds get @db1",
+	ArgsUsage:       "<name> <type> <id>",
+	Action:          handleBookmarkAdd,
+	HideHelpCommand: true,
+}
+
+var bookmarkListCmd = cli.Command{
+	Name:            "list",
+	Usage:           "List bookmarks",
+	Action:          handleBookmarkList,
+	HideHelpCommand: true,
+}
+
+var bookmarkDeleteCmd = cli.Command{
+	Name:            "delete",
+	Aliases:         []string{"rm"},
+	Usage:           "Delete a bookmark",
+	ArgsUsage:       "<name>",
+	Action:          handleBookmarkDelete,
+	HideHelpCommand: true,
+}
+
+func handleBookmarkAdd(ctx context.Context, cmd *cli.Command) error {
+	args := configArgs(cmd)
+	if len(args) != 3 {
+		return fmt.Errorf("usage: lw bookmark add <name> <type> <id>")
+	}
+	name, id := args[0], args[2]
+	if !aliasNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid bookmark name %q: use letters, digits, - and _", name)
+	}
+	group := findCommand(Command.Commands, args[1])
+	if group == nil || len(group.Commands) == 0 || slices.Contains(unresolvedCommands, group.Name) {
+		return fmt.Errorf("unknown resource type %q; use a command name such as dedicated-servers or ds", args[1])
+	}
+	if ref, ok := strings.CutPrefix(id, "ref:"); ok {
+		client, err := NewClient(cmd)
+		if err != nil {
+			return err
+		}
+		if id, err = findByReference(ctx, client, group.Name, ref); err != nil {
+			return err
+		}
+	}
+	if id == "" || strings.HasPrefix(id, "@") {
+		return fmt.Errorf("invalid ID %q", id)
+	}
+
+	doc, err := readConfigDoc()
+	if err != nil {
+		return err
+	}
+	root := doc.Content[0]
+	setNode(root, []string{"bookmarks", name, "type"}, group.Name, "!!str")
+	setNode(root, []string{"bookmarks", name, "id"}, id, "!!str")
+	if err := saveConfigDoc(doc); err != nil {
+		return fmt.Errorf("writing config: %w", err)
+	}
+	fmt.Printf("Bookmarked %s %s as @%s\n", group.Name, id, name)
+	return nil
+}
+
+func handleBookmarkList(_ context.Context, cmd *cli.Command) error {
+	bookmarks := loadConfig().Bookmarks
+	format := cmd.Root().String("output")
+	if format != "auto" {
+		if bookmarks == nil {
+			bookmarks = map[string]Bookmark{}
+		}
+		b, err := json.Marshal(bookmarks)
+		if err != nil {
+			return err
+		}
+		return ShowJSON(os.Stdout, string(b), format, cmd.Root().String("transform"))
+	}
+
+	if len(bookmarks) == 0 {
+		fmt.Fprintln(os.Stderr, "No bookmarks defined. Add one with 'lw bookmark add <name> <type> <id>'.")
+		return nil
+	}
+	names := make([]string, 0, len(bookmarks))
+	for name := range bookmarks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	table := NewTableWriter(os.Stdout, "NAME", "TYPE", "ID")
+	for _, name := range names {
+		b := bookmarks[name]
+		table.AddRow("@"+name, b.Type, b.ID)
+	}
+	table.Render()
+	return nil
+}
+
+func handleBookmarkDelete(_ context.Context, cmd *cli.Command) error {
+	args := configArgs(cmd)
+	if len(args) != 1 {
+		return fmt.Errorf("usage: lw bookmark delete <name>")
+	}
+	name := strings.TrimPrefix(args[0], "@")
+	doc, err := readConfigDoc()
+	if err != nil {
+		return err
+	}
+	root := doc.Content[0]
+	if !deleteNode(root, "bookmarks", name) {
+		return fmt.Errorf("bookmark %q not found", name)
+	}
+	if len(mappingKeys(lookupNode(root, "bookmarks"))) == 0 {
+		deleteNode(root, "bookmarks")
+	}
+	if err := saveConfigDoc(doc); err != nil {
+		return fmt.Errorf("writing config: %w", err)
+	}
+	fmt.Printf("Deleted bookmark %q\n", name)
+	return nil
+}
+
+// idArguments replaces @name and ref:text arguments of a command with the
+// IDs they stand for before its action runs.
+type idArguments struct {
+	cmd *cli.Command
+	// group is the top-level command the command belongs to.
+	group string
+}
+
+func (a *idArguments) HasName(string) bool { return false }
+func (a *idArguments) Usage() string       { return "" }
+func (a *idArguments) Get() any            { return nil }
+
+func (a *idArguments) Parse(args []string) ([]string, error) {
+	out := slices.Clone(args)
+	var bookmarks map[string]Bookmark
+	for i, arg := range args {
+		switch {
+		case strings.HasPrefix(arg, "@") && len(arg) > 1:
+			if bookmarks == nil {
+				bookmarks = loadConfig().Bookmarks
+			}
+			b, ok := bookmarks[arg[1:]]
+			if !ok {
+				return nil, fmt.Errorf("unknown bookmark %s; see 'lw bookmark list'", arg)
+			}
+			// A bookmark only stands for an ID of its own type, so that
+			// e.g. a server ID is never sent to the instances API.
+			if g := findCommand(Command.Commands, b.Type); g == nil || g.Name != a.group {
+				return nil, fmt.Errorf("bookmark %s is a %s bookmark and cannot be used with %s", arg, b.Type, a.group)
+			}
+			out[i] = b.ID
+		case strings.HasPrefix(arg, "ref:"):
+			client, err := NewClient(a.cmd)
+			if err != nil {
+				return nil, err
+			}
+			id, err := findByReference(context.Background(), client, a.group, arg[len("ref:"):])
+			if err != nil {
+				return nil, err
+			}
+			out[i] = id
+		}
+	}
+	return out, nil
+}
+
+// addIDResolvers lets every command under root that takes arguments accept
+// bookmarks and references in place of IDs.
+func addIDResolvers(root *cli.Command) {
+	for _, group := range root.Commands {
+		if slices.Contains(unresolvedCommands, group.Name) {
+			continue
+		}
+		var walk func(c *cli.Command)
+		walk = func(c *cli.Command) {
+			// Subcommands are shared by every root, so one may have its
+			// resolver already.
+			resolved := slices.ContainsFunc(c.Arguments, func(a cli.Argument) bool {
+				_, ok := a.(*idArguments)
+				return ok
+			})
+			if c.Action != nil && c.ArgsUsage != "" && !c.SkipFlagParsing && !resolved {
+				c.Arguments = append(c.Arguments, &idArguments{cmd: c, group: group.Name})
+			}
+			for _, sub := range c.Commands {
+				walk(sub)
+			}
+		}
+		walk(group)
+	}
+}
+
+// findByReference returns the ID of the only resource of type group whose
+// reference is ref.
+func findByReference(ctx context.Context, client *Client, group, ref string) (string, error) {
+	lookup, ok := referenceLookups[group]
+	if !ok {
+		types := make([]string, 0, len(referenceLookups))
+		for t := range referenceLookups {
+			types = append(types, t)
+		}
+		sort.Strings(types)
+		return "", fmt.Errorf("ref: lookups do not work for %s; they work for %s", group, strings.Join(types, ", "))
+	}
+	if ref == "" {
+		return "", fmt.Errorf("ref: needs a reference, e.g. ref:web-01")
+	}
+	q := url.Values{"reference": {ref}, "limit": {"50"}}
+	res, err := client.Get(ctx, lookup.path+"?"+q.Encode())
+	if err != nil {
+		return "", fmt.Errorf("looking up reference %q: %w", ref, err)
+	}
+	// The API filter may match parts of references, so only exact matches
+	// count.
+	var ids, near []string
+	for _, item := range res.Get(lookup.collection).Array() {
+		id := item.Get("id").String()
+		if r := item.Get(lookup.field).String(); r == ref {
+			ids = append(ids, id)
+		} else {
+			near = append(near, fmt.Sprintf("%s (%s)", id, r))
+		}
+	}
+	switch {
+	case len(ids) == 1:
+		return ids[0], nil
+	case len(ids) > 1:
+		return "", fmt.Errorf("reference %q matches %d %s: %s; use an ID instead", ref, len(ids), group, strings.Join(ids, ", "))
+	case len(near) > 0:
+		return "", fmt.Errorf("no %s resource has reference %q; similar: %s", group, ref, strings.Join(near, ", "))
+	}
+	return "", fmt.Errorf("no %s resource has reference %q", group, ref)
+}
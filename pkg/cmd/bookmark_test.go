@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func TestBookmarks(t *testing.T) {
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"GET /bareMetals/v2/servers": func(w http.ResponseWriter, r *http.Request) {
+			servers := []map[string]any{}
+			for _, s := range []struct{ id, ref string }{{"101", "web-01"}, {"102", "web-010"}, {"201", "db"}, {"202", "db"}} {
+				if strings.Contains(s.ref, r.URL.Query().Get("reference")) {
+					servers = append(servers, map[string]any{"id": s.id, "contract": map[string]any{"reference": s.ref}})
+				}
+			}
+			jsonResponse(w, 200, map[string]any{"servers": servers})
+		},
+		"GET /bareMetals/v2/servers/{id}": func(w http.ResponseWriter, r *http.Request) {
+			jsonResponse(w, 200, map[string]any{"id": r.PathValue("id")})
+		},
+	})
+	defer srv.Close()
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+
+	stdout, _, err := runCLI(t, "", []string{"bookmark", "add", "db1", "ds", "12490707"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "Bookmarked dedicated-servers 12490707 as @db1")
+	_, _, err = runCLI(t, srv.URL, []string{"bookmark", "add", "web", "dedicated-servers", "ref:web-01"})
+	require.NoError(t, err)
+
+	stdout, _, err = runCLI(t, "", []string{"-o", "json", "bookmark", "list"})
+	require.NoError(t, err)
+	assert.Equal(t, "dedicated-servers", gjson.Get(stdout, "db1.type").String())
+	assert.Equal(t, "101", gjson.Get(stdout, "web.id").String())
+
+	stdout, _, err = runCLI(t, srv.URL, []string{"-o", "json", "ds", "get", "@db1"})
+	require.NoError(t, err)
+	assert.Equal(t, "12490707", gjson.Get(stdout, "id").String())
+
+	// Only exact reference matches count.
+	stdout, _, err = runCLI(t, srv.URL, []string{"-o", "json", "ds", "get", "ref:web-01"})
+	require.NoError(t, err)
+	assert.Equal(t, "101", gjson.Get(stdout, "id").String())
+
+	_, _, err = runCLI(t, srv.URL, []string{"ds", "get", "ref:db"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `reference "db" matches 2 dedicated-servers: 201, 202`)
+
+	_, _, err = runCLI(t, srv.URL, []string{"ds", "get", "@nope"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown bookmark @nope")
+
+	// A bookmark is only accepted by commands of its own type.
+	_, _, err = runCLI(t, srv.URL, []string{"instances", "get", "@db1"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "bookmark @db1 is a dedicated-servers bookmark and cannot be used with instances")
+	_, _, err = runCLI(t, srv.URL, []string{"--yes", "instances", "terminate", "@db1"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot be used with instances")
+
+	_, _, err = runCLI(t, "", []string{"bookmark", "delete", "@db1"})
+	require.NoError(t, err)
+	stdout, _, err = runCLI(t, "", []string{"-o", "json", "bookmark", "list"})
+	require.NoError(t, err)
+	assert.False(t, gjson.Get(stdout, "db1").Exists())
+}
@@ -0,0 +1,321 @@
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kernel/leaseweb-cli/pkg/leaseweb"
+	"github.com/urfave/cli/v3"
+)
+
+// The response cache keeps GET responses of catalog endpoints, which rarely
+// change, on disk. It is enabled with the cache setting. A cached response
+// is used until the TTL of its endpoint passes; after that it is
+// revalidated with If-None-Match when the API sent an ETag for it.
+
+// cachedEndpoint is an endpoint whose responses may be cached, with the
+// paths below it.
+type cachedEndpoint struct {
+	path string
+	ttl  time.Duration
+}
+
+var cachedEndpoints = []cachedEndpoint{
+	{"/bareMetals/v2/controlPanels", 24 * time.Hour},
+	{"/bareMetals/v2/operatingSystems", 24 * time.Hour},
+	{"/bareMetals/v2/rescueImages", 24 * time.Hour},
+	{"/cdn/v2/edgeLocations", 24 * time.Hour},
+	// Prices and stock of products change more often than the rest.
+	{"/ordering/v1/products", time.Hour},
+	{"/publicCloud/v1/instanceTypes", 24 * time.Hour},
+	{"/publicCloud/v1/regions", 24 * time.Hour},
+}
+
+// cachedEndpointFor returns the cached endpoint path belongs to.
+func cachedEndpointFor(path string) (cachedEndpoint, bool) {
+	p, _, _ := strings.Cut(path, "?")
+	for _, e := range cachedEndpoints {
+		if p == e.path || strings.HasPrefix(p, e.path+"/") {
+			return e, true
+		}
+	}
+	return cachedEndpoint{}, false
+}
+
+// cacheEntry is a cached response, stored as a JSON file.
+type cacheEntry struct {
+	Profile string          `json:"profile"`
+	Path    string          `json:"path"`
+	ETag    string          `json:"etag,omitempty"`
+	Stored  time.Time       `json:"stored"`
+	Body    json.RawMessage `json:"body"`
+}
+
+// responseCache stores the responses of one profile and base URL.
+type responseCache struct {
+	dir     string
+	profile string
+	baseURL string
+	// refresh skips fresh entries; they are revalidated or fetched again.
+	refresh bool
+	debug   bool
+}
+
+// newResponseCache returns the cache for profile, or nil when caching is
+// off for this invocation.
+func newResponseCache(cmd *cli.Command, profile string, settings Settings) *responseCache {
+	root := cmd.Root()
+	if !settings.cacheEnabled() || root.Bool("no-cache") ||
+		root.String("record") != "" || root.String("replay") != "" {
+		return nil
+	}
+	dir, err := responseCacheDir()
+	if err != nil {
+		return nil
+	}
+	return &responseCache{
+		dir:     dir,
+		profile: profile,
+		baseURL: baseURLFor(settings),
+		refresh: root.Bool("refresh"),
+		debug:   root.Bool("debug"),
+	}
+}
+
+func responseCacheDir() (string, error) {
+	dir, err := cacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "responses"), nil
+}
+
+// get returns the response to a GET of path, from the cache when it is
+// fresh. send performs the request with the given extra headers.
+func (c *responseCache) get(path string, ttl time.Duration, send func(http.Header) (*leaseweb.Response, error)) (*leaseweb.Response, error) {
+	file := c.file(path)
+	entry, _ := readCacheEntry(file)
+	if entry != nil && !c.refresh && time.Since(entry.Stored) < ttl {
+		c.logf("hit", path)
+		return &leaseweb.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: entry.Body}, nil
+	}
+
+	var header http.Header
+	if entry != nil && entry.ETag != "" {
+		header = http.Header{"If-None-Match": {entry.ETag}}
+	}
+	resp, err := send(header)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotModified && entry != nil {
+		c.logf("revalidated", path)
+		entry.Stored = time.Now()
+		c.write(file, entry)
+		return &leaseweb.Response{StatusCode: http.StatusOK, Header: resp.Header, Body: entry.Body}, nil
+	}
+	if resp.StatusCode == http.StatusOK && json.Valid(resp.Body) {
+		c.logf("stored", path)
+		c.write(file, &cacheEntry{
+			Profile: c.profile,
+			Path:    path,
+			ETag:    resp.Header.Get("ETag"),
+			Stored:  time.Now(),
+			Body:    resp.Body,
+		})
+	}
+	return resp, nil
+}
+
+func (c *responseCache) file(path string) string {
+	h := sha256.Sum256([]byte(c.profile + "\x00" + c.baseURL + "\x00" + path))
+	return filepath.Join(c.dir, hex.EncodeToString(h[:16])+".json")
+}
+
+// write stores entry in file. Failures only cost a request next time, so
+// they are ignored.
+func (c *responseCache) write(file string, entry *cacheEntry) {
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		return
+	}
+	_ = os.WriteFile(file, b, 0600)
+}
+
+func (c *responseCache) logf(what, path string) {
+	if c.debug {
+		log.Printf("Cache: %s %s\n", what, path)
+	}
+}
+
+func readCacheEntry(file string) (*cacheEntry, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var e cacheEntry
+	if err := json.Unmarshal(b, &e); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// cacheFiles returns the entry files in the response cache with their
+// entries. Files that do not parse are returned with a nil entry.
+func cacheFiles() (map[string]*cacheEntry, error) {
+	dir, err := responseCacheDir()
+	if err != nil {
+		return nil, err
+	}
+	names, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string]*cacheEntry, len(names))
+	for _, name := range names {
+		files[name], _ = readCacheEntry(name)
+	}
+	return files, nil
+}
+
+var cacheCmd = cli.Command{
+	Name:  "cache",
+	Usage: "Manage the on-disk cache of catalog responses and completions",
+	Commands: []*cli.Command{
+		&cacheClearCmd,
+		&cacheStatsCmd,
+	},
+	HideHelpCommand: true,
+}
+
+var cacheClearCmd = cli.Command{
+	Name:            "clear",
+	Usage:           "Delete cached responses and completions; with --profile only those of that profile",
+	Action:          handleCacheClear,
+	HideHelpCommand: true,
+}
+
+var cacheStatsCmd = cli.Command{
+	Name:            "stats",
+	Usage:           "Show what the response cache holds",
+	Action:          handleCacheStats,
+	HideHelpCommand: true,
+}
+
+func handleCacheClear(_ context.Context, cmd *cli.Command) error {
+	profile := cmd.Root().String("profile")
+	files, err := cacheFiles()
+	if err != nil {
+		return err
+	}
+	removed := 0
+	for name, e := range files {
+		if profile != "" && (e == nil || e.Profile != profile) {
+			continue
+		}
+		if err := os.Remove(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		removed++
+	}
+	if profile == "" {
+		dir, err := cacheDir()
+		if err != nil {
+			return err
+		}
+		if err := os.RemoveAll(filepath.Join(dir, "completion")); err != nil {
+			return err
+		}
+		fmt.Printf("Removed %d cached responses and the completion cache\n", removed)
+		return nil
+	}
+	fmt.Printf("Removed %d cached responses of profile %q\n", removed, profile)
+	return nil
+}
+
+// cacheStat sums up the cached responses of an endpoint.
+type cacheStat struct {
+	Endpoint string `json:"endpoint"`
+	TTL      string `json:"ttl"`
+	Entries  int    `json:"entries"`
+	Fresh    int    `json:"fresh"`
+	Bytes    int64  `json:"bytes"`
+}
+
+func handleCacheStats(_ context.Context, cmd *cli.Command) error {
+	files, err := cacheFiles()
+	if err != nil {
+		return err
+	}
+	stats := map[string]*cacheStat{}
+	for _, e := range cachedEndpoints {
+		stats[e.path] = &cacheStat{Endpoint: e.path, TTL: e.ttl.String()}
+	}
+	for name, entry := range files {
+		if entry == nil {
+			continue
+		}
+		e, ok := cachedEndpointFor(entry.Path)
+		if !ok {
+			continue
+		}
+		s := stats[e.path]
+		s.Entries++
+		if time.Since(entry.Stored) < e.ttl {
+			s.Fresh++
+		}
+		if info, err := os.Stat(name); err == nil {
+			s.Bytes += info.Size()
+		}
+	}
+	list := make([]cacheStat, 0, len(stats))
+	for _, s := range stats {
+		list = append(list, *s)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Endpoint < list[j].Endpoint })
+
+	format := cmd.Root().String("output")
+	if format != "auto" {
+		b, err := json.Marshal(list)
+		if err != nil {
+			return err
+		}
+		return ShowJSON(os.Stdout, string(b), format, cmd.Root().String("transform"))
+	}
+
+	dir, _ := responseCacheDir()
+	fmt.Fprintf(os.Stderr, "Cache directory: %s\n", dir)
+	if !resolveSettings(cmd).cacheEnabled() {
+		fmt.Fprintln(os.Stderr, "Caching is off for this profile; enable it with 'lw config set cache true'.")
+	}
+	table := NewTableWriter(os.Stdout, "ENDPOINT", "TTL", "ENTRIES", "FRESH", "SIZE")
+	for _, s := range list {
+		table.AddRow(s.Endpoint, s.TTL, fmt.Sprint(s.Entries), fmt.Sprint(s.Fresh), fmt.Sprintf("%.1f KiB", float64(s.Bytes)/1024))
+	}
+	table.Render()
+	return nil
+}
+
+// cacheDir returns the directory lw keeps cached data in.
+func cacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "lw"), nil
+}
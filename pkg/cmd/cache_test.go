@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func TestResponseCache(t *testing.T) {
+	var requests, notModified int
+	var lastIfNoneMatch string
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"GET /publicCloud/v1/regions": func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			lastIfNoneMatch = r.Header.Get("If-None-Match")
+			if lastIfNoneMatch == `"v1"` {
+				notModified++
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set("ETag", `"v1"`)
+			jsonResponse(w, 200, map[string]any{"regions": []map[string]any{{"name": "eu-west-3", "location": "Amsterdam"}}})
+		},
+	})
+	defer srv.Close()
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(dir, ".cache"))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".config", "lw"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".config", "lw", "config.yaml"), []byte("cache: true\n"), 0600))
+
+	regions := func(args ...string) string {
+		t.Helper()
+		stdout, _, err := runCLI(t, srv.URL, append([]string{"-o", "json"}, append(args, "instances", "regions")...))
+		require.NoError(t, err)
+		return gjson.Get(stdout, "regions.0.name").String()
+	}
+
+	assert.Equal(t, "eu-west-3", regions())
+	assert.Equal(t, "eu-west-3", regions())
+	assert.Equal(t, 1, requests)
+
+	// --refresh revalidates the cached response with its ETag.
+	assert.Equal(t, "eu-west-3", regions("--refresh"))
+	assert.Equal(t, 2, requests)
+	assert.Equal(t, 1, notModified)
+
+	// --no-cache neither reads nor revalidates.
+	assert.Equal(t, "eu-west-3", regions("--no-cache"))
+	assert.Equal(t, 3, requests)
+	assert.Empty(t, lastIfNoneMatch)
+
+	stdout, _, err := runCLI(t, "", []string{"-o", "json", "cache", "stats"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), gjson.Get(stdout, `#(endpoint=="/publicCloud/v1/regions").entries`).Int())
+	assert.Equal(t, int64(1), gjson.Get(stdout, `#(endpoint=="/publicCloud/v1/regions").fresh`).Int())
+
+	stdout, _, err = runCLI(t, "", []string{"cache", "clear"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "Removed 1 cached responses")
+	assert.Equal(t, "eu-west-3", regions())
+	assert.Equal(t, 4, requests)
+
+	// Without the cache setting, nothing is cached.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".config", "lw", "config.yaml"), []byte("cache: false\n"), 0600))
+	regions()
+	regions()
+	assert.Equal(t, 6, requests)
+}
@@ -0,0 +1,328 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/tidwall/gjson"
+)
+
+// cassette is one recorded request and its response, stored as a JSON file
+// in the directory given to --record and --replay.
+type cassette struct {
+	Request  cassetteRequest  `json:"request"`
+	Response cassetteResponse `json:"response"`
+}
+
+type cassetteRequest struct {
+	Method string `json:"method"`
+	// URL is the path and query of the request; the host is left out so a
+	// recording can be replayed against any base URL.
+	URL    string          `json:"url"`
+	Header http.Header     `json:"headers,omitempty"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+type cassetteResponse struct {
+	StatusCode int             `json:"status"`
+	Header     http.Header     `json:"headers,omitempty"`
+	Body       json.RawMessage `json:"body,omitempty"`
+	// BinaryBody holds bodies that are not valid UTF-8, such as PDFs.
+	BinaryBody []byte `json:"binaryBody,omitempty"`
+}
+
+// scrubbedHeaders are replaced before a request is written to disk.
+var scrubbedHeaders = []string{"X-Lsw-Auth", "Authorization"}
+
+// credentialFields are the top-level JSON body fields holding API keys, by
+// the path of the endpoints that send or return them: the key to validate
+// and the key returned when one is created. Their string values are replaced
+// before a body is written to disk or printed; bodies of other endpoints are
+// kept whole so their cassettes still match on replay.
+var credentialFields = []struct {
+	path   string
+	fields []string
+}{
+	{"/auth/v2/apiKeys", []string{"apiKey", "key", "secret"}},
+}
+
+// scrubBody returns the body b of a request to or response from path with
+// the values of its credentialFields replaced. Other bodies are returned as
+// is.
+func scrubBody(path string, b []byte) []byte {
+	var fields []string
+	for _, c := range credentialFields {
+		if strings.Contains(path, c.path) {
+			fields = c.fields
+			break
+		}
+	}
+	v := gjson.ParseBytes(b)
+	if fields == nil || !gjson.ValidBytes(b) || !v.IsObject() {
+		return b
+	}
+
+	var buf bytes.Buffer
+	scrubbed := false
+	buf.WriteByte('{')
+	v.ForEach(func(key, val gjson.Result) bool {
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		buf.WriteString(key.Raw)
+		buf.WriteByte(':')
+		if val.Type == gjson.String && slices.Contains(fields, key.String()) {
+			buf.WriteString(`"REDACTED"`)
+			scrubbed = true
+		} else {
+			buf.WriteString(val.Raw)
+		}
+		return true
+	})
+	buf.WriteByte('}')
+	if !scrubbed {
+		return b
+	}
+	return buf.Bytes()
+}
+
+// recorder is a transport that passes requests on to next and writes each
+// exchange to dir.
+type recorder struct {
+	dir  string
+	next http.RoundTripper
+
+	mu  sync.Mutex
+	seq int
+}
+
+func newRecorder(dir string, next http.RoundTripper) (*recorder, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("creating cassette directory: %w", err)
+	}
+	// Carry on numbering after cassettes left by earlier runs.
+	names, err := cassetteFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	r := &recorder{dir: dir, next: next}
+	for _, name := range names {
+		if n, err := strconv.Atoi(strings.SplitN(name, "-", 2)[0]); err == nil && n > r.seq {
+			r.seq = n
+		}
+	}
+	return r, nil
+}
+
+func (r *recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	header := req.Header.Clone()
+	for _, h := range scrubbedHeaders {
+		if header.Get(h) != "" {
+			header.Set(h, "REDACTED")
+		}
+	}
+	c := cassette{
+		Request: cassetteRequest{
+			Method: req.Method,
+			URL:    req.URL.RequestURI(),
+			Header: header,
+			Body:   cassetteBody(scrubBody(req.URL.Path, reqBody)),
+		},
+		Response: cassetteResponse{
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header,
+		},
+	}
+	if utf8.Valid(respBody) {
+		c.Response.Body = cassetteBody(scrubBody(req.URL.Path, respBody))
+	} else {
+		c.Response.BinaryBody = respBody
+	}
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.seq++
+	name := fmt.Sprintf("%04d-%s%s.json", r.seq, req.Method, cassetteSlug(req.URL.Path))
+	if err := os.WriteFile(filepath.Join(r.dir, name), append(b, '\n'), 0600); err != nil {
+		return nil, fmt.Errorf("writing cassette: %w", err)
+	}
+	return resp, nil
+}
+
+// replayer is a transport that answers requests from the cassettes in a
+// directory without touching the network. Cassettes matching a request are
+// played in order; once they are all used the last one is played again.
+type replayer struct {
+	dir       string
+	cassettes []cassette
+
+	mu   sync.Mutex
+	used []bool
+	last map[string]int
+}
+
+func newReplayer(dir string) (*replayer, error) {
+	names, err := cassetteFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no cassettes found in %s", dir)
+	}
+	r := &replayer{dir: dir, used: make([]bool, len(names)), last: map[string]int{}}
+	for _, name := range names {
+		b, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		var c cassette
+		if err := json.Unmarshal(b, &c); err != nil {
+			return nil, fmt.Errorf("reading cassette %s: %w", name, err)
+		}
+		r.cassettes = append(r.cassettes, c)
+	}
+	return r, nil
+}
+
+func (r *replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+	// Recorded bodies are scrubbed, so the request is scrubbed the same way
+	// to match them.
+	key := req.Method + " " + req.URL.RequestURI() + " " + string(cassetteBody(scrubBody(req.URL.Path, body)))
+
+	r.mu.Lock()
+	match := -1
+	for i, c := range r.cassettes {
+		if !r.used[i] && c.Request.Method+" "+c.Request.URL+" "+string(cassetteBody(c.Request.Body)) == key {
+			match = i
+			break
+		}
+	}
+	if match < 0 {
+		if i, ok := r.last[key]; ok {
+			match = i
+		}
+	}
+	if match >= 0 {
+		r.used[match] = true
+		r.last[key] = match
+	}
+	r.mu.Unlock()
+
+	if match < 0 {
+		return nil, fmt.Errorf("replay: no cassette in %s matches %s %s", r.dir, req.Method, req.URL.RequestURI())
+	}
+	c := r.cassettes[match].Response
+	header := c.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	respBody := c.BinaryBody
+	if len(c.Body) > 0 {
+		respBody = cassetteBody(c.Body)
+		if s := gjson.ParseBytes(respBody); s.Type == gjson.String {
+			respBody = []byte(s.Str)
+		}
+	}
+	return &http.Response{
+		StatusCode:    c.StatusCode,
+		Status:        fmt.Sprintf("%d %s", c.StatusCode, http.StatusText(c.StatusCode)),
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(respBody)),
+		ContentLength: int64(len(respBody)),
+		Request:       req,
+	}, nil
+}
+
+// readBody reads and replaces *body so it can still be sent on.
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+	b, err := io.ReadAll(*body)
+	(*body).Close()
+	if err != nil {
+		return nil, err
+	}
+	*body = io.NopCloser(bytes.NewReader(b))
+	return b, nil
+}
+
+// cassetteBody returns a JSON body compacted and anything else as a JSON
+// string.
+func cassetteBody(b []byte) json.RawMessage {
+	if len(bytes.TrimSpace(b)) == 0 {
+		return nil
+	}
+	var buf bytes.Buffer
+	if json.Valid(b) && json.Compact(&buf, b) == nil {
+		return buf.Bytes()
+	}
+	s, _ := json.Marshal(string(b))
+	return s
+}
+
+var slugRe = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+// cassetteSlug turns a URL path into a file name fragment.
+func cassetteSlug(path string) string {
+	slug := strings.Trim(slugRe.ReplaceAllString(path, "-"), "-")
+	if len(slug) > 80 {
+		slug = slug[:80]
+	}
+	if slug == "" {
+		return ""
+	}
+	return "-" + slug
+}
+
+// cassetteFiles returns the cassette file names in dir in playing order.
+func cassetteFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading cassette directory: %w", err)
+	}
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ".json") {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func TestRecordReplay(t *testing.T) {
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"GET /bareMetals/v2/servers/12345": func(w http.ResponseWriter, r *http.Request) {
+			jsonResponse(w, 200, map[string]any{"id": "12345", "contract": map[string]any{"reference": "web-01"}})
+		},
+		"PUT /hosting/v2/domains/example.com/resourceRecordSets/www/A": func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(body)
+		},
+		"POST /publicCloud/v1/instances/abc/tags": func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(body)
+		},
+	})
+	dir := t.TempDir() + "/cassettes"
+	get := []string{"-o", "raw", "ds", "get", "12345"}
+	put := []string{"-o", "raw", "domains", "dns-update", "example.com", "www", "A", "--payload", `{"content":["1.2.3.4"],"ttl":300}`}
+	tag := []string{"-o", "raw", "api", "/publicCloud/v1/instances/abc/tags", "-f", "key=env", "-f", "value=prod"}
+
+	recordedGet, _, err := runCLI(t, srv.URL, append([]string{"--record", dir}, get...))
+	require.NoError(t, err)
+	recordedPut, _, err := runCLI(t, srv.URL, append([]string{"--record", dir}, put...))
+	require.NoError(t, err)
+	recordedTag, _, err := runCLI(t, srv.URL, append([]string{"--record", dir}, tag...))
+	require.NoError(t, err)
+	srv.Close()
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, "0001-GET-bareMetals-v2-servers-12345.json", entries[0].Name())
+	cassette, err := os.ReadFile(dir + "/" + entries[0].Name())
+	require.NoError(t, err)
+	assert.NotContains(t, string(cassette), "test-key")
+	assert.Equal(t, "REDACTED", gjson.GetBytes(cassette, "request.headers.X-Lsw-Auth.0").String())
+	assert.Equal(t, "web-01", gjson.GetBytes(cassette, "response.body.contract.reference").String())
+
+	// Replays need no API key.
+	t.Setenv("HOME", t.TempDir())
+	stdout, _, err := runCLIWithKey(t, context.Background(), srv.URL, "", append([]string{"--replay", dir}, get...))
+	require.NoError(t, err)
+	assert.Equal(t, strings.TrimSpace(recordedGet), strings.TrimSpace(stdout))
+	stdout, _, err = runCLIWithKey(t, context.Background(), srv.URL, "", append([]string{"--replay", dir}, put...))
+	require.NoError(t, err)
+	assert.Equal(t, strings.TrimSpace(recordedPut), strings.TrimSpace(stdout))
+
+	// Only API key endpoints are scrubbed; a tag key is kept and replays.
+	cassette, err = os.ReadFile(dir + "/" + entries[2].Name())
+	require.NoError(t, err)
+	assert.Equal(t, "env", gjson.GetBytes(cassette, "request.body.key").String())
+	assert.Equal(t, "env", gjson.GetBytes(cassette, "response.body.key").String())
+	stdout, _, err = runCLIWithKey(t, context.Background(), srv.URL, "", append([]string{"--replay", dir}, tag...))
+	require.NoError(t, err)
+	assert.Equal(t, strings.TrimSpace(recordedTag), strings.TrimSpace(stdout))
+
+	_, _, err = runCLIWithKey(t, context.Background(), srv.URL, "", []string{"--replay", dir, "ds", "get", "99999"})
+	assert.ErrorContains(t, err, "replay: no cassette in "+dir+" matches GET /bareMetals/v2/servers/99999")
+}
@@ -42,10 +42,11 @@ func cdnCRUD(basePath string, minArgs int, argErr string) func(context.Context,
 		if err != nil {
 			return err
 		}
-		path := basePath
-		for i := 0; i < minArgs; i++ {
-			path = fmt.Sprintf(path, args[i])
+		vals := make([]any, minArgs)
+		for i := range vals {
+			vals[i] = args[i]
 		}
+		path := fmt.Sprintf(basePath, vals...)
 		res, err := client.Get(ctx, path)
 		if err != nil {
 			return err
@@ -64,11 +65,16 @@ func cdnPost(basePath string, minArgs int, argErr string) func(context.Context,
 		if err != nil {
 			return err
 		}
-		path := basePath
-		for i := 0; i < minArgs; i++ {
-			path = fmt.Sprintf(path, args[i])
+		vals := make([]any, minArgs)
+		for i := range vals {
+			vals[i] = args[i]
 		}
-		res, err := client.PostJSON(ctx, path, []byte(cmd.String("payload")))
+		path := fmt.Sprintf(basePath, vals...)
+		body, err := loadPayload(cmd)
+		if err != nil {
+			return err
+		}
+		res, err := client.PostJSON(ctx, path, body)
 		if err != nil {
 			return err
 		}
@@ -86,11 +92,16 @@ func cdnPut(basePath string, minArgs int, argErr string) func(context.Context, *
 		if err != nil {
 			return err
 		}
-		path := basePath
-		for i := 0; i < minArgs; i++ {
-			path = fmt.Sprintf(path, args[i])
+		vals := make([]any, minArgs)
+		for i := range vals {
+			vals[i] = args[i]
+		}
+		path := fmt.Sprintf(basePath, vals...)
+		body, err := loadPayload(cmd)
+		if err != nil {
+			return err
 		}
-		res, err := client.PutJSON(ctx, path, []byte(cmd.String("payload")))
+		res, err := client.PutJSON(ctx, path, body)
 		if err != nil {
 			return err
 		}
@@ -108,10 +119,11 @@ func cdnDel(basePath string, minArgs int, argErr string) func(context.Context, *
 		if err != nil {
 			return err
 		}
-		path := basePath
-		for i := 0; i < minArgs; i++ {
-			path = fmt.Sprintf(path, args[i])
+		vals := make([]any, minArgs)
+		for i := range vals {
+			vals[i] = args[i]
 		}
+		path := fmt.Sprintf(basePath, vals...)
 		_, err = client.Delete(ctx, path)
 		if err != nil {
 			return err
@@ -127,7 +139,7 @@ var cdnDistributionsListCmd = cli.Command{Name: "list", Usage: "List distributio
 	if err != nil {
 		return err
 	}
-	res, err := client.Get(ctx, "/cdn/v2/distributions?"+PaginationQuery(cmd))
+	res, err := GetList(ctx, client, cmd, "/cdn/v2/distributions?"+PaginationQuery(cmd))
 	if err != nil {
 		return err
 	}
@@ -137,21 +149,21 @@ var cdnDistributionsListCmd = cli.Command{Name: "list", Usage: "List distributio
 var cdnDistributionCreateCmd = cli.Command{Name: "create", Usage: "Create distribution", Flags: payloadFlag, Action: cdnPost("/cdn/v2/distributions", 0, ""), HideHelpCommand: true}
 var cdnDistributionGetCmd = cli.Command{Name: "get", ArgsUsage: "<id>", Action: cdnCRUD("/cdn/v2/distributions/%s", 1, "distribution ID required"), HideHelpCommand: true}
 var cdnDistributionUpdateCmd = cli.Command{Name: "update", ArgsUsage: "<id>", Flags: payloadFlag, Action: cdnPut("/cdn/v2/distributions/%s", 1, "distribution ID required"), HideHelpCommand: true}
-var cdnDistributionDeleteCmd = cli.Command{Name: "delete", ArgsUsage: "<id>", Action: cdnDel("/cdn/v2/distributions/%s", 1, "distribution ID required"), HideHelpCommand: true}
+var cdnDistributionDeleteCmd = cli.Command{Name: "delete", ArgsUsage: "<id>", Action: confirm(destructive{action: "delete CDN distribution %s"}, cdnDel("/cdn/v2/distributions/%s", 1, "distribution ID required")), HideHelpCommand: true}
 
 // Origins
 var cdnOriginsCmd = cli.Command{Name: "origins", ArgsUsage: "<dist-id>", Action: cdnCRUD("/cdn/v2/distributions/%s/origins", 1, "distribution ID required"), HideHelpCommand: true}
 var cdnOriginCreateCmd = cli.Command{Name: "origin-create", ArgsUsage: "<dist-id>", Flags: payloadFlag, Action: cdnPost("/cdn/v2/distributions/%s/origins", 1, "distribution ID required"), HideHelpCommand: true}
 var cdnOriginGetCmd = cli.Command{Name: "origin-get", ArgsUsage: "<dist-id> <origin-id>", Action: cdnCRUD("/cdn/v2/distributions/%s/origins/%s", 2, "distribution ID and origin ID required"), HideHelpCommand: true}
 var cdnOriginUpdateCmd = cli.Command{Name: "origin-update", ArgsUsage: "<dist-id> <origin-id>", Flags: payloadFlag, Action: cdnPut("/cdn/v2/distributions/%s/origins/%s", 2, "distribution ID and origin ID required"), HideHelpCommand: true}
-var cdnOriginDeleteCmd = cli.Command{Name: "origin-delete", ArgsUsage: "<dist-id> <origin-id>", Action: cdnDel("/cdn/v2/distributions/%s/origins/%s", 2, "distribution ID and origin ID required"), HideHelpCommand: true}
+var cdnOriginDeleteCmd = cli.Command{Name: "origin-delete", ArgsUsage: "<dist-id> <origin-id>", Action: confirm(destructive{action: "delete origin %[2]s of CDN distribution %[1]s"}, cdnDel("/cdn/v2/distributions/%s/origins/%s", 2, "distribution ID and origin ID required")), HideHelpCommand: true}
 
 // Cache Settings
 var cdnCacheSettingsCmd = cli.Command{Name: "cache-settings", ArgsUsage: "<dist-id>", Action: cdnCRUD("/cdn/v2/distributions/%s/cacheSettings", 1, "distribution ID required"), HideHelpCommand: true}
 var cdnCacheSettingCreateCmd = cli.Command{Name: "cache-setting-create", ArgsUsage: "<dist-id>", Flags: payloadFlag, Action: cdnPost("/cdn/v2/distributions/%s/cacheSettings", 1, "distribution ID required"), HideHelpCommand: true}
 var cdnCacheSettingGetCmd = cli.Command{Name: "cache-setting-get", ArgsUsage: "<dist-id> <cs-id>", Action: cdnCRUD("/cdn/v2/distributions/%s/cacheSettings/%s", 2, "distribution ID and setting ID required"), HideHelpCommand: true}
 var cdnCacheSettingUpdateCmd = cli.Command{Name: "cache-setting-update", ArgsUsage: "<dist-id> <cs-id>", Flags: payloadFlag, Action: cdnPut("/cdn/v2/distributions/%s/cacheSettings/%s", 2, "distribution ID and setting ID required"), HideHelpCommand: true}
-var cdnCacheSettingDeleteCmd = cli.Command{Name: "cache-setting-delete", ArgsUsage: "<dist-id> <cs-id>", Action: cdnDel("/cdn/v2/distributions/%s/cacheSettings/%s", 2, "distribution ID and setting ID required"), HideHelpCommand: true}
+var cdnCacheSettingDeleteCmd = cli.Command{Name: "cache-setting-delete", ArgsUsage: "<dist-id> <cs-id>", Action: confirm(destructive{action: "delete cache setting %[2]s of CDN distribution %[1]s"}, cdnDel("/cdn/v2/distributions/%s/cacheSettings/%s", 2, "distribution ID and setting ID required")), HideHelpCommand: true}
 
 // Cache Purge
 var cdnCachePurgeCmd = cli.Command{Name: "cache-purge", ArgsUsage: "<dist-id>", Flags: payloadFlag, Action: cdnPost("/cdn/v2/distributions/%s/cachePurge", 1, "distribution ID required"), HideHelpCommand: true}
@@ -161,21 +173,21 @@ var cdnSSLCertsCmd = cli.Command{Name: "ssl-certs", ArgsUsage: "<dist-id>", Acti
 var cdnSSLCertCreateCmd = cli.Command{Name: "ssl-cert-create", ArgsUsage: "<dist-id>", Flags: payloadFlag, Action: cdnPost("/cdn/v2/distributions/%s/sslCertificates", 1, "distribution ID required"), HideHelpCommand: true}
 var cdnSSLCertGetCmd = cli.Command{Name: "ssl-cert-get", ArgsUsage: "<dist-id> <cert-id>", Action: cdnCRUD("/cdn/v2/distributions/%s/sslCertificates/%s", 2, "distribution ID and cert ID required"), HideHelpCommand: true}
 var cdnSSLCertUpdateCmd = cli.Command{Name: "ssl-cert-update", ArgsUsage: "<dist-id> <cert-id>", Flags: payloadFlag, Action: cdnPut("/cdn/v2/distributions/%s/sslCertificates/%s", 2, "distribution ID and cert ID required"), HideHelpCommand: true}
-var cdnSSLCertDeleteCmd = cli.Command{Name: "ssl-cert-delete", ArgsUsage: "<dist-id> <cert-id>", Action: cdnDel("/cdn/v2/distributions/%s/sslCertificates/%s", 2, "distribution ID and cert ID required"), HideHelpCommand: true}
+var cdnSSLCertDeleteCmd = cli.Command{Name: "ssl-cert-delete", ArgsUsage: "<dist-id> <cert-id>", Action: confirm(destructive{action: "delete SSL certificate %[2]s of CDN distribution %[1]s"}, cdnDel("/cdn/v2/distributions/%s/sslCertificates/%s", 2, "distribution ID and cert ID required")), HideHelpCommand: true}
 
 // Custom Rules
 var cdnCustomRulesCmd = cli.Command{Name: "custom-rules", ArgsUsage: "<dist-id>", Action: cdnCRUD("/cdn/v2/distributions/%s/customRules", 1, "distribution ID required"), HideHelpCommand: true}
 var cdnCustomRuleCreateCmd = cli.Command{Name: "custom-rule-create", ArgsUsage: "<dist-id>", Flags: payloadFlag, Action: cdnPost("/cdn/v2/distributions/%s/customRules", 1, "distribution ID required"), HideHelpCommand: true}
 var cdnCustomRuleGetCmd = cli.Command{Name: "custom-rule-get", ArgsUsage: "<dist-id> <rule-id>", Action: cdnCRUD("/cdn/v2/distributions/%s/customRules/%s", 2, "distribution ID and rule ID required"), HideHelpCommand: true}
 var cdnCustomRuleUpdateCmd = cli.Command{Name: "custom-rule-update", ArgsUsage: "<dist-id> <rule-id>", Flags: payloadFlag, Action: cdnPut("/cdn/v2/distributions/%s/customRules/%s", 2, "distribution ID and rule ID required"), HideHelpCommand: true}
-var cdnCustomRuleDeleteCmd = cli.Command{Name: "custom-rule-delete", ArgsUsage: "<dist-id> <rule-id>", Action: cdnDel("/cdn/v2/distributions/%s/customRules/%s", 2, "distribution ID and rule ID required"), HideHelpCommand: true}
+var cdnCustomRuleDeleteCmd = cli.Command{Name: "custom-rule-delete", ArgsUsage: "<dist-id> <rule-id>", Action: confirm(destructive{action: "delete custom rule %[2]s of CDN distribution %[1]s"}, cdnDel("/cdn/v2/distributions/%s/customRules/%s", 2, "distribution ID and rule ID required")), HideHelpCommand: true}
 
 // WAF
 var cdnWAFCmd = cli.Command{Name: "waf", ArgsUsage: "<dist-id>", Action: cdnCRUD("/cdn/v2/distributions/%s/waf", 1, "distribution ID required"), HideHelpCommand: true}
 var cdnWAFUpdateCmd = cli.Command{Name: "waf-update", ArgsUsage: "<dist-id>", Flags: payloadFlag, Action: cdnPut("/cdn/v2/distributions/%s/waf", 1, "distribution ID required"), HideHelpCommand: true}
 var cdnWAFIPsCmd = cli.Command{Name: "waf-ips", Usage: "List WAF IP whitelist", ArgsUsage: "<dist-id>", Action: cdnCRUD("/cdn/v2/distributions/%s/waf/ipWhitelist", 1, "distribution ID required"), HideHelpCommand: true}
 var cdnWAFIPCreateCmd = cli.Command{Name: "waf-ip-create", ArgsUsage: "<dist-id>", Flags: payloadFlag, Action: cdnPost("/cdn/v2/distributions/%s/waf/ipWhitelist", 1, "distribution ID required"), HideHelpCommand: true}
-var cdnWAFIPDeleteCmd = cli.Command{Name: "waf-ip-delete", ArgsUsage: "<dist-id> <ip-id>", Action: cdnDel("/cdn/v2/distributions/%s/waf/ipWhitelist/%s", 2, "distribution ID and IP ID required"), HideHelpCommand: true}
+var cdnWAFIPDeleteCmd = cli.Command{Name: "waf-ip-delete", ArgsUsage: "<dist-id> <ip-id>", Action: confirm(destructive{action: "delete WAF IP %[2]s of CDN distribution %[1]s"}, cdnDel("/cdn/v2/distributions/%s/waf/ipWhitelist/%s", 2, "distribution ID and IP ID required")), HideHelpCommand: true}
 
 // Geo Restrictions
 var cdnGeoRestrictionsCmd = cli.Command{Name: "geo-restrictions", ArgsUsage: "<dist-id>", Action: cdnCRUD("/cdn/v2/distributions/%s/geoRestrictions", 1, "distribution ID required"), HideHelpCommand: true}
@@ -5,109 +5,223 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
-	"net/http/httputil"
+	"os"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/kernel/leaseweb-cli/pkg/leaseweb"
 	"github.com/tidwall/gjson"
 	"github.com/urfave/cli/v3"
 )
 
-type APIError struct {
-	Method     string
-	URL        string
-	StatusCode int
-	Status     string
-	Body       string
+// APIError is returned for API responses with a status of 400 or above.
+type APIError = leaseweb.Error
+
+// Client wraps the leaseweb SDK client with the gjson helpers used by the
+// commands.
+type Client struct {
+	api *leaseweb.Client
+	// validate checks request bodies against the OpenAPI spec before they
+	// are sent.
+	validate bool
+	// cache holds catalog responses; nil when caching is off.
+	cache *responseCache
 }
 
-func (e *APIError) Error() string {
-	return fmt.Sprintf("%s %s: %s", e.Method, e.URL, e.Status)
+func NewClient(cmd *cli.Command) (*Client, error) {
+	if profilesRequested(cmd) {
+		return nil, fmt.Errorf("--profiles and --all-profiles work only with %s", strings.Join(multiProfileCommands, ", "))
+	}
+	profile, _ := resolveProfile(cmd)
+	return newClient(cmd, profile, resolveSettings(cmd), func() (string, error) {
+		return resolveAPIKey(cmd)
+	})
 }
 
-type Client struct {
-	http    *http.Client
-	baseURL string
-	apiKey  string
-	debug   bool
+// newProfileClient is NewClient for the named profile instead of the active
+// one. LEASEWEB_API_KEY does not apply: every profile uses its own key.
+func newProfileClient(cmd *cli.Command, name string) (*Client, error) {
+	cfg := loadConfig()
+	p := cfg.Profiles[name]
+	return newClient(cmd, name, cfg.Settings.merge(p.Settings), func() (string, error) {
+		key, err := profileAPIKey(name, p)
+		if err == nil && key == "" {
+			err = fmt.Errorf("%w for profile %q. Run 'lw config init --profile %s'", ErrNoAPIKey, name, name)
+		}
+		return key, err
+	})
 }
 
-func NewClient(cmd *cli.Command) (*Client, error) {
-	apiKey, err := resolveAPIKey(cmd)
+func newClient(cmd *cli.Command, profile string, settings Settings, resolveKey func() (string, error)) (*Client, error) {
+	root := cmd.Root()
+	record, replay := root.String("record"), root.String("replay")
+	if record != "" && replay != "" {
+		return nil, fmt.Errorf("--record and --replay cannot be used together")
+	}
+
+	apiKey, err := resolveKey()
+	if err != nil {
+		// Replays never reach the API, so they need no key.
+		if replay == "" {
+			return nil, err
+		}
+		apiKey = "replay"
+	}
+	maxRetries, retryWait := resolveRetrySettings(cmd, settings)
+	timeout, err := resolveTimeout(cmd, settings)
 	if err != nil {
 		return nil, err
 	}
+	opts := []leaseweb.Option{
+		leaseweb.WithBaseURL(baseURLFor(settings)),
+		leaseweb.WithUserAgent(userAgent()),
+		leaseweb.WithDebug(root.Bool("debug")),
+		leaseweb.WithRetries(maxRetries, retryWait),
+		leaseweb.WithRateLimit(settings.RateLimit, settings.RateBurst),
+		leaseweb.WithTimeout(timeout),
+	}
+	if mode := root.String("dry-run"); mode != "" {
+		opts = append(opts, leaseweb.WithDryRun(dryRunPrinter(os.Stdout, mode, root.String("output"))))
+	}
+	switch {
+	case record != "":
+		rec, err := newRecorder(record, http.DefaultTransport)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, leaseweb.WithHTTPClient(&http.Client{Transport: rec}))
+	case replay != "":
+		rep, err := newReplayer(replay)
+		if err != nil {
+			return nil, err
+		}
+		// Recorded retries are replayed without waiting for them.
+		opts = append(opts, leaseweb.WithHTTPClient(&http.Client{Transport: rep}), leaseweb.WithRetries(maxRetries, 0))
+	}
 	return &Client{
-		http:    http.DefaultClient,
-		baseURL: resolveBaseURL(),
-		apiKey:  apiKey,
-		debug:   cmd.Root().Bool("debug"),
+		api:      leaseweb.NewClient(apiKey, opts...),
+		validate: !root.Bool("skip-validation") && hasFlag(cmd, "payload"),
+		cache:    newResponseCache(cmd, profile, settings),
 	}, nil
 }
 
+// hasFlag reports whether cmd itself defines the named flag.
+func hasFlag(cmd *cli.Command, name string) bool {
+	for _, f := range cmd.Flags {
+		if slices.Contains(f.Names(), name) {
+			return true
+		}
+	}
+	return false
+}
+
 // NewClientWithBaseURL creates a client with explicit base URL (useful for testing).
 func NewClientWithBaseURL(baseURL, apiKey string) *Client {
-	return &Client{
-		http:    http.DefaultClient,
-		baseURL: baseURL,
-		apiKey:  apiKey,
-	}
+	return &Client{api: leaseweb.NewClient(apiKey,
+		leaseweb.WithBaseURL(baseURL),
+		leaseweb.WithUserAgent(userAgent()),
+	)}
 }
 
-func (c *Client) Do(ctx context.Context, method, path string, body io.Reader) (gjson.Result, error) {
-	url := c.baseURL + path
+// API returns the underlying SDK client.
+func (c *Client) API() *leaseweb.Client {
+	return c.api
+}
 
-	req, err := http.NewRequestWithContext(ctx, method, url, body)
-	if err != nil {
-		return gjson.Result{}, fmt.Errorf("creating request: %w", err)
-	}
+func userAgent() string {
+	return fmt.Sprintf("lw-cli/%s", Version)
+}
 
-	req.Header.Set("X-LSW-Auth", c.apiKey)
-	req.Header.Set("User-Agent", fmt.Sprintf("lw-cli/%s", Version))
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
-	}
+// resolveRetrySettings returns the retry settings from global flags or their
+// environment variables, falling back to s and then to the built-in
+// defaults.
+func resolveRetrySettings(cmd *cli.Command, s Settings) (int, time.Duration) {
+	maxRetries := leaseweb.DefaultMaxRetries
+	retryWait := leaseweb.DefaultRetryWait
 
-	if c.debug {
-		if dump, err := httputil.DumpRequest(req, true); err == nil {
-			log.Printf("Request:\n%s\n", dump)
+	if s.MaxRetries != nil {
+		maxRetries = *s.MaxRetries
+	}
+	if s.RetryWait != "" {
+		if d, err := time.ParseDuration(s.RetryWait); err == nil {
+			retryWait = d
 		}
 	}
 
-	resp, err := c.http.Do(req)
-	if err != nil {
-		return gjson.Result{}, fmt.Errorf("executing request: %w", err)
+	root := cmd.Root()
+	if root.IsSet("max-retries") {
+		maxRetries = root.Int("max-retries")
+	}
+	if root.IsSet("retry-wait") {
+		retryWait = root.Duration("retry-wait")
 	}
-	defer resp.Body.Close()
+	if maxRetries < 0 {
+		maxRetries = 0
+	}
+	return maxRetries, retryWait
+}
 
-	respBody, err := io.ReadAll(resp.Body)
+// resolveTimeout returns --timeout or LEASEWEB_TIMEOUT, falling back to the
+// timeout in the config. Zero means no limit.
+func resolveTimeout(cmd *cli.Command, s Settings) (time.Duration, error) {
+	if root := cmd.Root(); root.IsSet("timeout") {
+		return root.Duration("timeout"), nil
+	}
+	if s.Timeout == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(s.Timeout)
 	if err != nil {
-		return gjson.Result{}, fmt.Errorf("reading response: %w", err)
+		return 0, fmt.Errorf("timeout setting in config: %w", err)
 	}
+	return d, nil
+}
+
+// send performs the request through the SDK client. Headers in header are
+// added to, or override, the defaults.
+func (c *Client) send(ctx context.Context, method, path string, body []byte, header http.Header) (*leaseweb.Response, error) {
+	return c.api.Do(ctx, &leaseweb.Request{Method: method, Path: path, Body: body, Header: header})
+}
 
-	if c.debug {
-		if dump, err := httputil.DumpResponse(resp, false); err == nil {
-			log.Printf("Response:\n%s\n%s\n", dump, string(respBody))
+func (c *Client) Do(ctx context.Context, method, path string, body io.Reader) (gjson.Result, error) {
+	var reqBody []byte
+	if body != nil {
+		b, err := io.ReadAll(body)
+		if err != nil {
+			return gjson.Result{}, fmt.Errorf("reading request body: %w", err)
 		}
+		reqBody = b
 	}
 
-	if resp.StatusCode >= 400 {
-		return gjson.Result{}, &APIError{
-			Method:     method,
-			URL:        url,
-			StatusCode: resp.StatusCode,
-			Status:     resp.Status,
-			Body:       string(respBody),
+	if c.validate && reqBody != nil {
+		if op := operationForRequest(method, path); op != nil {
+			if err := validateBody(op, reqBody); err != nil {
+				return gjson.Result{}, err
+			}
 		}
 	}
 
+	var resp *leaseweb.Response
+	var err error
+	if e, ok := cachedEndpointFor(path); ok && c.cache != nil && method == http.MethodGet {
+		resp, err = c.cache.get(path, e.ttl, func(header http.Header) (*leaseweb.Response, error) {
+			return c.send(ctx, method, path, nil, header)
+		})
+	} else {
+		resp, err = c.send(ctx, method, path, reqBody, nil)
+	}
+	if err != nil {
+		return gjson.Result{}, err
+	}
+
 	// For 204 No Content or empty bodies, return empty result
-	if resp.StatusCode == 204 || len(respBody) == 0 {
+	if resp.StatusCode == 204 || len(resp.Body) == 0 {
 		return gjson.Result{}, nil
 	}
 
-	return gjson.ParseBytes(respBody), nil
+	return gjson.ParseBytes(resp.Body), nil
 }
 
 func (c *Client) Get(ctx context.Context, path string) (gjson.Result, error) {
@@ -136,38 +250,11 @@ func (c *Client) Delete(ctx context.Context, path string) (gjson.Result, error)
 
 // DoRaw is like Do but returns the raw response body bytes (for binary responses like PDFs).
 func (c *Client) DoRaw(ctx context.Context, method, path string) ([]byte, string, error) {
-	url := c.baseURL + path
-
-	req, err := http.NewRequestWithContext(ctx, method, url, nil)
-	if err != nil {
-		return nil, "", fmt.Errorf("creating request: %w", err)
-	}
-
-	req.Header.Set("X-LSW-Auth", c.apiKey)
-	req.Header.Set("User-Agent", fmt.Sprintf("lw-cli/%s", Version))
-
-	resp, err := c.http.Do(req)
+	resp, err := c.send(ctx, method, path, nil, nil)
 	if err != nil {
-		return nil, "", fmt.Errorf("executing request: %w", err)
+		return nil, "", err
 	}
-	defer resp.Body.Close()
-
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, "", fmt.Errorf("reading response: %w", err)
-	}
-
-	if resp.StatusCode >= 400 {
-		return nil, "", &APIError{
-			Method:     method,
-			URL:        url,
-			StatusCode: resp.StatusCode,
-			Status:     resp.Status,
-			Body:       string(respBody),
-		}
-	}
-
-	return respBody, resp.Header.Get("Content-Type"), nil
+	return resp.Body, resp.Header.Get("Content-Type"), nil
 }
 
 // BuildQueryString constructs a query string from key-value pairs, omitting empty values.
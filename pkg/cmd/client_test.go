@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaxRetriesFlag(t *testing.T) {
+	var calls atomic.Int32
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"GET /services/v1/services": func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		},
+	})
+	defer srv.Close()
+
+	_, _, err := runCLI(t, srv.URL, []string{
+		"--max-retries", "1", "--retry-wait", "1ms",
+		"services", "list",
+	})
+	require.Error(t, err)
+	assert.Equal(t, int32(2), calls.Load())
+}
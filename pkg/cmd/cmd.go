@@ -3,19 +3,35 @@ package cmd
 import (
 	"fmt"
 	"os"
-	"slices"
 	"strings"
 
+	"github.com/kernel/leaseweb-cli/pkg/leaseweb"
 	"github.com/urfave/cli/v3"
 )
 
+// Command is the lw command tree run by Run.
 var Command *cli.Command
 
+// outputFormat holds --output; the output setting of the profile replaces
+// it when the flag is left at its default.
+var outputFormat string
+
+// outputColumns holds --columns, which overrides the columns of csv and tsv
+// output.
+var outputColumns []string
+
 func init() {
 	cli.VersionPrinter = func(cmd *cli.Command) {
 		fmt.Fprintf(os.Stdout, "lw version %s\n", cmd.Root().Version)
 	}
-	Command = &cli.Command{
+	Command = newRootCommand()
+}
+
+// newRootCommand returns the lw command tree. Every call returns new root
+// flags, so a run never sees the flags set by an earlier one; the
+// subcommands are shared.
+func newRootCommand() *cli.Command {
+	root := &cli.Command{
 		Name:    "lw",
 		Usage:   "CLI for the Leaseweb API",
 		Version: Version,
@@ -25,32 +41,102 @@ func init() {
 				Aliases: []string{"p"},
 				Usage:   "Config profile to use",
 			},
+			&cli.StringSliceFlag{
+				Name:  "profiles",
+				Usage: "Run a list command for each of the comma-separated `PROFILES` at once and merge the results",
+			},
+			&cli.BoolFlag{
+				Name:  "all-profiles",
+				Usage: "Run a list command for every configured profile at once and merge the results",
+			},
 			&cli.BoolFlag{
 				Name:  "debug",
 				Usage: "Enable debug logging of HTTP requests",
 			},
 			&cli.StringFlag{
-				Name:    "output",
-				Aliases: []string{"o"},
-				Usage:   "Output format (one of: " + strings.Join(OutputFormats, ", ") + ")",
-				Value:   "auto",
-				Validator: func(format string) error {
-					if !slices.Contains(OutputFormats, strings.ToLower(format)) {
-						return fmt.Errorf("output must be one of: %s", strings.Join(OutputFormats, ", "))
-					}
-					return nil
-				},
+				Name:        "output",
+				Aliases:     []string{"o"},
+				Usage:       "Output format (one of: " + strings.Join(OutputFormats, ", ") + "; or go-template=TEMPLATE, template-file=FILE, custom-columns=HEADER:PATH,...)",
+				Value:       "auto",
+				Sources:     cli.EnvVars("LEASEWEB_OUTPUT"),
+				Destination: &outputFormat,
+				Validator:   checkOutputFormat,
+			},
+			&cli.StringSliceFlag{
+				Name:  "columns",
+				Usage: "Comma-separated dotted JSON `PATHS` to show as columns with -o csv and -o tsv",
 			},
 			&cli.StringFlag{
 				Name:  "transform",
 				Usage: "GJSON expression to transform output",
 			},
+			&cli.BoolFlag{
+				Name:  "all",
+				Usage: "Fetch every page of list results",
+			},
+			&cli.IntFlag{
+				Name:  "page-size",
+				Usage: "Number of results per request when using --all",
+				Value: leaseweb.DefaultPageSize,
+			},
+			&cli.IntFlag{
+				Name:    "max-retries",
+				Usage:   "Maximum number of retries for rate-limited or failed requests",
+				Value:   leaseweb.DefaultMaxRetries,
+				Sources: cli.EnvVars("LEASEWEB_MAX_RETRIES"),
+			},
+			&cli.DurationFlag{
+				Name:    "retry-wait",
+				Usage:   "Base wait between retries, doubled on each attempt",
+				Value:   leaseweb.DefaultRetryWait,
+				Sources: cli.EnvVars("LEASEWEB_RETRY_WAIT"),
+			},
+			&cli.DurationFlag{
+				Name:        "timeout",
+				Usage:       "Time limit for each API request attempt, e.g. 30s",
+				DefaultText: "none",
+				Sources:     cli.EnvVars("LEASEWEB_TIMEOUT"),
+			},
+			&dryRunFlag{
+				Name:  "dry-run",
+				Usage: "Print mutating requests instead of sending them; --dry-run=curl prints curl commands",
+			},
+			&cli.StringFlag{
+				Name:  "record",
+				Usage: "Record every API request and response as cassette files in `DIR`",
+			},
+			&cli.StringFlag{
+				Name:  "replay",
+				Usage: "Answer API requests from the cassettes in `DIR` instead of the network",
+			},
+			&cli.BoolFlag{
+				Name:    "yes",
+				Aliases: []string{"y"},
+				Usage:   "Run destructive commands without asking for confirmation (or set LEASEWEB_ASSUME_YES=1)",
+			},
+			&cli.BoolFlag{
+				Name:  "no-cache",
+				Usage: "Neither read nor store cached catalog responses",
+			},
+			&cli.BoolFlag{
+				Name:  "refresh",
+				Usage: "Fetch catalog responses again, or revalidate them, instead of using the cache",
+			},
+			&cli.BoolFlag{
+				Name:  "skip-validation",
+				Usage: "Send request bodies without checking them against the OpenAPI spec",
+			},
 		},
 		Commands: []*cli.Command{
 			&abuseReportsCmd,
 			&acronisBackupCmd,
 			&aggregationPacksCmd,
+			&aliasCmd,
+			&apiCmd,
 			&apiKeysCmd,
+			&authCmd,
+			&bookmarkCmd,
+			&cacheCmd,
 			&cdnCmd,
 			&colocationsCmd,
 			&configCmd,
@@ -65,10 +151,12 @@ func init() {
 			&ipsCmd,
 			&ordersCmd,
 			&loadBalancersCmd,
+			&mockServerCmd,
 			&networkEquipmentCmd,
 			&privateCloudsCmd,
 			&privateNetworksCmd,
 			&remoteManagementCmd,
+			&schemaCmd,
 			&servicesCmd,
 			&storageCmd,
 			&trafficPolicyCmd,
@@ -77,8 +165,14 @@ func init() {
 			&vpsCmd,
 			&webhostingCmd,
 		},
-		EnableShellCompletion:      true,
-		ShellCompletionCommandName: "@completion",
-		HideHelpCommand:            true,
+		Before:                          applyOutputSetting,
+		EnableShellCompletion:           true,
+		ShellCompletionCommandName:      "@completion",
+		ConfigureShellCompletionCommand: configureCompletionCommand,
+		HideHelpCommand:                 true,
 	}
+	addGeneratedCommands(root)
+	addIDResolvers(root)
+	addCompletions(root)
+	return root
 }
@@ -32,8 +32,21 @@ func jsonResponse(w http.ResponseWriter, statusCode int, body any) {
 
 func runCLI(t *testing.T, baseURL string, args []string) (stdout string, stderr string, err error) {
 	t.Helper()
+	return runCLIContext(t, context.Background(), baseURL, args)
+}
+
+// runCLIContext is runCLI with a context, for tests that cancel a command.
+func runCLIContext(t *testing.T, ctx context.Context, baseURL string, args []string) (stdout string, stderr string, err error) {
+	t.Helper()
+	return runCLIWithKey(t, ctx, baseURL, "test-key", args)
+}
+
+// runCLIWithKey runs lw with apiKey as LEASEWEB_API_KEY. An empty apiKey
+// leaves the key to the config file.
+func runCLIWithKey(t *testing.T, ctx context.Context, baseURL, apiKey string, args []string) (stdout string, stderr string, err error) {
+	t.Helper()
 
-	t.Setenv("LEASEWEB_API_KEY", "test-key")
+	t.Setenv("LEASEWEB_API_KEY", apiKey)
 	if baseURL != "" {
 		t.Setenv("LEASEWEB_BASE_URL", baseURL)
 	}
@@ -51,7 +64,7 @@ func runCLI(t *testing.T, baseURL string, args []string) (stdout string, stderr
 	os.Stderr = wErr
 
 	fullArgs := append([]string{"lw"}, args...)
-	err = Command.Run(context.Background(), fullArgs)
+	err = runRoot(ctx, newRootCommand(), fullArgs)
 
 	wOut.Close()
 	wErr.Close()
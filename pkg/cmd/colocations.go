@@ -53,7 +53,7 @@ var coloListCmd = cli.Command{Name: "list", Usage: "List colocations", Flags: Pa
 	if err != nil {
 		return err
 	}
-	res, err := client.Get(ctx, "/bareMetals/v2/colocations?"+PaginationQuery(cmd))
+	res, err := GetList(ctx, client, cmd, "/bareMetals/v2/colocations?"+PaginationQuery(cmd))
 	if err != nil {
 		return err
 	}
@@ -147,7 +147,7 @@ var coloCredentialUpdateCmd = cli.Command{Name: "credential-update", ArgsUsage:
 	return ShowResult(os.Stdout, res, cmd.Root().String("output"), cmd.Root().String("transform"))
 }, HideHelpCommand: true}
 
-var coloCredentialDeleteCmd = cli.Command{Name: "credential-delete", ArgsUsage: "<id> <type> <username>", Action: func(ctx context.Context, cmd *cli.Command) error {
+var coloCredentialDeleteCmd = cli.Command{Name: "credential-delete", ArgsUsage: "<id> <type> <username>", Action: confirm(destructive{"delete the %[2]s credential %[3]s of colocation %[1]s", "/bareMetals/v2/colocations/%s", serverDetails}, func(ctx context.Context, cmd *cli.Command) error {
 	args := cmd.Args().Slice()
 	if len(args) < 3 {
 		return fmt.Errorf("colocation ID, type, and username required")
@@ -162,7 +162,7 @@ var coloCredentialDeleteCmd = cli.Command{Name: "credential-delete", ArgsUsage:
 	}
 	fmt.Fprintf(os.Stderr, "Deleted credential %s/%s\n", args[1], args[2])
 	return nil
-}, HideHelpCommand: true}
+}), HideHelpCommand: true}
 
 var coloIPsCmd = cli.Command{Name: "ips", ArgsUsage: "<id>", Action: coloSimpleGet("/ips"), HideHelpCommand: true}
 
@@ -199,7 +199,7 @@ var coloIPUpdateCmd = cli.Command{Name: "ip-update", ArgsUsage: "<id> <ip>", Fla
 	return ShowResult(os.Stdout, res, cmd.Root().String("output"), cmd.Root().String("transform"))
 }, HideHelpCommand: true}
 
-var coloIPNullCmd = cli.Command{Name: "ip-null", ArgsUsage: "<id> <ip>", Action: func(ctx context.Context, cmd *cli.Command) error {
+var coloIPNullCmd = cli.Command{Name: "ip-null", ArgsUsage: "<id> <ip>", Action: confirm(destructive{"null route IP %[2]s of colocation %[1]s", "/bareMetals/v2/colocations/%s", serverDetails}, func(ctx context.Context, cmd *cli.Command) error {
 	args := cmd.Args().Slice()
 	if len(args) < 2 {
 		return fmt.Errorf("colocation ID and IP required")
@@ -214,7 +214,7 @@ var coloIPNullCmd = cli.Command{Name: "ip-null", ArgsUsage: "<id> <ip>", Action:
 	}
 	fmt.Fprintf(os.Stderr, "Null routed %s\n", args[1])
 	return nil
-}, HideHelpCommand: true}
+}), HideHelpCommand: true}
 
 var coloIPUnnullCmd = cli.Command{Name: "ip-unnull", ArgsUsage: "<id> <ip>", Action: func(ctx context.Context, cmd *cli.Command) error {
 	args := cmd.Args().Slice()
@@ -319,7 +319,11 @@ func coloNotifHandler(settingType, method string) func(context.Context, *cli.Com
 			if len(args) < 1 {
 				return fmt.Errorf("colocation ID required")
 			}
-			res, err := client.PostJSON(ctx, base, []byte(cmd.String("payload")))
+			body, err := loadPayload(cmd)
+			if err != nil {
+				return err
+			}
+			res, err := client.PostJSON(ctx, base, body)
 			if err != nil {
 				return err
 			}
@@ -328,7 +332,11 @@ func coloNotifHandler(settingType, method string) func(context.Context, *cli.Com
 			if len(args) < 2 {
 				return fmt.Errorf("colocation ID and notification ID required")
 			}
-			res, err := client.PutJSON(ctx, base+"/"+args[1], []byte(cmd.String("payload")))
+			body, err := loadPayload(cmd)
+			if err != nil {
+				return err
+			}
+			res, err := client.PutJSON(ctx, base+"/"+args[1], body)
 			if err != nil {
 				return err
 			}
@@ -352,12 +360,12 @@ var coloNotifBandwidthListCmd = cli.Command{Name: "notif-bandwidth-list", ArgsUs
 var coloNotifBandwidthCreateCmd = cli.Command{Name: "notif-bandwidth-create", ArgsUsage: "<id>", Flags: payloadFlag, Action: coloNotifHandler("bandwidth", "create"), HideHelpCommand: true}
 var coloNotifBandwidthGetCmd = cli.Command{Name: "notif-bandwidth-get", ArgsUsage: "<id> <nid>", Action: coloNotifHandler("bandwidth", "get"), HideHelpCommand: true}
 var coloNotifBandwidthUpdateCmd = cli.Command{Name: "notif-bandwidth-update", ArgsUsage: "<id> <nid>", Flags: payloadFlag, Action: coloNotifHandler("bandwidth", "update"), HideHelpCommand: true}
-var coloNotifBandwidthDeleteCmd = cli.Command{Name: "notif-bandwidth-delete", ArgsUsage: "<id> <nid>", Action: coloNotifHandler("bandwidth", "delete"), HideHelpCommand: true}
+var coloNotifBandwidthDeleteCmd = cli.Command{Name: "notif-bandwidth-delete", ArgsUsage: "<id> <nid>", Action: confirm(destructive{"delete bandwidth notification setting %[2]s of colocation %[1]s", "/bareMetals/v2/colocations/%s", serverDetails}, coloNotifHandler("bandwidth", "delete")), HideHelpCommand: true}
 var coloNotifDatatrafficListCmd = cli.Command{Name: "notif-datatraffic-list", ArgsUsage: "<id>", Action: coloNotifHandler("datatraffic", "list"), HideHelpCommand: true}
 var coloNotifDatatrafficCreateCmd = cli.Command{Name: "notif-datatraffic-create", ArgsUsage: "<id>", Flags: payloadFlag, Action: coloNotifHandler("datatraffic", "create"), HideHelpCommand: true}
 var coloNotifDatatrafficGetCmd = cli.Command{Name: "notif-datatraffic-get", ArgsUsage: "<id> <nid>", Action: coloNotifHandler("datatraffic", "get"), HideHelpCommand: true}
 var coloNotifDatatrafficUpdateCmd = cli.Command{Name: "notif-datatraffic-update", ArgsUsage: "<id> <nid>", Flags: payloadFlag, Action: coloNotifHandler("datatraffic", "update"), HideHelpCommand: true}
-var coloNotifDatatrafficDeleteCmd = cli.Command{Name: "notif-datatraffic-delete", ArgsUsage: "<id> <nid>", Action: coloNotifHandler("datatraffic", "delete"), HideHelpCommand: true}
+var coloNotifDatatrafficDeleteCmd = cli.Command{Name: "notif-datatraffic-delete", ArgsUsage: "<id> <nid>", Action: confirm(destructive{"delete data traffic notification setting %[2]s of colocation %[1]s", "/bareMetals/v2/colocations/%s", serverDetails}, coloNotifHandler("datatraffic", "delete")), HideHelpCommand: true}
 
 var coloNotifDDoSGetCmd = cli.Command{Name: "notif-ddos-get", ArgsUsage: "<id>", Action: coloSimpleGet("/notificationSettings/ddos"), HideHelpCommand: true}
 var coloNotifDDoSUpdateCmd = cli.Command{Name: "notif-ddos-update", ArgsUsage: "<id>", Flags: payloadFlag, Action: func(ctx context.Context, cmd *cli.Command) error {
@@ -369,7 +377,11 @@ var coloNotifDDoSUpdateCmd = cli.Command{Name: "notif-ddos-update", ArgsUsage: "
 	if err != nil {
 		return err
 	}
-	res, err := client.PutJSON(ctx, coloPath(args)+"/notificationSettings/ddos", []byte(cmd.String("payload")))
+	body, err := loadPayload(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := client.PutJSON(ctx, coloPath(args)+"/notificationSettings/ddos", body)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,406 @@
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tidwall/gjson"
+	"github.com/urfave/cli/v3"
+)
+
+// completionCacheTTL is how long completions fetched from the API are
+// reused, so that pressing tab repeatedly does not wait on the API.
+const completionCacheTTL = 5 * time.Minute
+
+// completionTimeout bounds the requests made for a completion.
+const completionTimeout = 5 * time.Second
+
+// completionFlag is appended by the completion scripts to ask for the
+// candidates of the next word.
+const completionFlag = "--generate-shell-completion"
+
+// completion is a candidate offered to the shell.
+type completion struct {
+	Value       string `json:"value"`
+	Description string `json:"description,omitempty"`
+}
+
+// completer returns the candidates for an argument or flag value of cmd.
+type completer func(ctx context.Context, cmd *cli.Command) ([]completion, error)
+
+var (
+	serverCompletions      = listCompletions("/bareMetals/v2/servers", "servers", "id", itemField("contract.reference"))
+	instanceCompletions    = listCompletions("/publicCloud/v1/instances", "instances", "id", itemField("reference"))
+	vpsCompletions         = listCompletions("/publicCloud/v1/vps/", "vps", "id", itemField("reference"))
+	domainCompletions      = listCompletions("/hosting/v2/domains", "domains", "domainName", itemField("status"))
+	regionCompletions      = listCompletions("/publicCloud/v1/regions", "regions", "name", itemField("location"))
+	osCompletions          = listCompletions("/bareMetals/v2/operatingSystems", "operatingSystems", "id", itemField("name"))
+	rescueImageCompletions = listCompletions("/bareMetals/v2/rescueImages", "rescueImages", "id", itemField("name"))
+)
+
+// argCompletions complete the first argument of commands by its placeholder
+// in ArgsUsage.
+var argCompletions = map[string]completer{
+	"<server-id>":   serverCompletions,
+	"<serverId>":    serverCompletions,
+	"<instance-id>": instanceCompletions,
+	"<instanceId>":  instanceCompletions,
+	"<vps-id>":      vpsCompletions,
+	"<vpsId>":       vpsCompletions,
+	"<domain>":      domainCompletions,
+	"<domainName>":  domainCompletions,
+}
+
+// flagCompletions complete flag values, by command and flag name.
+var flagCompletions = map[string]map[string]completer{
+	"dedicated-servers install": {"os": osCompletions},
+	"dedicated-servers rescue":  {"os": rescueImageCompletions},
+	"instances launch":          {"region": regionCompletions, "type": instanceTypeCompletions},
+}
+
+// runArgsKey is the context key under which Run stores its arguments, which
+// completions need to tell whether a flag value is being completed.
+type runArgsKey struct{}
+
+// addCompletions sets up the completion of IDs and flag values for the
+// commands under root.
+func addCompletions(root *cli.Command) {
+	for _, group := range root.Commands {
+		var walk func(c *cli.Command, path string)
+		walk = func(c *cli.Command, path string) {
+			flags := map[string]completer{}
+			for name, comp := range flagCompletions[path] {
+				flags[name] = comp
+			}
+			if op, ok := generatedOperations[c]; ok {
+				for _, q := range op.Query {
+					if len(q.Enum) > 0 {
+						flags[q.Flag] = staticCompletions(q.Enum)
+					}
+				}
+			}
+			var arg completer
+			if words := strings.Fields(c.ArgsUsage); len(words) > 0 && c.Action != nil {
+				arg = argCompletions[words[0]]
+			}
+			if arg != nil || len(flags) > 0 {
+				c.ShellComplete = completeCommand(group.Name, arg, flags)
+			}
+			for _, sub := range c.Commands {
+				walk(sub, path+" "+sub.Name)
+			}
+		}
+		walk(group, group.Name)
+	}
+}
+
+// completeCommand returns the completion function of a command whose first
+// argument is completed by arg and whose flag values by flags. Bookmarks of
+// the command's resource type are offered with its IDs.
+func completeCommand(group string, arg completer, flags map[string]completer) cli.ShellCompleteFunc {
+	return func(ctx context.Context, cmd *cli.Command) {
+		words, ok := ctx.Value(runArgsKey{}).([]string)
+		if !ok {
+			words = os.Args
+		}
+		words = slices.DeleteFunc(slices.Clone(words), func(w string) bool { return w == completionFlag })
+		last := ""
+		if len(words) > 1 {
+			last = words[len(words)-1]
+		}
+
+		var comp completer
+		switch {
+		case strings.HasPrefix(last, "-"):
+			comp = flags[strings.TrimLeft(last, "-")]
+		case arg != nil && cmd.Args().Len() == 0:
+			comp = arg
+		}
+		if comp == nil {
+			cli.DefaultCompleteWithFlags(ctx, cmd)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, completionTimeout)
+		defer cancel()
+		items, err := comp(ctx, cmd)
+		if err != nil {
+			return
+		}
+		if !strings.HasPrefix(last, "-") {
+			items = append(items, bookmarkCompletions(group)...)
+		}
+		printCompletions(items)
+	}
+}
+
+// printCompletions writes items for the completion script of the shell in
+// $SHELL, which the scripts set to the shell completing. zsh and fish show
+// the descriptions; bash only takes the values.
+func printCompletions(items []completion) {
+	shell := filepath.Base(os.Getenv("SHELL"))
+	for _, c := range items {
+		desc := strings.Join(strings.Fields(c.Description), " ")
+		switch {
+		case strings.HasSuffix(shell, "zsh"):
+			v := strings.ReplaceAll(c.Value, ":", `\:`)
+			if desc != "" {
+				v += ":" + desc
+			}
+			fmt.Fprintln(os.Stdout, v)
+		case strings.HasSuffix(shell, "fish") && desc != "":
+			fmt.Fprintf(os.Stdout, "%s\t%s\n", c.Value, desc)
+		default:
+			fmt.Fprintln(os.Stdout, c.Value)
+		}
+	}
+}
+
+// itemField returns a function reading path from an item.
+func itemField(path string) func(gjson.Result) string {
+	return func(item gjson.Result) string { return item.Get(path).String() }
+}
+
+// listCompletions completes the value field of the items of a collection,
+// described by describe. The collection is cached for completionCacheTTL.
+func listCompletions(path, collection, value string, describe func(gjson.Result) string) completer {
+	return func(ctx context.Context, cmd *cli.Command) ([]completion, error) {
+		cacheFile := completionCacheFile(cmd, path)
+		if items, ok := readCompletionCache(cacheFile); ok {
+			return items, nil
+		}
+		if keyFromCommandConfigured(cmd) {
+			return nil, nil
+		}
+		client, err := NewClient(cmd)
+		if err != nil {
+			return nil, err
+		}
+		res, err := fetchAllPages(ctx, client, path, nil, 0, nil)
+		if err != nil {
+			return nil, err
+		}
+		var items []completion
+		for _, item := range res.Get(collection).Array() {
+			if v := item.Get(value).String(); v != "" {
+				items = append(items, completion{Value: v, Description: describe(item)})
+			}
+		}
+		writeCompletionCache(cacheFile, items)
+		return items, nil
+	}
+}
+
+// keyFromCommandConfigured reports whether the API key of the active
+// profile comes from api_key_command. Such helpers may prompt for a
+// passphrase, which a completion cannot answer, so completions do not run
+// them.
+func keyFromCommandConfigured(cmd *cli.Command) bool {
+	if os.Getenv("LEASEWEB_API_KEY") != "" {
+		return false
+	}
+	profile, err := resolveProfile(cmd)
+	if err != nil {
+		return false
+	}
+	p, ok := loadConfig().Profiles[profile]
+	return ok && slices.Contains(p.keySources(), keySourceCommand)
+}
+
+// instanceTypeCompletions completes the instance types of the region given
+// by --region or the profile.
+func instanceTypeCompletions(ctx context.Context, cmd *cli.Command) ([]completion, error) {
+	region, err := resolveRegion(cmd)
+	if err != nil {
+		return nil, err
+	}
+	describe := func(item gjson.Result) string {
+		r := item.Get("resources")
+		if !r.Exists() {
+			return ""
+		}
+		return fmt.Sprintf("%s %s, %s %s",
+			r.Get("cpu.value"), r.Get("cpu.unit"), r.Get("memory.value"), r.Get("memory.unit"))
+	}
+	return listCompletions("/publicCloud/v1/instanceTypes?region="+url.QueryEscape(region), "instanceTypes", "name", describe)(ctx, cmd)
+}
+
+// staticCompletions completes a fixed set of values.
+func staticCompletions(values []string) completer {
+	return func(context.Context, *cli.Command) ([]completion, error) {
+		items := make([]completion, len(values))
+		for i, v := range values {
+			items[i] = completion{Value: v}
+		}
+		return items, nil
+	}
+}
+
+// bookmarkCompletions returns the bookmarks of resources managed by group.
+func bookmarkCompletions(group string) []completion {
+	var items []completion
+	for name, b := range loadConfig().Bookmarks {
+		if b.Type == group {
+			items = append(items, completion{Value: "@" + name, Description: b.ID})
+		}
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].Value < items[j].Value })
+	return items
+}
+
+// completionCacheFile returns the file caching the completions read from
+// path. Each profile, base URL and API key has its own files.
+func completionCacheFile(cmd *cli.Command, path string) string {
+	dir, err := cacheDir()
+	if err != nil {
+		return ""
+	}
+	profile, _ := resolveProfile(cmd)
+	h := sha256.Sum256([]byte(strings.Join([]string{profile, resolveBaseURL(cmd), os.Getenv("LEASEWEB_API_KEY"), path}, "\x00")))
+	return filepath.Join(dir, "completion", hex.EncodeToString(h[:12])+".json")
+}
+
+func readCompletionCache(file string) ([]completion, bool) {
+	if file == "" {
+		return nil, false
+	}
+	info, err := os.Stat(file)
+	if err != nil || time.Since(info.ModTime()) > completionCacheTTL {
+		return nil, false
+	}
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, false
+	}
+	var items []completion
+	if err := json.Unmarshal(b, &items); err != nil {
+		return nil, false
+	}
+	return items, true
+}
+
+// writeCompletionCache stores items in file. Failures only cost speed, so
+// they are ignored.
+func writeCompletionCache(file string, items []completion) {
+	if file == "" {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+		return
+	}
+	b, err := json.Marshal(items)
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(file, b, 0600)
+}
+
+// configureCompletionCommand replaces the completion scripts of urfave/cli.
+// Each script sets SHELL to the shell it is written for, since candidates
+// are formatted by shell and $SHELL is only the login shell. The fish script
+// of urfave/cli also only knows the command tree, so it asks lw for
+// candidates like the bash and zsh scripts do.
+func configureCompletionCommand(c *cli.Command) {
+	action := c.Action
+	c.Action = func(ctx context.Context, cmd *cli.Command) error {
+		script, ok := completionScripts[cmd.Args().First()]
+		if !ok {
+			return action(ctx, cmd)
+		}
+		_, err := fmt.Fprintf(os.Stdout, script, cmd.Root().Name)
+		return err
+	}
+}
+
+// completionScripts are the completion scripts by shell, with the program
+// name as their argument.
+var completionScripts = map[string]string{
+	"bash": bashCompletionScript,
+	"zsh":  zshCompletionScript,
+	"fish": fishCompletionScript,
+}
+
+const bashCompletionScript = `# bash completion for %[1]s
+
+__%[1]s_init_completion() {
+  COMPREPLY=()
+  _get_comp_words_by_ref "$@" cur prev words cword
+}
+
+__%[1]s_bash_autocomplete() {
+  if [[ "${COMP_WORDS[0]}" != "source" ]]; then
+    local cur opts words
+    COMPREPLY=()
+    cur="${COMP_WORDS[COMP_CWORD]}"
+    if declare -F _init_completion >/dev/null 2>&1; then
+      _init_completion -n "=:" || return
+    else
+      __%[1]s_init_completion -n "=:" || return
+    fi
+    words=("${words[@]:0:$cword}")
+    if [[ "$cur" == "-"* ]]; then
+      requestComp="env SHELL=bash ${words[*]} ${cur} --generate-shell-completion"
+    else
+      requestComp="env SHELL=bash ${words[*]} --generate-shell-completion"
+    fi
+    opts=$(eval "${requestComp}" 2>/dev/null)
+    COMPREPLY=($(compgen -W "${opts}" -- ${cur}))
+    return 0
+  fi
+}
+
+complete -o bashdefault -o default -o nospace -F __%[1]s_bash_autocomplete %[1]s
+`
+
+const zshCompletionScript = `#compdef %[1]s
+compdef _%[1]s %[1]s
+
+# zsh completion for %[1]s
+
+_%[1]s() {
+	local -a opts
+	local current
+	current=${words[-1]}
+	if [[ "$current" == "-"* ]]; then
+		opts=("${(@f)$(env SHELL=zsh ${words[@]:0:#words[@]-1} ${current} --generate-shell-completion 2>/dev/null)}")
+	else
+		opts=("${(@f)$(env SHELL=zsh ${words[@]:0:#words[@]-1} --generate-shell-completion 2>/dev/null)}")
+	fi
+
+	if [[ "${opts[1]}" != "" ]]; then
+		_describe 'values' opts
+	else
+		_files
+	fi
+}
+
+# Don't run the completion function when being sourced or evaluated.
+if [ "$funcstack[1]" = "_%[1]s" ]; then
+	_%[1]s
+fi
+`
+
+const fishCompletionScript = `# fish completion for %[1]s
+
+function __%[1]s_complete
+    set -l words (commandline -opc)
+    set -l current (commandline -ct)
+    if string match -q -- '-*' $current
+        env SHELL=fish $words $current --generate-shell-completion 2>/dev/null
+    else
+        env SHELL=fish $words --generate-shell-completion 2>/dev/null
+    end
+end
+
+complete -c %[1]s -f -a '(__%[1]s_complete)'
+`
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShellCompletion(t *testing.T) {
+	serverRequests := 0
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"GET /bareMetals/v2/servers": func(w http.ResponseWriter, r *http.Request) {
+			serverRequests++
+			jsonResponse(w, 200, map[string]any{
+				"servers": []map[string]any{
+					{"id": "101", "contract": map[string]any{"reference": "web 01"}},
+					{"id": "102"},
+				},
+				"_metadata": map[string]any{"totalCount": 2},
+			})
+		},
+		"GET /publicCloud/v1/regions": func(w http.ResponseWriter, r *http.Request) {
+			jsonResponse(w, 200, map[string]any{"regions": []map[string]any{{"name": "eu-west-3", "location": "Amsterdam"}}})
+		},
+		"GET /publicCloud/v1/instanceTypes": func(w http.ResponseWriter, r *http.Request) {
+			jsonResponse(w, 200, map[string]any{"instanceTypes": []map[string]any{{"name": "lsw.c3.large." + r.URL.Query().Get("region")}}})
+		},
+	})
+	defer srv.Close()
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(dir, ".cache"))
+	t.Setenv("SHELL", "/bin/zsh")
+
+	_, _, err := runCLI(t, "", []string{"bookmark", "add", "db1", "ds", "12490707"})
+	require.NoError(t, err)
+
+	stdout, _, err := runCLI(t, srv.URL, []string{"ds", "get", "--generate-shell-completion"})
+	require.NoError(t, err)
+	assert.Equal(t, "101:web 01\n102\n@db1:12490707\n", stdout)
+
+	// A second completion within the cache lifetime does not ask the API.
+	t.Setenv("SHELL", "/bin/bash")
+	stdout, _, err = runCLI(t, srv.URL, []string{"ds", "power-cycle", "--generate-shell-completion"})
+	require.NoError(t, err)
+	assert.Equal(t, "101\n102\n@db1\n", stdout)
+	assert.Equal(t, 1, serverRequests)
+
+	// Once the ID is given, nothing more is completed.
+	stdout, _, err = runCLI(t, srv.URL, []string{"ds", "get", "101", "--generate-shell-completion"})
+	require.NoError(t, err)
+	assert.Empty(t, stdout)
+
+	t.Setenv("SHELL", "/usr/bin/fish")
+	stdout, _, err = runCLI(t, srv.URL, []string{"instances", "launch", "--region", "--generate-shell-completion"})
+	require.NoError(t, err)
+	assert.Equal(t, "eu-west-3\tAmsterdam\n", stdout)
+
+	stdout, _, err = runCLI(t, srv.URL, []string{"instances", "launch", "--region", "ca-central-1", "--type", "--generate-shell-completion"})
+	require.NoError(t, err)
+	assert.Equal(t, "lsw.c3.large.ca-central-1\n", stdout)
+
+	stdout, _, err = runCLI(t, "", []string{"@completion", "fish"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "--generate-shell-completion")
+	assert.Contains(t, stdout, "complete -c lw -f -a '(__lw_complete)'")
+
+	// The scripts tell lw which shell completes, whatever the login shell.
+	stdout, _, err = runCLI(t, "", []string{"@completion", "bash"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout, `requestComp="env SHELL=bash ${words[*]} --generate-shell-completion"`)
+	stdout, _, err = runCLI(t, "", []string{"@completion", "zsh"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "$(env SHELL=zsh ${words[@]:0:#words[@]-1} --generate-shell-completion 2>/dev/null)")
+
+	// An api_key_command may prompt, so completion does not run it.
+	marker := filepath.Join(dir, "key-command-ran")
+	config := fmt.Sprintf(`default_profile: pw
+profiles:
+  pw:
+    api_key_command: "touch %s; echo pw-key"
+bookmarks:
+  db1:
+    type: dedicated-servers
+    id: "12490707"
+`, marker)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".config", "lw", "config.yaml"), []byte(config), 0600))
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(dir, ".cache-pw"))
+	stdout, _, err = runCLIWithKey(t, context.Background(), srv.URL, "", []string{"ds", "get", "--generate-shell-completion"})
+	require.NoError(t, err)
+	assert.Equal(t, "@db1\t12490707\n", stdout)
+	assert.NoFileExists(t, marker)
+	assert.Equal(t, 1, serverRequests)
+}
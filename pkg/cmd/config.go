@@ -1,24 +1,100 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
-	"strings"
+	"sort"
 
+	"github.com/kernel/leaseweb-cli/pkg/leaseweb"
 	"github.com/knadh/koanf/parsers/yaml"
 	"github.com/knadh/koanf/providers/file"
 	"github.com/knadh/koanf/v2"
 	"github.com/urfave/cli/v3"
 )
 
+// ErrNoAPIKey is returned when no API key is configured for the profile.
+var ErrNoAPIKey = errors.New("no API key found")
+
 type ProfileConfig struct {
 	APIKey string `koanf:"api_key"`
+	// APIKeyCommand, APIKeyFile and APIKeyEnv keep the key out of the config
+	// file: it is read from a command's output, a file or another variable.
+	APIKeyCommand string `koanf:"api_key_command"`
+	APIKeyFile    string `koanf:"api_key_file"`
+	APIKeyEnv     string `koanf:"api_key_env"`
+	Settings      `koanf:",squash"`
+}
+
+// Settings are the defaults a profile can set. The same keys at the top
+// level of the config file apply to every profile that leaves them unset.
+type Settings struct {
+	BaseURL string `koanf:"base_url"`
+	Output  string `koanf:"output"`
+	// DefaultRegion is used by commands with a --region flag when it is
+	// not given, and DefaultLocation by those with --location.
+	DefaultRegion   string  `koanf:"default_region"`
+	DefaultLocation string  `koanf:"default_location"`
+	Timeout         string  `koanf:"timeout"`
+	MaxRetries      *int    `koanf:"max_retries"`
+	RetryWait       string  `koanf:"retry_wait"`
+	RateLimit       float64 `koanf:"rate_limit"`
+	RateBurst       int     `koanf:"rate_burst"`
+	// Cache enables the on-disk cache of catalog responses.
+	Cache *bool `koanf:"cache"`
+}
+
+// merge returns s with the fields set in o taking their place.
+func (s Settings) merge(o Settings) Settings {
+	if o.BaseURL != "" {
+		s.BaseURL = o.BaseURL
+	}
+	if o.Output != "" {
+		s.Output = o.Output
+	}
+	if o.DefaultRegion != "" {
+		s.DefaultRegion = o.DefaultRegion
+	}
+	if o.DefaultLocation != "" {
+		s.DefaultLocation = o.DefaultLocation
+	}
+	if o.Timeout != "" {
+		s.Timeout = o.Timeout
+	}
+	if o.MaxRetries != nil {
+		s.MaxRetries = o.MaxRetries
+	}
+	if o.RetryWait != "" {
+		s.RetryWait = o.RetryWait
+	}
+	if o.RateLimit > 0 {
+		s.RateLimit = o.RateLimit
+	}
+	if o.RateBurst > 0 {
+		s.RateBurst = o.RateBurst
+	}
+	if o.Cache != nil {
+		s.Cache = o.Cache
+	}
+	return s
+}
+
+// cacheEnabled reports whether the cache setting is on.
+func (s Settings) cacheEnabled() bool {
+	return s.Cache != nil && *s.Cache
 }
 
 type CLIConfig struct {
-	DefaultProfile string                   `koanf:"default_profile"`
-	Profiles       map[string]ProfileConfig `koanf:"profiles"`
+	DefaultProfile string `koanf:"default_profile"`
+	// Settings holds the global defaults, overridden by each profile.
+	Settings `koanf:",squash"`
+	Profiles map[string]ProfileConfig `koanf:"profiles"`
+	// Aliases maps user-defined command names to what they expand to.
+	Aliases map[string]string `koanf:"aliases"`
+	// Bookmarks maps names usable as @name to the resources they stand for.
+	Bookmarks map[string]Bookmark `koanf:"bookmarks"`
 }
 
 func getConfigDir() string {
@@ -68,6 +144,20 @@ func resolveProfile(cmd *cli.Command) (string, error) {
 	return "", fmt.Errorf("no profile specified and no default profile set. Use -p <profile>, set LEASEWEB_PROFILE, or run 'lw config init'")
 }
 
+// resolveSettings returns the settings of the resolved profile laid over
+// the global ones. Flags and environment variables take precedence over
+// both; callers check those first.
+func resolveSettings(cmd *cli.Command) Settings {
+	cfg := loadConfig()
+	s := cfg.Settings
+	if profile, err := resolveProfile(cmd); err == nil {
+		if p, ok := cfg.Profiles[profile]; ok {
+			s = s.merge(p.Settings)
+		}
+	}
+	return s
+}
+
 func resolveAPIKey(cmd *cli.Command) (string, error) {
 	if k := os.Getenv("LEASEWEB_API_KEY"); k != "" {
 		return k, nil
@@ -77,40 +167,106 @@ func resolveAPIKey(cmd *cli.Command) (string, error) {
 		return "", err
 	}
 	cfg := loadConfig()
-	if p, ok := cfg.Profiles[profile]; ok && p.APIKey != "" {
-		return p.APIKey, nil
+	if p, ok := cfg.Profiles[profile]; ok {
+		key, err := profileAPIKey(profile, p)
+		if err != nil {
+			return "", err
+		}
+		if key != "" {
+			return key, nil
+		}
 	}
-	return "", fmt.Errorf("no API key found for profile %q. Set LEASEWEB_API_KEY or run 'lw config init'", profile)
+	return "", fmt.Errorf("%w for profile %q. Set LEASEWEB_API_KEY or run 'lw config init'", ErrNoAPIKey, profile)
+}
+
+func resolveBaseURL(cmd *cli.Command) string {
+	return baseURLFor(resolveSettings(cmd))
 }
 
-func resolveBaseURL() string {
+// baseURLFor returns LEASEWEB_BASE_URL, the base URL in s or the production
+// endpoint, in that order.
+func baseURLFor(s Settings) string {
 	if u := os.Getenv("LEASEWEB_BASE_URL"); u != "" {
 		return u
 	}
-	return "https://api.leaseweb.com"
+	if s.BaseURL != "" {
+		return s.BaseURL
+	}
+	return leaseweb.DefaultBaseURL
 }
 
-func writeConfig(cfg *CLIConfig) error {
-	dir := getConfigDir()
-	if dir == "" {
-		return fmt.Errorf("could not determine home directory")
+// resolveRegion returns --region, LEASEWEB_REGION or the default region of
+// the profile, in that order.
+func resolveRegion(cmd *cli.Command) (string, error) {
+	return resolveDefault(cmd, "region", "LEASEWEB_REGION", resolveSettings(cmd).DefaultRegion, "default_region")
+}
+
+// resolveLocation returns --location, LEASEWEB_LOCATION or the default
+// location of the profile, in that order.
+func resolveLocation(cmd *cli.Command) (string, error) {
+	return resolveDefault(cmd, "location", "LEASEWEB_LOCATION", resolveSettings(cmd).DefaultLocation, "default_location")
+}
+
+func resolveDefault(cmd *cli.Command, flag, env, setting, key string) (string, error) {
+	if v := cmd.String(flag); v != "" {
+		return v, nil
+	}
+	if v := os.Getenv(env); v != "" {
+		return v, nil
 	}
-	if err := os.MkdirAll(dir, 0700); err != nil {
-		return fmt.Errorf("creating config directory: %w", err)
+	if setting != "" {
+		return setting, nil
 	}
+	return "", fmt.Errorf("--%s is required: pass it, set %s or set %s in the profile", flag, env, key)
+}
 
-	var b strings.Builder
-	if cfg.DefaultProfile != "" {
-		fmt.Fprintf(&b, "default_profile: %s\n", cfg.DefaultProfile)
+// applyOutputSetting makes the profile's output format the default of
+// --output when neither the flag nor LEASEWEB_OUTPUT chose a format,
+// and records --columns for the output functions.
+func applyOutputSetting(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+	outputColumns = cmd.StringSlice("columns")
+	if cmd.IsSet("output") || os.Getenv("LEASEWEB_OUTPUT") != "" {
+		return ctx, nil
 	}
-	if len(cfg.Profiles) > 0 {
-		b.WriteString("profiles:\n")
-		for name, p := range cfg.Profiles {
-			fmt.Fprintf(&b, "  %s:\n", name)
-			fmt.Fprintf(&b, "    api_key: %q\n", p.APIKey)
+	if format := resolveSettings(cmd).Output; format != "" {
+		if err := checkOutputFormat(format); err != nil {
+			return ctx, fmt.Errorf("output setting in config: %w", err)
 		}
+		outputFormat = format
 	}
+	return ctx, nil
+}
 
-	path := getConfigPath()
-	return os.WriteFile(path, []byte(b.String()), 0600)
+// writeConfig saves cfg to the config file. Keys and comments in the file
+// that CLIConfig does not cover are kept.
+func writeConfig(cfg *CLIConfig) error {
+	doc, err := readConfigDoc()
+	if err != nil {
+		return err
+	}
+	root := doc.Content[0]
+	applyValues(root, append([]configValue{{"default_profile", cfg.DefaultProfile, "!!str"}}, settingValues(cfg.Settings)...))
+
+	if len(cfg.Profiles) == 0 {
+		deleteNode(root, "profiles")
+		return saveConfigDoc(doc)
+	}
+	profiles := ensureMapping(root, "profiles")
+	for _, name := range mappingKeys(profiles) {
+		if _, ok := cfg.Profiles[name]; !ok {
+			deleteNode(profiles, name)
+		}
+	}
+	names := profileNames(cfg)
+	sort.Strings(names)
+	for _, name := range names {
+		p := cfg.Profiles[name]
+		applyValues(ensureMapping(profiles, name), append([]configValue{
+			{keySourceConfig, p.APIKey, "!!str"},
+			{keySourceCommand, p.APIKeyCommand, "!!str"},
+			{keySourceFile, p.APIKeyFile, "!!str"},
+			{keySourceEnv, p.APIKeyEnv, "!!str"},
+		}, settingValues(p.Settings)...))
+	}
+	return saveConfigDoc(doc)
 }
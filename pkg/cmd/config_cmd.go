@@ -5,9 +5,11 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 
 	"github.com/urfave/cli/v3"
+	"go.yaml.in/yaml/v3"
 )
 
 var configCmd = cli.Command{
@@ -16,13 +18,34 @@ var configCmd = cli.Command{
 	Commands: []*cli.Command{
 		&configInitCmd,
 		&configShowCmd,
+		&configGetCmd,
+		&configSetCmd,
+		&configUnsetCmd,
+		&configUseCmd,
+		&configPathCmd,
+		&configProfilesCmd,
+		&configDoctorCmd,
+		&configRotateKeyCmd,
 	},
 	HideHelpCommand: true,
 }
 
+// apiKeySourceFlags set up a profile's API key without prompting.
+var apiKeySourceFlags = []cli.Flag{
+	&cli.BoolFlag{Name: "api-key-stdin", Usage: "Read the API key from the first line of stdin and store it in the config"},
+	&cli.StringFlag{Name: "api-key-command", Usage: "Read the API key from the output of `COMMAND` when needed"},
+	&cli.StringFlag{Name: "api-key-file", Usage: "Read the API key from `FILE` when needed"},
+	&cli.StringFlag{Name: "api-key-env", Usage: "Read the API key from the environment variable `NAME` when needed"},
+	&cli.BoolFlag{Name: "default", Usage: "Make the profile the default"},
+}
+
 var configInitCmd = cli.Command{
-	Name:            "init",
-	Usage:           "Initialize or update CLI configuration",
+	Name:  "init",
+	Usage: "Initialize or update CLI configuration",
+	Description: "Prompts for a profile and its API key. Given --profile and one of the --api-key-* flags,\n" +
+		"it runs without prompting, e.g. in CI:\n\n" +
+		"   echo \"$KEY\" | lw config init --profile ci --api-key-stdin --default",
+	Flags:           apiKeySourceFlags,
 	Action:          handleConfigInit,
 	HideHelpCommand: true,
 }
@@ -38,18 +61,34 @@ func handleConfigInit(_ context.Context, cmd *cli.Command) error {
 	reader := bufio.NewReader(os.Stdin)
 	cfg := loadConfig()
 
+	source, ok, err := keySourceFromFlags(cmd, reader)
+	if err != nil {
+		return err
+	}
+	if ok {
+		name := cmd.Root().String("profile")
+		if name == "" {
+			return fmt.Errorf("--profile is required with --api-key-stdin, --api-key-command, --api-key-file and --api-key-env")
+		}
+		return saveProfile(cfg, name, source, cmd.Bool("default"))
+	}
+
 	if len(cfg.Profiles) > 0 {
 		fmt.Printf("Existing profiles: %s\n", strings.Join(profileNames(cfg), ", "))
 		fmt.Println()
 	}
 
-	name := prompt(reader, `Profile name, e.g. "us" or "ca"`, "")
+	name := cmd.Root().String("profile")
+	if name == "" {
+		name = prompt(reader, `Profile name, e.g. "us" or "ca"`, "")
+	}
 	if name == "" {
 		return fmt.Errorf("profile name is required")
 	}
 
 	if p, ok := cfg.Profiles[name]; ok {
-		fmt.Printf("Profile %q already exists (API key: %s)\n", name, maskKey(p.APIKey))
+		source, detail := describeKeySource(p)
+		fmt.Printf("Profile %q already exists (API key from %s: %s)\n", name, source, detail)
 		overwrite := prompt(reader, "Overwrite? (y/n)", "n")
 		if strings.ToLower(overwrite) != "y" {
 			fmt.Println("Aborted.")
@@ -57,12 +96,33 @@ func handleConfigInit(_ context.Context, cmd *cli.Command) error {
 		}
 	}
 
-	apiKey := prompt(reader, fmt.Sprintf("API key for %q", name), "")
-	if apiKey == "" {
+	fmt.Println("Where should the API key be read from?")
+	fmt.Println("  key      stored in the config file")
+	fmt.Println("  command  output of a command, e.g. \"pass show leaseweb/us\"")
+	fmt.Println("  file     a file only you can read")
+	fmt.Println("  env      another environment variable")
+	var p ProfileConfig
+	switch source := strings.ToLower(prompt(reader, "API key source", "key")); source {
+	case "key":
+		p.APIKey = prompt(reader, fmt.Sprintf("API key for %q", name), "")
+	case "command":
+		p.APIKeyCommand = prompt(reader, "Command that prints the API key", "")
+	case "file":
+		p.APIKeyFile = prompt(reader, "File containing the API key", "")
+	case "env":
+		p.APIKeyEnv = prompt(reader, "Environment variable holding the API key", "")
+	default:
+		return fmt.Errorf("unknown API key source %q: use key, command, file or env", source)
+	}
+	if len(p.keySources()) == 0 {
 		return fmt.Errorf("API key is required")
 	}
+	if _, err := profileAPIKey(name, p); err != nil {
+		return err
+	}
 
-	cfg.Profiles[name] = ProfileConfig{APIKey: apiKey}
+	p.Settings = cfg.Profiles[name].Settings
+	cfg.Profiles[name] = p
 
 	defaultDefault := "n"
 	if cfg.DefaultProfile == "" || len(cfg.Profiles) == 1 {
@@ -98,19 +158,346 @@ func handleConfigShow(_ context.Context, cmd *cli.Command) error {
 	fmt.Printf("Active profile: %s\n", active)
 	fmt.Println()
 
-	table := NewTableWriter(os.Stdout, "PROFILE", "API KEY", "DEFAULT")
+	table := NewTableWriter(os.Stdout, "PROFILE", "KEY SOURCE", "API KEY", "DEFAULT")
 	for name, p := range cfg.Profiles {
-		key := maskKey(p.APIKey)
+		source, detail := describeKeySource(p)
 		def := ""
 		if name == cfg.DefaultProfile {
 			def = "*"
 		}
-		table.AddRow(name, key, def)
+		table.AddRow(name, source, detail, def)
 	}
 	table.Render()
 	return nil
 }
 
+var configGetCmd = cli.Command{
+	Name:      "get",
+	Usage:     "Print a config value, e.g. output or profiles.us.default_region",
+	ArgsUsage: "<key>",
+	Description: "API keys are masked; pass --reveal with profiles.<profile>.api_key to print the key\n" +
+		"itself.",
+	Flags: []cli.Flag{
+		&cli.BoolFlag{Name: "reveal", Usage: "Print the API key of profiles.<profile>.api_key unmasked"},
+	},
+	Action:          handleConfigGet,
+	HideHelpCommand: true,
+}
+
+var configSetCmd = cli.Command{
+	Name:            "set",
+	Usage:           "Set a config value, e.g. output json or profiles.us.default_region eu-west-3",
+	ArgsUsage:       "<key> <value>",
+	Action:          handleConfigSet,
+	HideHelpCommand: true,
+}
+
+var configUnsetCmd = cli.Command{
+	Name:            "unset",
+	Usage:           "Remove a config value",
+	ArgsUsage:       "<key>",
+	Action:          handleConfigUnset,
+	HideHelpCommand: true,
+}
+
+var configUseCmd = cli.Command{
+	Name:            "use",
+	Usage:           "Make a profile the default",
+	ArgsUsage:       "<profile>",
+	Action:          handleConfigUse,
+	HideHelpCommand: true,
+}
+
+var configPathCmd = cli.Command{
+	Name:            "path",
+	Usage:           "Print the path of the config file",
+	Action:          handleConfigPath,
+	HideHelpCommand: true,
+}
+
+var configProfilesCmd = cli.Command{
+	Name:  "profiles",
+	Usage: "Add, remove and rename profiles",
+	Commands: []*cli.Command{
+		&configProfilesAddCmd,
+		&configProfilesRemoveCmd,
+		&configProfilesRenameCmd,
+	},
+	HideHelpCommand: true,
+}
+
+var configProfilesAddCmd = cli.Command{
+	Name:            "add",
+	Usage:           "Add a profile; its API key source is given with one of the --api-key-* flags",
+	ArgsUsage:       "<profile>",
+	Flags:           apiKeySourceFlags,
+	Action:          handleConfigProfilesAdd,
+	HideHelpCommand: true,
+}
+
+var configProfilesRemoveCmd = cli.Command{
+	Name:            "remove",
+	Aliases:         []string{"rm"},
+	Usage:           "Remove a profile",
+	ArgsUsage:       "<profile>",
+	Action:          handleConfigProfilesRemove,
+	HideHelpCommand: true,
+}
+
+var configProfilesRenameCmd = cli.Command{
+	Name:            "rename",
+	Usage:           "Rename a profile",
+	ArgsUsage:       "<profile> <new-name>",
+	Action:          handleConfigProfilesRename,
+	HideHelpCommand: true,
+}
+
+func handleConfigGet(_ context.Context, cmd *cli.Command) error {
+	args := configArgs(cmd)
+	if len(args) != 1 {
+		return fmt.Errorf("expected one key")
+	}
+	key, err := parseConfigKey(args[0])
+	if err != nil {
+		return err
+	}
+	doc, err := readConfigDoc()
+	if err != nil {
+		return err
+	}
+	n := lookupNode(doc.Content[0], key.path()...)
+	if n == nil || isNullNode(n) {
+		return fmt.Errorf("%s is not set", args[0])
+	}
+	if n.Kind == yaml.ScalarNode {
+		value := n.Value
+		if key.profile != "" && key.name == "api_key" && !cmd.Bool("reveal") {
+			value = maskKey(value)
+		}
+		fmt.Println(value)
+		return nil
+	}
+	maskAPIKeys(n)
+	b, err := yaml.Marshal(n)
+	if err != nil {
+		return err
+	}
+	fmt.Print(string(b))
+	return nil
+}
+
+func handleConfigSet(_ context.Context, cmd *cli.Command) error {
+	args := configArgs(cmd)
+	if len(args) != 2 {
+		return fmt.Errorf("expected a key and a value")
+	}
+	key, err := parseConfigKey(args[0])
+	if err != nil {
+		return err
+	}
+	value := args[1]
+	tag, err := checkConfigValue(key, value)
+	if err != nil {
+		return err
+	}
+
+	doc, err := readConfigDoc()
+	if err != nil {
+		return err
+	}
+	root := doc.Content[0]
+	if key.profile != "" && lookupNode(root, "profiles", key.profile) == nil {
+		return fmt.Errorf("profile %q does not exist; add it with 'lw config profiles add'", key.profile)
+	}
+	if key.name == "default_profile" && lookupNode(root, "profiles", value) == nil {
+		return fmt.Errorf("profile %q does not exist", value)
+	}
+	// A profile reads its key from one source, so setting one drops the others.
+	if key.profile != "" && slices.Contains(keySourceKeys, key.name) {
+		for _, other := range keySourceKeys {
+			if other != key.name {
+				deleteNode(root, "profiles", key.profile, other)
+			}
+		}
+	}
+	setNode(root, key.path(), value, tag)
+	return saveConfigDoc(doc)
+}
+
+func handleConfigUnset(_ context.Context, cmd *cli.Command) error {
+	args := configArgs(cmd)
+	if len(args) != 1 {
+		return fmt.Errorf("expected one key")
+	}
+	key, err := parseConfigKey(args[0])
+	if err != nil {
+		return err
+	}
+	if key.profile != "" && key.name == "" {
+		return fmt.Errorf("use 'lw config profiles remove %s' to remove a profile", key.profile)
+	}
+	doc, err := readConfigDoc()
+	if err != nil {
+		return err
+	}
+	if !deleteNode(doc.Content[0], key.path()...) {
+		return fmt.Errorf("%s is not set", args[0])
+	}
+	return saveConfigDoc(doc)
+}
+
+func handleConfigUse(_ context.Context, cmd *cli.Command) error {
+	if cmd.Args().Len() != 1 {
+		return fmt.Errorf("profile name required")
+	}
+	name := cmd.Args().First()
+	doc, err := readConfigDoc()
+	if err != nil {
+		return err
+	}
+	root := doc.Content[0]
+	if lookupNode(root, "profiles", name) == nil {
+		return fmt.Errorf("profile %q does not exist", name)
+	}
+	setNode(root, []string{"default_profile"}, name, "!!str")
+	if err := saveConfigDoc(doc); err != nil {
+		return err
+	}
+	fmt.Printf("Default profile is now %q\n", name)
+	return nil
+}
+
+func handleConfigPath(_ context.Context, cmd *cli.Command) error {
+	path := getConfigPath()
+	if path == "" {
+		return fmt.Errorf("could not determine home directory")
+	}
+	fmt.Println(path)
+	return nil
+}
+
+func handleConfigProfilesAdd(_ context.Context, cmd *cli.Command) error {
+	if cmd.Args().Len() != 1 {
+		return fmt.Errorf("profile name required")
+	}
+	name := cmd.Args().First()
+	cfg := loadConfig()
+	if _, ok := cfg.Profiles[name]; ok {
+		return fmt.Errorf("profile %q already exists", name)
+	}
+	source, ok, err := keySourceFromFlags(cmd, bufio.NewReader(os.Stdin))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("an API key source is required: use --api-key-stdin, --api-key-command, --api-key-file or --api-key-env")
+	}
+	return saveProfile(cfg, name, source, cmd.Bool("default"))
+}
+
+func handleConfigProfilesRemove(_ context.Context, cmd *cli.Command) error {
+	if cmd.Args().Len() != 1 {
+		return fmt.Errorf("profile name required")
+	}
+	name := cmd.Args().First()
+	doc, err := readConfigDoc()
+	if err != nil {
+		return err
+	}
+	root := doc.Content[0]
+	if !deleteNode(root, "profiles", name) {
+		return fmt.Errorf("profile %q does not exist", name)
+	}
+	if n := lookupNode(root, "default_profile"); n != nil && n.Value == name {
+		deleteNode(root, "default_profile")
+		fmt.Fprintf(os.Stderr, "%q was the default profile; pick another with 'lw config use'\n", name)
+	}
+	if err := saveConfigDoc(doc); err != nil {
+		return err
+	}
+	fmt.Printf("Profile %q removed\n", name)
+	return nil
+}
+
+func handleConfigProfilesRename(_ context.Context, cmd *cli.Command) error {
+	if cmd.Args().Len() != 2 {
+		return fmt.Errorf("expected the profile and its new name")
+	}
+	old, name := cmd.Args().Get(0), cmd.Args().Get(1)
+	doc, err := readConfigDoc()
+	if err != nil {
+		return err
+	}
+	root := doc.Content[0]
+	if lookupNode(root, "profiles", name) != nil {
+		return fmt.Errorf("profile %q already exists", name)
+	}
+	if !renameNode(lookupNode(root, "profiles"), old, name) {
+		return fmt.Errorf("profile %q does not exist", old)
+	}
+	if n := lookupNode(root, "default_profile"); n != nil && n.Value == old {
+		n.Value = name
+	}
+	if err := saveConfigDoc(doc); err != nil {
+		return err
+	}
+	fmt.Printf("Profile %q renamed to %q\n", old, name)
+	return nil
+}
+
+// configArgs returns the arguments of cmd. urfave/cli puts an empty
+// command name in front of a first argument that is also the name of a flag
+// given on the command line, such as "output" after -o json; it is dropped.
+func configArgs(cmd *cli.Command) []string {
+	args := cmd.Args().Slice()
+	if len(args) > 1 && args[0] == "" {
+		return args[1:]
+	}
+	return args
+}
+
+// keySourceFromFlags returns the API key source given with the
+// --api-key-* flags, and false when none was given.
+func keySourceFromFlags(cmd *cli.Command, stdin *bufio.Reader) (ProfileConfig, bool, error) {
+	var p ProfileConfig
+	n := 0
+	if cmd.Bool("api-key-stdin") {
+		n++
+		line, _ := stdin.ReadString('\n')
+		if p.APIKey = strings.TrimSpace(line); p.APIKey == "" {
+			return p, false, fmt.Errorf("no API key on stdin")
+		}
+	}
+	if p.APIKeyCommand = cmd.String("api-key-command"); p.APIKeyCommand != "" {
+		n++
+	}
+	if p.APIKeyFile = cmd.String("api-key-file"); p.APIKeyFile != "" {
+		n++
+	}
+	if p.APIKeyEnv = cmd.String("api-key-env"); p.APIKeyEnv != "" {
+		n++
+	}
+	if n > 1 {
+		return p, false, fmt.Errorf("give only one of --api-key-stdin, --api-key-command, --api-key-file and --api-key-env")
+	}
+	return p, n == 1, nil
+}
+
+// saveProfile stores the key source of profile name, keeping its other
+// settings, and makes it the default when asked or when there is none.
+func saveProfile(cfg *CLIConfig, name string, source ProfileConfig, makeDefault bool) error {
+	source.Settings = cfg.Profiles[name].Settings
+	cfg.Profiles[name] = source
+	if makeDefault || cfg.DefaultProfile == "" {
+		cfg.DefaultProfile = name
+	}
+	if err := writeConfig(cfg); err != nil {
+		return fmt.Errorf("writing config: %w", err)
+	}
+	fmt.Printf("Profile %q saved to %s\n", name, getConfigPath())
+	return nil
+}
+
 func prompt(reader *bufio.Reader, label, defaultVal string) string {
 	if defaultVal != "" {
 		fmt.Printf("%s [%s]: ", label, defaultVal)
@@ -125,6 +512,21 @@ func prompt(reader *bufio.Reader, label, defaultVal string) string {
 	return input
 }
 
+// maskAPIKeys masks the api_key values in the mapping n and the mappings
+// it contains.
+func maskAPIKeys(n *yaml.Node) {
+	if n.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			if k, v := n.Content[i], n.Content[i+1]; k.Value == "api_key" && v.Kind == yaml.ScalarNode {
+				v.Value = maskKey(v.Value)
+			}
+		}
+	}
+	for _, c := range n.Content {
+		maskAPIKeys(c)
+	}
+}
+
 func maskKey(key string) string {
 	if len(key) <= 8 {
 		return strings.Repeat("*", len(key))
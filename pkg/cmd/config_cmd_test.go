@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigSubcommands(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	configDir := filepath.Join(dir, ".config", "lw")
+	require.NoError(t, os.MkdirAll(configDir, 0700))
+	configPath := filepath.Join(configDir, "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("# managed by hand\ncustom_key: keep\nprofiles:\n  us:\n    api_key: \"us-key\" # rotated in May\n"), 0600))
+
+	stdout, _, err := runCLI(t, "", []string{"config", "path"})
+	require.NoError(t, err)
+	assert.Equal(t, configPath+"\n", stdout)
+
+	// Non-interactive init reads the key from stdin.
+	oldStdin := os.Stdin
+	r, w, _ := os.Pipe()
+	os.Stdin = r
+	_, _ = w.WriteString("ci-key\n")
+	w.Close()
+	_, _, err = runCLI(t, "", []string{"config", "init", "--profile", "ci", "--api-key-stdin", "--default"})
+	os.Stdin = oldStdin
+	require.NoError(t, err)
+	cfg := loadConfig()
+	assert.Equal(t, "ci", cfg.DefaultProfile)
+	assert.Equal(t, "ci-key", cfg.Profiles["ci"].APIKey)
+
+	// config get masks API keys unless a single key is revealed.
+	stdout, _, err = runCLI(t, "", []string{"config", "get", "profiles.ci"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "api_key: '******'")
+	stdout, _, err = runCLI(t, "", []string{"config", "get", "profiles"})
+	require.NoError(t, err)
+	assert.NotContains(t, stdout, "ci-key")
+	stdout, _, err = runCLI(t, "", []string{"config", "get", "profiles.ci.api_key"})
+	require.NoError(t, err)
+	assert.Equal(t, "******\n", stdout)
+	stdout, _, err = runCLI(t, "", []string{"config", "get", "--reveal", "profiles.ci.api_key"})
+	require.NoError(t, err)
+	assert.Equal(t, "ci-key\n", stdout)
+
+	_, _, err = runCLI(t, "", []string{"config", "profiles", "add", "eu", "--api-key-env", "EU_KEY"})
+	require.NoError(t, err)
+	_, _, err = runCLI(t, "", []string{"config", "profiles", "add", "eu", "--api-key-env", "EU_KEY"})
+	require.ErrorContains(t, err, "already exists")
+
+	for _, args := range [][]string{
+		{"config", "set", "output", "json"},
+		{"config", "set", "profiles.eu.default_region", "eu-west-3"},
+		{"config", "set", "profiles.eu.max_retries", "5"},
+		{"config", "set", "profiles.eu.api_key_command", "pass show leaseweb/eu"},
+		{"config", "use", "eu"},
+		{"config", "profiles", "rename", "eu", "europe"},
+		{"config", "profiles", "remove", "ci"},
+		{"config", "unset", "output"},
+	} {
+		_, _, err := runCLI(t, "", args)
+		require.NoError(t, err, args)
+	}
+
+	stdout, _, err = runCLI(t, "", []string{"config", "get", "profiles.europe.default_region"})
+	require.NoError(t, err)
+	assert.Equal(t, "eu-west-3\n", stdout)
+
+	_, _, err = runCLI(t, "", []string{"config", "get", "output"})
+	require.ErrorContains(t, err, "not set")
+	_, _, err = runCLI(t, "", []string{"config", "set", "output", "xml"})
+	require.ErrorContains(t, err, "output must be one of")
+	_, _, err = runCLI(t, "", []string{"config", "set", "profiles.nope.output", "json"})
+	require.ErrorContains(t, err, "does not exist")
+	_, _, err = runCLI(t, "", []string{"config", "set", "colour", "red"})
+	require.ErrorContains(t, err, "unknown config key")
+
+	cfg = loadConfig()
+	assert.Equal(t, "europe", cfg.DefaultProfile)
+	assert.NotContains(t, cfg.Profiles, "ci")
+	europe := cfg.Profiles["europe"]
+	assert.Equal(t, "pass show leaseweb/eu", europe.APIKeyCommand)
+	assert.Empty(t, europe.APIKeyEnv, "setting a key source replaces the others")
+	require.NotNil(t, europe.MaxRetries)
+	assert.Equal(t, 5, *europe.MaxRetries)
+
+	b, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "# managed by hand")
+	assert.Contains(t, string(b), "custom_key: keep")
+	assert.Contains(t, string(b), "# rotated in May")
+	entries, err := os.ReadDir(configDir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "no temporary files are left behind")
+}
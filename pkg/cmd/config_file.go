@@ -0,0 +1,324 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// The config file is edited as a YAML node tree rather than rewritten from
+// CLIConfig, so keys lw does not know about and comments survive every write.
+
+// settingKeys are the keys of Settings. They are valid at the top level of
+// the config file and in each profile.
+var settingKeys = []string{
+	"base_url", "output", "default_region", "default_location", "timeout",
+	"max_retries", "retry_wait", "rate_limit", "rate_burst", "cache",
+}
+
+// keySourceKeys are the profile keys naming where the API key comes from.
+var keySourceKeys = []string{keySourceConfig, keySourceCommand, keySourceFile, keySourceEnv}
+
+// configKey is a dotted config key split into the profile it belongs to, if
+// any, and the setting within it.
+type configKey struct {
+	profile string
+	name    string
+}
+
+// parseConfigKey splits keys such as "output", "profiles.us" and
+// "profiles.us.output". Profile names may contain dots; the setting is
+// after the last one.
+func parseConfigKey(key string) (configKey, error) {
+	rest, ok := strings.CutPrefix(key, "profiles.")
+	if !ok {
+		return configKey{name: key}, nil
+	}
+	i := strings.LastIndex(rest, ".")
+	if i < 0 {
+		return configKey{profile: rest}, nil
+	}
+	if i == 0 || i == len(rest)-1 {
+		return configKey{}, fmt.Errorf("invalid key %q: use profiles.<profile>.<setting>", key)
+	}
+	return configKey{profile: rest[:i], name: rest[i+1:]}, nil
+}
+
+// path returns the key's path in the YAML mapping.
+func (k configKey) path() []string {
+	switch {
+	case k.profile == "":
+		return []string{k.name}
+	case k.name == "":
+		return []string{"profiles", k.profile}
+	}
+	return []string{"profiles", k.profile, k.name}
+}
+
+// checkConfigValue validates value for the setting k and returns the YAML
+// tag it is written with.
+func checkConfigValue(k configKey, value string) (string, error) {
+	switch {
+	case k.name == "":
+		return "", fmt.Errorf("profiles.%s is a profile; set one of its keys, e.g. profiles.%s.output", k.profile, k.profile)
+	case k.profile == "" && k.name == "default_profile":
+		return "!!str", nil
+	case k.profile != "" && slices.Contains(keySourceKeys, k.name):
+		return "!!str", nil
+	case !slices.Contains(settingKeys, k.name):
+		return "", fmt.Errorf("unknown config key %q; valid keys are default_profile, %s, and profiles.<profile>.<key> for those and %s",
+			k.name, strings.Join(settingKeys, ", "), strings.Join(keySourceKeys, ", "))
+	}
+	switch k.name {
+	case "output":
+		if err := checkOutputFormat(value); err != nil {
+			return "", err
+		}
+	case "timeout", "retry_wait":
+		if _, err := time.ParseDuration(value); err != nil {
+			return "", fmt.Errorf("%s must be a duration such as 30s: %w", k.name, err)
+		}
+	case "max_retries", "rate_burst":
+		if n, err := strconv.Atoi(value); err != nil || n < 0 {
+			return "", fmt.Errorf("%s must be a whole number of at least 0", k.name)
+		}
+		return "!!int", nil
+	case "rate_limit":
+		if f, err := strconv.ParseFloat(value, 64); err != nil || f < 0 {
+			return "", fmt.Errorf("rate_limit must be a number of at least 0")
+		}
+		return "!!float", nil
+	case "cache":
+		if _, err := strconv.ParseBool(value); err != nil {
+			return "", fmt.Errorf("cache must be true or false")
+		}
+		return "!!bool", nil
+	}
+	return "!!str", nil
+}
+
+// readConfigDoc parses the config file, or returns an empty document when
+// there is none yet.
+func readConfigDoc() (*yaml.Node, error) {
+	path := getConfigPath()
+	if path == "" {
+		return nil, fmt.Errorf("could not determine home directory")
+	}
+	doc := &yaml.Node{Kind: yaml.DocumentNode}
+	b, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	if len(bytes.TrimSpace(b)) > 0 {
+		if err := yaml.Unmarshal(b, doc); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", path, err)
+		}
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		if len(doc.Content) > 0 && !isNullNode(doc.Content[0]) {
+			return nil, fmt.Errorf("parsing %s: the top level must be a mapping", path)
+		}
+		doc.Content = []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}
+	}
+	return doc, nil
+}
+
+// saveConfigDoc writes doc to the config file through a temporary file and
+// a rename, so the file is never left half written.
+func saveConfigDoc(doc *yaml.Node) error {
+	dir := getConfigDir()
+	if dir == "" {
+		return fmt.Errorf("could not determine home directory")
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("creating config directory: %w", err)
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("encoding config: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("encoding config: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, ".config-*.yaml")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if err := tmp.Chmod(0600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filepath.Join(dir, "config.yaml"))
+}
+
+func isNullNode(n *yaml.Node) bool {
+	return n.Kind == yaml.ScalarNode && n.Tag == "!!null"
+}
+
+// lookupNode returns the node at path below the mapping m, or nil.
+func lookupNode(m *yaml.Node, path ...string) *yaml.Node {
+	for _, key := range path {
+		if m == nil || m.Kind != yaml.MappingNode {
+			return nil
+		}
+		var next *yaml.Node
+		for i := 0; i+1 < len(m.Content); i += 2 {
+			if m.Content[i].Value == key {
+				next = m.Content[i+1]
+				break
+			}
+		}
+		m = next
+	}
+	return m
+}
+
+// ensureMapping returns the mapping under key in m, creating it, or
+// replacing an empty value, as needed.
+func ensureMapping(m *yaml.Node, key string) *yaml.Node {
+	if n := lookupNode(m, key); n != nil {
+		if n.Kind != yaml.MappingNode {
+			*n = yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", HeadComment: n.HeadComment, LineComment: n.LineComment}
+		}
+		return n
+	}
+	n := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	m.Content = append(m.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, n)
+	return n
+}
+
+// setNode sets the scalar at path below m, creating mappings on the way.
+// A value that is already there is left alone so its quoting is kept.
+func setNode(m *yaml.Node, path []string, value, tag string) {
+	for _, key := range path[:len(path)-1] {
+		m = ensureMapping(m, key)
+	}
+	key := path[len(path)-1]
+	if n := lookupNode(m, key); n != nil {
+		if n.Kind == yaml.ScalarNode && n.Value == value && n.Tag == tag {
+			return
+		}
+		*n = yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: value, HeadComment: n.HeadComment, LineComment: n.LineComment}
+		return
+	}
+	m.Content = append(m.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: value})
+}
+
+// deleteNode removes the key at the end of path below m and reports whether
+// it was there.
+func deleteNode(m *yaml.Node, path ...string) bool {
+	m = lookupNode(m, path[:len(path)-1]...)
+	if m == nil || m.Kind != yaml.MappingNode {
+		return false
+	}
+	key := path[len(path)-1]
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			m.Content = slices.Delete(m.Content, i, i+2)
+			return true
+		}
+	}
+	return false
+}
+
+// renameNode renames the key old of mapping m to new, keeping its value and
+// comments.
+func renameNode(m *yaml.Node, old, new string) bool {
+	if m == nil || m.Kind != yaml.MappingNode {
+		return false
+	}
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == old {
+			m.Content[i].Value = new
+			return true
+		}
+	}
+	return false
+}
+
+// mappingKeys returns the keys of mapping m in file order.
+func mappingKeys(m *yaml.Node) []string {
+	if m == nil || m.Kind != yaml.MappingNode {
+		return nil
+	}
+	keys := make([]string, 0, len(m.Content)/2)
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		keys = append(keys, m.Content[i].Value)
+	}
+	return keys
+}
+
+// configValue is a setting as written to the config file. An empty value
+// means the setting is not set.
+type configValue struct {
+	key, value, tag string
+}
+
+// settingValues returns the settings in s as config values.
+func settingValues(s Settings) []configValue {
+	maxRetries := ""
+	if s.MaxRetries != nil {
+		maxRetries = strconv.Itoa(*s.MaxRetries)
+	}
+	rateLimit, rateBurst := "", ""
+	if s.RateLimit > 0 {
+		rateLimit = strconv.FormatFloat(s.RateLimit, 'g', -1, 64)
+	}
+	if s.RateBurst > 0 {
+		rateBurst = strconv.Itoa(s.RateBurst)
+	}
+	cache := ""
+	if s.Cache != nil {
+		cache = strconv.FormatBool(*s.Cache)
+	}
+	return []configValue{
+		{"base_url", s.BaseURL, "!!str"},
+		{"output", s.Output, "!!str"},
+		{"default_region", s.DefaultRegion, "!!str"},
+		{"default_location", s.DefaultLocation, "!!str"},
+		{"timeout", s.Timeout, "!!str"},
+		{"max_retries", maxRetries, "!!int"},
+		{"retry_wait", s.RetryWait, "!!str"},
+		{"rate_limit", rateLimit, "!!float"},
+		{"rate_burst", rateBurst, "!!int"},
+		{"cache", cache, "!!bool"},
+	}
+}
+
+// applyValues writes values into mapping m, removing those that are not set.
+func applyValues(m *yaml.Node, values []configValue) {
+	for _, v := range values {
+		if v.value == "" {
+			deleteNode(m, v.key)
+		} else {
+			setNode(m, []string{v.key}, v.value, v.tag)
+		}
+	}
+}
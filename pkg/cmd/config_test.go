@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func TestProfileSettings(t *testing.T) {
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"GET /publicCloud/v1/instanceTypes": func(w http.ResponseWriter, r *http.Request) {
+			region := r.URL.Query().Get("region")
+			if region == "slow" {
+				time.Sleep(200 * time.Millisecond)
+			}
+			jsonResponse(w, 200, map[string]any{"instanceTypes": []map[string]any{{"name": region}}})
+		},
+	})
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".config", "lw"), 0700))
+	config := fmt.Sprintf(`default_profile: eu
+output: json
+default_region: us-east-1
+profiles:
+  eu:
+    base_url: %q
+    default_region: eu-west-3
+    timeout: "50ms"
+    max_retries: 0
+`, srv.URL)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".config", "lw", "config.yaml"), []byte(config), 0600))
+
+	// The profile's region wins over the global one; the global output
+	// format applies because the profile sets none.
+	stdout, _, err := runCLI(t, "", []string{"instances", "types"})
+	require.NoError(t, err)
+	assert.Equal(t, "eu-west-3", gjson.Get(stdout, "instanceTypes.0.name").String())
+
+	// An explicit -o auto wins over the output setting.
+	stdout, _, err = runCLI(t, "", []string{"-o", "auto", "instances", "types"})
+	require.NoError(t, err)
+	assert.False(t, gjson.Valid(stdout), stdout)
+
+	t.Setenv("LEASEWEB_REGION", "ap-south-1")
+	stdout, _, err = runCLI(t, "", []string{"instances", "types"})
+	require.NoError(t, err)
+	assert.Equal(t, "ap-south-1", gjson.Get(stdout, "instanceTypes.0.name").String())
+
+	stdout, _, err = runCLI(t, "", []string{"instances", "types", "--region", "ca-central-1"})
+	require.NoError(t, err)
+	assert.Equal(t, "ca-central-1", gjson.Get(stdout, "instanceTypes.0.name").String())
+
+	_, _, err = runCLI(t, "", []string{"--max-retries", "0", "instances", "types", "--region", "slow"})
+	require.Error(t, err)
+	assert.Equal(t, ExitNetwork, ExitCode(err))
+
+	_, _, err = runCLI(t, "", []string{"--timeout", "5s", "instances", "types", "--region", "slow"})
+	require.NoError(t, err)
+}
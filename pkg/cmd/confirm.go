@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/tidwall/gjson"
+	"github.com/urfave/cli/v3"
+)
+
+// destructive describes a command that must be confirmed before it runs:
+// what it does and where the current details of its resource are fetched.
+type destructive struct {
+	// action describes the command; it is formatted with the arguments.
+	action string
+	// path is the GET path of the resource, formatted with the first
+	// argument. Without one, no details are shown.
+	path   string
+	fields []detailField
+}
+
+// detailField is a labelled GJSON path shown before asking for confirmation.
+type detailField struct {
+	label string
+	path  string
+}
+
+var (
+	instanceDetails  = []detailField{{"ID", "id"}, {"Reference", "reference"}, {"IP", "ips.#.ip"}, {"Type", "type"}, {"State", "state"}, {"Region", "region"}}
+	serverDetails    = []detailField{{"ID", "id"}, {"Reference", "contract.reference"}, {"IP", "networkInterfaces.public.ip"}, {"Site", "location.site"}}
+	equipmentDetails = []detailField{{"ID", "id"}, {"Name", "name"}, {"IP", "networkInterfaces.public.ip"}, {"Site", "location.site"}}
+	serviceDetails   = []detailField{{"ID", "id"}, {"Reference", "reference"}, {"Product", "productId"}, {"Equipment", "equipmentId"}, {"Status", "status"}}
+	networkDetails   = []detailField{{"ID", "id"}, {"Name", "name"}}
+	rangeDetails     = []detailField{{"ID", "id"}, {"Range", "range"}, {"Type", "type"}, {"Location", "location"}}
+	domainDetails    = []detailField{{"Domain", "domainName"}, {"Status", "status"}}
+	ipDetails        = []detailField{{"IP", "ip"}, {"Type", "type"}, {"Reverse lookup", "reverseLookup"}, {"Equipment", "equipmentId"}}
+	lbDetails        = []detailField{{"ID", "id"}, {"Reference", "reference"}, {"IP", "ips.#.ip"}, {"Type", "type.name"}, {"State", "state"}, {"Region", "region"}}
+)
+
+// confirmInput returns where answers to confirmation prompts are read from,
+// or nil when stdin is not a terminal.
+var confirmInput = func() io.Reader {
+	if isTerminal(os.Stdin) {
+		return os.Stdin
+	}
+	return nil
+}
+
+// confirm wraps action so it only runs once the user confirms it by typing
+// the resource ID or y. --yes and LEASEWEB_ASSUME_YES skip the prompt; without
+// them the command is refused when stdin is not a terminal. Dry runs send
+// nothing and are never asked about.
+func confirm(d destructive, action cli.ActionFunc) cli.ActionFunc {
+	return func(ctx context.Context, cmd *cli.Command) error {
+		args := cmd.Args().Slice()
+		n := len(strings.Fields(cmd.ArgsUsage))
+		root := cmd.Root()
+		if len(args) < n || root.Bool("yes") || assumeYes() || root.String("dry-run") != "" {
+			return action(ctx, cmd)
+		}
+
+		vals := make([]any, n)
+		for i := range vals {
+			vals[i] = args[i]
+		}
+		what := fmt.Sprintf(d.action, vals...)
+
+		in := confirmInput()
+		if in == nil {
+			return fmt.Errorf("refusing to %s without confirmation: stdin is not a terminal (use --yes or set LEASEWEB_ASSUME_YES=1)", what)
+		}
+
+		if d.path == "" {
+			fmt.Fprintf(os.Stderr, "You are about to %s.\n", what)
+		} else {
+			client, err := NewClient(cmd)
+			if err != nil {
+				return err
+			}
+			res, err := client.Get(ctx, fmt.Sprintf(d.path, args[0]))
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "You are about to %s:\n\n", what)
+			showDetailFields(os.Stderr, res, d.fields)
+			fmt.Fprintln(os.Stderr)
+		}
+		fmt.Fprintf(os.Stderr, "Type %q or 'y' to continue: ", args[0])
+
+		answer, _ := bufio.NewReader(in).ReadString('\n')
+		answer = strings.TrimSpace(answer)
+		if answer != args[0] && !strings.EqualFold(answer, "y") && !strings.EqualFold(answer, "yes") {
+			return fmt.Errorf("aborted: %s was not confirmed", what)
+		}
+		return action(ctx, cmd)
+	}
+}
+
+// assumeYes reports whether LEASEWEB_ASSUME_YES is set to a true value.
+func assumeYes() bool {
+	v, _ := strconv.ParseBool(os.Getenv("LEASEWEB_ASSUME_YES"))
+	return v
+}
+
+// showDetailFields prints the fields of res that are set, one per line.
+func showDetailFields(w io.Writer, res gjson.Result, fields []detailField) {
+	width := 0
+	for _, f := range fields {
+		width = max(width, len(f.label))
+	}
+	for _, f := range fields {
+		v := res.Get(f.path)
+		var value string
+		if v.IsArray() {
+			var items []string
+			for _, item := range v.Array() {
+				items = append(items, formatValue(item))
+			}
+			value = strings.Join(items, ", ")
+		} else if v.Exists() && v.Type != gjson.Null {
+			value = formatValue(v)
+		}
+		if value != "" {
+			fmt.Fprintf(w, "  %-*s  %s\n", width, f.label, value)
+		}
+	}
+}
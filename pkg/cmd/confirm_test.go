@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+)
+
+func TestConfirmation(t *testing.T) {
+	var terminated int
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"GET /publicCloud/v1/instances/abc": func(w http.ResponseWriter, r *http.Request) {
+			jsonResponse(w, 200, map[string]any{
+				"id":        "abc",
+				"reference": "web-1",
+				"ips":       []map[string]any{{"ip": "10.0.0.1"}, {"ip": "10.0.0.2"}},
+				"state":     "RUNNING",
+			})
+		},
+		"DELETE /publicCloud/v1/instances/abc": func(w http.ResponseWriter, r *http.Request) {
+			terminated++
+			w.WriteHeader(http.StatusNoContent)
+		},
+	})
+	defer srv.Close()
+
+	_, _, err := runCLI(t, srv.URL, []string{"instances", "terminate", "abc"})
+	assert.ErrorContains(t, err, "refusing to terminate instance abc without confirmation")
+	assert.Equal(t, 0, terminated)
+
+	oldInput := confirmInput
+	defer func() { confirmInput = oldInput }()
+
+	confirmInput = func() io.Reader { return strings.NewReader("n\n") }
+	_, stderr, err := runCLI(t, srv.URL, []string{"instances", "terminate", "abc"})
+	assert.ErrorContains(t, err, "aborted")
+	assert.Contains(t, stderr, "You are about to terminate instance abc:")
+	assert.Contains(t, stderr, "Reference  web-1")
+	assert.Contains(t, stderr, "IP         10.0.0.1, 10.0.0.2")
+	assert.Equal(t, 0, terminated)
+
+	confirmInput = func() io.Reader { return strings.NewReader("abc\n") }
+	_, _, err = runCLI(t, srv.URL, []string{"instances", "terminate", "abc"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, terminated)
+
+	confirmInput = oldInput
+	_, _, err = runCLI(t, srv.URL, []string{"--yes", "instances", "terminate", "abc"})
+	require.NoError(t, err)
+	t.Setenv("LEASEWEB_ASSUME_YES", "1")
+	_, _, err = runCLI(t, srv.URL, []string{"instances", "terminate", "abc"})
+	require.NoError(t, err)
+	assert.Equal(t, 3, terminated)
+}
+
+// destructiveWords mark a command that deletes, wipes or cuts off a
+// resource when they appear in its name.
+var destructiveWords = []string{"delete", "terminate", "install", "reinstall", "null", "cancel", "remove"}
+
+// notDestructive are commands named with a destructive word that only read
+// or undo something.
+var notDestructive = []string{
+	"reinstall-images",           // lists images
+	"null-route-history",         // reads
+	"inspect-null-route-history", // reads
+	"null-route-get",             // reads
+	"null-route-update",          // edits the comment of a null route
+	"null-routed-ipv6",           // reads
+	"remove-null-route",          // lifts a null route
+	"cancel-termination",         // undoes terminate
+	"job-cancel",                 // stops a running job
+}
+
+// TestDestructiveCommandsConfirm runs every command that deletes, wipes or
+// cuts off a resource without --yes and with stdin not a terminal, and
+// expects each to refuse before sending a request.
+func TestDestructiveCommandsConfirm(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unconfirmed request %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("LEASEWEB_ASSUME_YES", "")
+	oldInput := confirmInput
+	defer func() { confirmInput = oldInput }()
+	confirmInput = func() io.Reader { return nil }
+
+	looksDestructive := func(c *cli.Command) bool {
+		if op, ok := generatedOperations[c]; ok && op.Method == http.MethodDelete {
+			return true
+		}
+		if slices.Contains(notDestructive, c.Name) {
+			return false
+		}
+		if c.Name == "power-off" {
+			return true
+		}
+		for _, w := range strings.Split(c.Name, "-") {
+			if slices.Contains(destructiveWords, w) {
+				return true
+			}
+		}
+		return false
+	}
+
+	checked := 0
+	var walk func(c *cli.Command, path []string)
+	walk = func(c *cli.Command, path []string) {
+		for _, sub := range c.Commands {
+			walk(sub, append(slices.Clone(path), sub.Name))
+		}
+		if c.Action == nil || len(c.Commands) > 0 || !looksDestructive(c) {
+			return
+		}
+		args := append([]string{"--max-retries", "0"}, path...)
+		for _, f := range c.Flags {
+			if r, ok := f.(cli.RequiredFlag); ok && r.IsRequired() {
+				args = append(args, "--"+f.Names()[0], "1")
+			}
+		}
+		for range strings.Fields(c.ArgsUsage) {
+			args = append(args, "1")
+		}
+		_, _, err := runCLI(t, srv.URL, args)
+		assert.ErrorContains(t, err, "without confirmation", strings.Join(path, " "))
+		checked++
+	}
+	for _, group := range Command.Commands {
+		if !slices.Contains(unresolvedCommands, group.Name) {
+			walk(group, []string{group.Name})
+		}
+	}
+	assert.Greater(t, checked, 80)
+}
@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// Key sources a profile can take its API key from. Only one may be set.
+const (
+	keySourceConfig  = "api_key"
+	keySourceCommand = "api_key_command"
+	keySourceFile    = "api_key_file"
+	keySourceEnv     = "api_key_env"
+)
+
+// keySources returns the key sources set in p, in the order above.
+func (p ProfileConfig) keySources() []string {
+	var sources []string
+	if p.APIKey != "" {
+		sources = append(sources, keySourceConfig)
+	}
+	if p.APIKeyCommand != "" {
+		sources = append(sources, keySourceCommand)
+	}
+	if p.APIKeyFile != "" {
+		sources = append(sources, keySourceFile)
+	}
+	if p.APIKeyEnv != "" {
+		sources = append(sources, keySourceEnv)
+	}
+	return sources
+}
+
+// describeKeySource returns the key source of p and where it points, without
+// the key itself: the masked key, the command, the file or the variable name.
+func describeKeySource(p ProfileConfig) (source, detail string) {
+	sources := p.keySources()
+	switch {
+	case len(sources) == 0:
+		return "-", ""
+	case len(sources) > 1:
+		return "conflict", strings.Join(sources, ", ")
+	}
+	switch sources[0] {
+	case keySourceCommand:
+		return "command", p.APIKeyCommand
+	case keySourceFile:
+		return "file", p.APIKeyFile
+	case keySourceEnv:
+		return "env", "$" + p.APIKeyEnv
+	}
+	return "config", maskKey(p.APIKey)
+}
+
+// apiKeyCache holds the keys read from external sources, so a helper such as
+// `pass` runs at most once per process.
+var apiKeyCache = struct {
+	sync.Mutex
+	keys map[string]string
+}{keys: map[string]string{}}
+
+// profileAPIKey returns the API key of profile p from whichever source it
+// configures. It returns "" when p has no key source.
+func profileAPIKey(name string, p ProfileConfig) (string, error) {
+	sources := p.keySources()
+	switch {
+	case len(sources) == 0:
+		return "", nil
+	case len(sources) > 1:
+		return "", fmt.Errorf("profile %q sets more than one API key source (%s); keep only one", name, strings.Join(sources, ", "))
+	case sources[0] == keySourceConfig:
+		return p.APIKey, nil
+	}
+
+	cacheKey := sources[0] + "\x00" + p.APIKeyCommand + p.APIKeyFile + p.APIKeyEnv
+	apiKeyCache.Lock()
+	defer apiKeyCache.Unlock()
+	if key, ok := apiKeyCache.keys[cacheKey]; ok {
+		return key, nil
+	}
+
+	var key string
+	var err error
+	switch sources[0] {
+	case keySourceCommand:
+		key, err = keyFromCommand(p.APIKeyCommand)
+	case keySourceFile:
+		key, err = keyFromFile(p.APIKeyFile)
+	case keySourceEnv:
+		key = strings.TrimSpace(os.Getenv(p.APIKeyEnv))
+		if key == "" {
+			err = fmt.Errorf("environment variable %s is not set", p.APIKeyEnv)
+		}
+	}
+	if err != nil {
+		return "", fmt.Errorf("reading API key of profile %q from %s: %w", name, sources[0], err)
+	}
+	apiKeyCache.keys[cacheKey] = key
+	return key, nil
+}
+
+// keyFromCommand runs command with the shell and returns the first line of
+// its output. Stdin and stderr are left attached so helpers can prompt for a
+// passphrase.
+func keyFromCommand(command string) (string, error) {
+	c := shellCommand(command)
+	var stdout bytes.Buffer
+	c.Stdin = os.Stdin
+	c.Stdout = &stdout
+	c.Stderr = os.Stderr
+	if err := c.Run(); err != nil {
+		return "", fmt.Errorf("%q failed: %w", command, err)
+	}
+	key := firstLine(stdout.String())
+	if key == "" {
+		return "", fmt.Errorf("%q printed nothing", command)
+	}
+	return key, nil
+}
+
+// shellCommand returns command run by the shell of the platform.
+func shellCommand(command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.Command("cmd", "/C", command)
+	}
+	return exec.Command("sh", "-c", command)
+}
+
+// keyFromFile returns the first line of path, which must not be readable by
+// other users.
+func keyFromFile(path string) (string, error) {
+	path = expandHome(path)
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0077 != 0 {
+		return "", fmt.Errorf("%s is accessible by other users (mode %04o); run 'chmod 600 %s'", path, info.Mode().Perm(), path)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	key := firstLine(string(b))
+	if key == "" {
+		return "", fmt.Errorf("%s is empty", path)
+	}
+	return key, nil
+}
+
+// firstLine returns the first line of s without surrounding space; password
+// managers print metadata after the secret.
+func firstLine(s string) string {
+	s, _, _ = strings.Cut(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(s)
+}
+
+// expandHome replaces a leading ~ in path with the home directory.
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[1:])
+}
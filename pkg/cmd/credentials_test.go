@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeySources(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+
+	keyFile := filepath.Join(dir, "us.key")
+	require.NoError(t, os.WriteFile(keyFile, []byte("file-key\n"), 0644))
+	_, err := profileAPIKey("us", ProfileConfig{APIKeyFile: keyFile})
+	require.ErrorContains(t, err, "chmod 600")
+	require.NoError(t, os.Chmod(keyFile, 0600))
+	key, err := profileAPIKey("us", ProfileConfig{APIKeyFile: keyFile})
+	require.NoError(t, err)
+	assert.Equal(t, "file-key", key)
+
+	// The command runs once per process; later lookups are cached.
+	counter := filepath.Join(dir, "runs")
+	command := fmt.Sprintf("echo x >> %s; printf 'command-key\\nlogin: me\\n'", counter)
+	for range 2 {
+		key, err = profileAPIKey("ca", ProfileConfig{APIKeyCommand: command})
+		require.NoError(t, err)
+		assert.Equal(t, "command-key", key)
+	}
+	runs, err := os.ReadFile(counter)
+	require.NoError(t, err)
+	assert.Equal(t, "x\n", string(runs))
+
+	t.Setenv("LW_TEST_KEY", "env-key")
+	key, err = profileAPIKey("eu", ProfileConfig{APIKeyEnv: "LW_TEST_KEY"})
+	require.NoError(t, err)
+	assert.Equal(t, "env-key", key)
+
+	_, err = profileAPIKey("both", ProfileConfig{APIKey: "a", APIKeyEnv: "LW_TEST_KEY"})
+	require.ErrorContains(t, err, "more than one API key source")
+
+	require.NoError(t, writeConfig(&CLIConfig{
+		DefaultProfile: "us",
+		Profiles: map[string]ProfileConfig{
+			"us": {APIKeyCommand: "pass show leaseweb/us"},
+			"ca": {APIKey: "BD483105-0000-0000-0000-000000000000"},
+		},
+	}))
+	cfg := loadConfig()
+	assert.Equal(t, "pass show leaseweb/us", cfg.Profiles["us"].APIKeyCommand)
+	assert.Empty(t, cfg.Profiles["us"].APIKey)
+
+	stdout, _, err := runCLI(t, "", []string{"config", "show"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "pass show leaseweb/us")
+	assert.Contains(t, stdout, "BD48****")
+	assert.NotContains(t, stdout, "BD483105-0000")
+}
@@ -50,7 +50,7 @@ var dcaListAccessCmd = cli.Command{Name: "list", Usage: "List access requests",
 	if err != nil {
 		return err
 	}
-	res, err := client.Get(ctx, "/datacenterAccess/v1/accessRequests?"+PaginationQuery(cmd))
+	res, err := GetList(ctx, client, cmd, "/datacenterAccess/v1/accessRequests?"+PaginationQuery(cmd))
 	if err != nil {
 		return err
 	}
@@ -62,7 +62,11 @@ var dcaCreateAccessCmd = cli.Command{Name: "create", Usage: "Create access reque
 	if err != nil {
 		return err
 	}
-	res, err := client.PostJSON(ctx, "/datacenterAccess/v1/accessRequests", []byte(cmd.String("payload")))
+	body, err := loadPayload(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := client.PostJSON(ctx, "/datacenterAccess/v1/accessRequests", body)
 	if err != nil {
 		return err
 	}
@@ -94,14 +98,18 @@ var dcaUpdateAccessCmd = cli.Command{Name: "update", Usage: "Update access reque
 	if err != nil {
 		return err
 	}
-	res, err := client.PutJSON(ctx, "/datacenterAccess/v1/accessRequests/"+args[0], []byte(cmd.String("payload")))
+	body, err := loadPayload(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := client.PutJSON(ctx, "/datacenterAccess/v1/accessRequests/"+args[0], body)
 	if err != nil {
 		return err
 	}
 	return ShowResult(os.Stdout, res, cmd.Root().String("output"), cmd.Root().String("transform"))
 }, HideHelpCommand: true}
 
-var dcaDeleteAccessCmd = cli.Command{Name: "delete", Usage: "Delete access request", ArgsUsage: "<id>", Action: func(ctx context.Context, cmd *cli.Command) error {
+var dcaDeleteAccessCmd = cli.Command{Name: "delete", Usage: "Delete access request", ArgsUsage: "<id>", Action: confirm(destructive{action: "delete datacenter access request %s"}, func(ctx context.Context, cmd *cli.Command) error {
 	args := cmd.Args().Slice()
 	if len(args) < 1 {
 		return fmt.Errorf("access request ID required")
@@ -116,7 +124,7 @@ var dcaDeleteAccessCmd = cli.Command{Name: "delete", Usage: "Delete access reque
 	}
 	fmt.Fprintf(os.Stderr, "Deleted access request %s\n", args[0])
 	return nil
-}, HideHelpCommand: true}
+}), HideHelpCommand: true}
 
 var dcaListVisitorsCmd = cli.Command{Name: "visitors", Usage: "List visitors for request", ArgsUsage: "<id>", Action: func(ctx context.Context, cmd *cli.Command) error {
 	args := cmd.Args().Slice()
@@ -31,7 +31,7 @@ func handleNEList(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	res, err := client.Get(ctx, "/bareMetals/v2/networkEquipments?"+PaginationQuery(cmd))
+	res, err := GetList(ctx, client, cmd, "/bareMetals/v2/networkEquipments?"+PaginationQuery(cmd))
 	if err != nil {
 		return err
 	}
@@ -181,7 +181,7 @@ func handleNECredentialUpdate(ctx context.Context, cmd *cli.Command) error {
 	return ShowResult(os.Stdout, res, cmd.Root().String("output"), cmd.Root().String("transform"))
 }
 
-var neCredentialDeleteCmd = cli.Command{Name: "credential-delete", Usage: "Delete credential", ArgsUsage: "<id> <type> <username>", Action: handleNECredentialDelete, HideHelpCommand: true}
+var neCredentialDeleteCmd = cli.Command{Name: "credential-delete", Usage: "Delete credential", ArgsUsage: "<id> <type> <username>", Action: confirm(destructive{"delete the %[2]s credential %[3]s of network equipment %[1]s", "/bareMetals/v2/networkEquipments/%s", equipmentDetails}, handleNECredentialDelete), HideHelpCommand: true}
 
 func handleNECredentialDelete(ctx context.Context, cmd *cli.Command) error {
 	args := cmd.Args().Slice()
@@ -259,7 +259,7 @@ func handleNEIPUpdate(ctx context.Context, cmd *cli.Command) error {
 	return ShowResult(os.Stdout, res, cmd.Root().String("output"), cmd.Root().String("transform"))
 }
 
-var neIPNullCmd = cli.Command{Name: "ip-null", Usage: "Null route IP", ArgsUsage: "<id> <ip>", Action: handleNEIPNull, HideHelpCommand: true}
+var neIPNullCmd = cli.Command{Name: "ip-null", Usage: "Null route IP", ArgsUsage: "<id> <ip>", Action: confirm(destructive{"null route IP %[2]s of network equipment %[1]s", "/bareMetals/v2/networkEquipments/%s", equipmentDetails}, handleNEIPNull), HideHelpCommand: true}
 
 func handleNEIPNull(ctx context.Context, cmd *cli.Command) error {
 	args := cmd.Args().Slice()
@@ -348,7 +348,7 @@ var nePowerStatusCmd = cli.Command{Name: "power-status", Usage: "Show power stat
 	return ShowResult(os.Stdout, res, cmd.Root().String("output"), cmd.Root().String("transform"))
 }, HideHelpCommand: true}
 
-var nePowerOffCmd = cli.Command{Name: "power-off", Usage: "Power off", ArgsUsage: "<id>", Action: func(ctx context.Context, cmd *cli.Command) error {
+var nePowerOffCmd = cli.Command{Name: "power-off", Usage: "Power off", ArgsUsage: "<id>", Action: confirm(destructive{"power off network equipment %s", "/bareMetals/v2/networkEquipments/%s", equipmentDetails}, func(ctx context.Context, cmd *cli.Command) error {
 	args := cmd.Args().Slice()
 	if len(args) < 1 {
 		return fmt.Errorf("ID required")
@@ -363,7 +363,7 @@ var nePowerOffCmd = cli.Command{Name: "power-off", Usage: "Power off", ArgsUsage
 	}
 	fmt.Fprintf(os.Stderr, "Powered off %s\n", args[0])
 	return nil
-}, HideHelpCommand: true}
+}), HideHelpCommand: true}
 
 var nePowerOnCmd = cli.Command{Name: "power-on", Usage: "Power on", ArgsUsage: "<id>", Action: func(ctx context.Context, cmd *cli.Command) error {
 	args := cmd.Args().Slice()
@@ -34,7 +34,7 @@ var drListCmd = cli.Command{Name: "list", Usage: "List dedicated racks", Flags:
 	if err != nil {
 		return err
 	}
-	res, err := client.Get(ctx, "/bareMetals/v2/privateRacks?"+PaginationQuery(cmd))
+	res, err := GetList(ctx, client, cmd, "/bareMetals/v2/privateRacks?"+PaginationQuery(cmd))
 	if err != nil {
 		return err
 	}
@@ -156,7 +156,7 @@ var drCredentialUpdateCmd = cli.Command{Name: "credential-update", Usage: "Updat
 	return ShowResult(os.Stdout, res, cmd.Root().String("output"), cmd.Root().String("transform"))
 }, HideHelpCommand: true}
 
-var drCredentialDeleteCmd = cli.Command{Name: "credential-delete", Usage: "Delete credential", ArgsUsage: "<id> <type> <username>", Action: func(ctx context.Context, cmd *cli.Command) error {
+var drCredentialDeleteCmd = cli.Command{Name: "credential-delete", Usage: "Delete credential", ArgsUsage: "<id> <type> <username>", Action: confirm(destructive{"delete the %[2]s credential %[3]s of private rack %[1]s", "/bareMetals/v2/privateRacks/%s", serverDetails}, func(ctx context.Context, cmd *cli.Command) error {
 	args := cmd.Args().Slice()
 	if len(args) < 3 {
 		return fmt.Errorf("rack ID, type, and username required")
@@ -171,7 +171,7 @@ var drCredentialDeleteCmd = cli.Command{Name: "credential-delete", Usage: "Delet
 	}
 	fmt.Fprintf(os.Stderr, "Deleted credential %s/%s\n", args[1], args[2])
 	return nil
-}, HideHelpCommand: true}
+}), HideHelpCommand: true}
 
 var drIPsCmd = cli.Command{Name: "ips", Usage: "List IPs", ArgsUsage: "<id>", Action: func(ctx context.Context, cmd *cli.Command) error {
 	args := cmd.Args().Slice()
@@ -222,7 +222,7 @@ var drIPUpdateCmd = cli.Command{Name: "ip-update", ArgsUsage: "<id> <ip>", Flags
 	return ShowResult(os.Stdout, res, cmd.Root().String("output"), cmd.Root().String("transform"))
 }, HideHelpCommand: true}
 
-var drIPNullCmd = cli.Command{Name: "ip-null", ArgsUsage: "<id> <ip>", Action: func(ctx context.Context, cmd *cli.Command) error {
+var drIPNullCmd = cli.Command{Name: "ip-null", ArgsUsage: "<id> <ip>", Action: confirm(destructive{"null route IP %[2]s of private rack %[1]s", "/bareMetals/v2/privateRacks/%s", serverDetails}, func(ctx context.Context, cmd *cli.Command) error {
 	args := cmd.Args().Slice()
 	if len(args) < 2 {
 		return fmt.Errorf("rack ID and IP required")
@@ -237,7 +237,7 @@ var drIPNullCmd = cli.Command{Name: "ip-null", ArgsUsage: "<id> <ip>", Action: f
 	}
 	fmt.Fprintf(os.Stderr, "Null routed %s\n", args[1])
 	return nil
-}, HideHelpCommand: true}
+}), HideHelpCommand: true}
 
 var drIPUnnullCmd = cli.Command{Name: "ip-unnull", ArgsUsage: "<id> <ip>", Action: func(ctx context.Context, cmd *cli.Command) error {
 	args := cmd.Args().Slice()
@@ -337,7 +337,11 @@ func drNotifHandler(settingType, method string) func(context.Context, *cli.Comma
 			if len(args) < 1 {
 				return fmt.Errorf("rack ID required")
 			}
-			res, err := client.PostJSON(ctx, base, []byte(cmd.String("payload")))
+			body, err := loadPayload(cmd)
+			if err != nil {
+				return err
+			}
+			res, err := client.PostJSON(ctx, base, body)
 			if err != nil {
 				return err
 			}
@@ -346,7 +350,11 @@ func drNotifHandler(settingType, method string) func(context.Context, *cli.Comma
 			if len(args) < 2 {
 				return fmt.Errorf("rack ID and notification ID required")
 			}
-			res, err := client.PutJSON(ctx, base+"/"+args[1], []byte(cmd.String("payload")))
+			body, err := loadPayload(cmd)
+			if err != nil {
+				return err
+			}
+			res, err := client.PutJSON(ctx, base+"/"+args[1], body)
 			if err != nil {
 				return err
 			}
@@ -366,18 +374,16 @@ func drNotifHandler(settingType, method string) func(context.Context, *cli.Comma
 	}
 }
 
-var payloadFlag = []cli.Flag{&cli.StringFlag{Name: "payload", Required: true}}
-
 var drNotifBandwidthListCmd = cli.Command{Name: "notif-bandwidth-list", ArgsUsage: "<id>", Action: drNotifHandler("bandwidth", "list"), HideHelpCommand: true}
 var drNotifBandwidthCreateCmd = cli.Command{Name: "notif-bandwidth-create", ArgsUsage: "<id>", Flags: payloadFlag, Action: drNotifHandler("bandwidth", "create"), HideHelpCommand: true}
 var drNotifBandwidthGetCmd = cli.Command{Name: "notif-bandwidth-get", ArgsUsage: "<id> <nid>", Action: drNotifHandler("bandwidth", "get"), HideHelpCommand: true}
 var drNotifBandwidthUpdateCmd = cli.Command{Name: "notif-bandwidth-update", ArgsUsage: "<id> <nid>", Flags: payloadFlag, Action: drNotifHandler("bandwidth", "update"), HideHelpCommand: true}
-var drNotifBandwidthDeleteCmd = cli.Command{Name: "notif-bandwidth-delete", ArgsUsage: "<id> <nid>", Action: drNotifHandler("bandwidth", "delete"), HideHelpCommand: true}
+var drNotifBandwidthDeleteCmd = cli.Command{Name: "notif-bandwidth-delete", ArgsUsage: "<id> <nid>", Action: confirm(destructive{"delete bandwidth notification setting %[2]s of private rack %[1]s", "/bareMetals/v2/privateRacks/%s", serverDetails}, drNotifHandler("bandwidth", "delete")), HideHelpCommand: true}
 var drNotifDatatrafficListCmd = cli.Command{Name: "notif-datatraffic-list", ArgsUsage: "<id>", Action: drNotifHandler("datatraffic", "list"), HideHelpCommand: true}
 var drNotifDatatrafficCreateCmd = cli.Command{Name: "notif-datatraffic-create", ArgsUsage: "<id>", Flags: payloadFlag, Action: drNotifHandler("datatraffic", "create"), HideHelpCommand: true}
 var drNotifDatatrafficGetCmd = cli.Command{Name: "notif-datatraffic-get", ArgsUsage: "<id> <nid>", Action: drNotifHandler("datatraffic", "get"), HideHelpCommand: true}
 var drNotifDatatrafficUpdateCmd = cli.Command{Name: "notif-datatraffic-update", ArgsUsage: "<id> <nid>", Flags: payloadFlag, Action: drNotifHandler("datatraffic", "update"), HideHelpCommand: true}
-var drNotifDatatrafficDeleteCmd = cli.Command{Name: "notif-datatraffic-delete", ArgsUsage: "<id> <nid>", Action: drNotifHandler("datatraffic", "delete"), HideHelpCommand: true}
+var drNotifDatatrafficDeleteCmd = cli.Command{Name: "notif-datatraffic-delete", ArgsUsage: "<id> <nid>", Action: confirm(destructive{"delete data traffic notification setting %[2]s of private rack %[1]s", "/bareMetals/v2/privateRacks/%s", serverDetails}, drNotifHandler("datatraffic", "delete")), HideHelpCommand: true}
 
 var drNotifDDoSGetCmd = cli.Command{Name: "notif-ddos-get", ArgsUsage: "<id>", Action: func(ctx context.Context, cmd *cli.Command) error {
 	args := cmd.Args().Slice()
@@ -404,7 +410,11 @@ var drNotifDDoSUpdateCmd = cli.Command{Name: "notif-ddos-update", ArgsUsage: "<i
 	if err != nil {
 		return err
 	}
-	res, err := client.PutJSON(ctx, drPath(args)+"/notificationSettings/ddos", []byte(cmd.String("payload")))
+	body, err := loadPayload(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := client.PutJSON(ctx, drPath(args)+"/notificationSettings/ddos", body)
 	if err != nil {
 		return err
 	}
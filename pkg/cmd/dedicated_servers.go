@@ -7,6 +7,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/kernel/leaseweb-cli/pkg/leaseweb"
 	"github.com/tidwall/gjson"
 	"github.com/urfave/cli/v3"
 )
@@ -87,72 +88,42 @@ var dsListCmd = cli.Command{
 }
 
 func handleDSList(ctx context.Context, cmd *cli.Command) error {
-	client, err := NewClient(cmd)
-	if err != nil {
-		return err
-	}
-
-	q := PaginationQuery(cmd)
-	if ref := cmd.String("reference"); ref != "" {
-		q += "&reference=" + ref
-	}
-
-	res, err := client.Get(ctx, "/bareMetals/v2/servers?"+q)
-	if err != nil {
-		return err
-	}
-
-	format := cmd.Root().String("output")
-	if format != "auto" {
-		return ShowResult(os.Stdout, res, format, cmd.Root().String("transform"))
-	}
-
-	servers := res.Get("servers")
-	if !servers.Exists() || !servers.IsArray() || len(servers.Array()) == 0 {
-		fmt.Fprintln(os.Stderr, "No dedicated servers found.")
-		return nil
-	}
-
-	table := NewTableWriter(os.Stdout, "ID", "REFERENCE", "SITE", "CHASSIS", "CPU", "RAM", "STORAGE", "PUBLIC IP")
-	table.TruncOrder = []int{3, 4, 7}
-	servers.ForEach(func(_, s gjson.Result) bool {
-		id := s.Get("id").String()
-		ref := s.Get("reference").String()
-		site := s.Get("location.site").String()
-		chassis := s.Get("specs.chassis").String()
-		cpu := s.Get("specs.cpu.type").String()
-		ram := fmt.Sprintf("%d %s", s.Get("specs.ram.size").Int(), s.Get("specs.ram.unit").String())
-		storage := formatDisks(s.Get("specs.hdd"))
-		pubIP := s.Get("networkInterfaces.public.ip").String()
-		table.AddRow(id, ref, site, chassis, cpu, ram, storage, pubIP)
-		return true
+	return runList(ctx, cmd, func(ctx context.Context, client *Client) ([]leaseweb.Server, gjson.Result, error) {
+		return ListPages(ctx, cmd, func(opts leaseweb.ListOptions) (*leaseweb.Page[leaseweb.Server], error) {
+			return client.API().BareMetal.ListServers(ctx, &leaseweb.ListServersOptions{
+				ListOptions: opts,
+				Reference:   cmd.String("reference"),
+			})
+		})
+	}, listView[leaseweb.Server]{
+		empty:      "No dedicated servers found.",
+		headers:    []string{"ID", "REFERENCE", "SITE", "CHASSIS", "CPU", "RAM", "STORAGE", "PUBLIC IP"},
+		truncOrder: []int{3, 4, 7},
+		columns: []string{"id", "reference", "location.site", "specs.chassis", "specs.cpu.type", "specs.ram.size",
+			"specs.hdd", "networkInterfaces.public.ip"},
+		row: func(s leaseweb.Server) []string {
+			ram := fmt.Sprintf("%d %s", s.Specs.RAM.Size, s.Specs.RAM.Unit)
+			pubIP := ""
+			if s.NetworkInterfaces.Public != nil {
+				pubIP = s.NetworkInterfaces.Public.IP
+			}
+			return []string{s.ID, s.Reference, s.Location.Site, s.Specs.Chassis, s.Specs.CPU.Type, ram, formatDisks(s.Specs.HDD), pubIP}
+		},
 	})
-	table.Render()
-	return nil
 }
 
-func formatDisks(hdd gjson.Result) string {
-	if !hdd.Exists() || !hdd.IsArray() {
-		return ""
-	}
+func formatDisks(hdd []leaseweb.ServerHDD) string {
 	var parts []string
-	hdd.ForEach(func(_, d gjson.Result) bool {
-		amount := d.Get("amount").Int()
-		size := d.Get("size").Float()
-		unit := d.Get("unit").String()
-		typ := d.Get("type").String()
-		perf := d.Get("performanceType").String()
-
-		sizeStr := fmt.Sprintf("%.2f", size)
+	for _, d := range hdd {
+		sizeStr := fmt.Sprintf("%.2f", d.Size)
 		sizeStr = strings.TrimRight(strings.TrimRight(sizeStr, "0"), ".")
 
-		entry := fmt.Sprintf("%dx%s%s %s", amount, sizeStr, unit, typ)
-		if perf != "" {
-			entry += " " + perf
+		entry := fmt.Sprintf("%dx%s%s %s", d.Amount, sizeStr, d.Unit, d.Type)
+		if d.PerformanceType != "" {
+			entry += " " + d.PerformanceType
 		}
 		parts = append(parts, entry)
-		return true
-	})
+	}
 	return strings.Join(parts, ", ")
 }
 
@@ -204,20 +175,23 @@ func handleDSUpdate(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	body, _ := json.Marshal(map[string]string{"reference": cmd.String("reference")})
-	res, err := client.PutJSON(ctx, "/bareMetals/v2/servers/"+args[0], body)
+	server, err := client.API().BareMetal.UpdateServerReference(ctx, args[0], cmd.String("reference"))
 	if err != nil {
 		return err
 	}
+	var res gjson.Result
+	if server != nil {
+		res = gjson.ParseBytes(server.Raw)
+	}
 	return ShowResult(os.Stdout, res, cmd.Root().String("output"), cmd.Root().String("transform"))
 }
 
 var dsIPsCmd = cli.Command{
-	Name:      "ips",
-	Usage:     "List IPs for a dedicated server",
-	ArgsUsage: "<server-id>",
-	Flags:     PaginationFlags,
-	Action:    handleDSIPs,
+	Name:            "ips",
+	Usage:           "List IPs for a dedicated server",
+	ArgsUsage:       "<server-id>",
+	Flags:           PaginationFlags,
+	Action:          handleDSIPs,
 	HideHelpCommand: true,
 }
 
@@ -230,14 +204,16 @@ func handleDSIPs(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	res, err := client.Get(ctx, fmt.Sprintf("/bareMetals/v2/servers/%s/ips?%s", args[0], PaginationQuery(cmd)))
+	res, err := GetList(ctx, client, cmd, fmt.Sprintf("/bareMetals/v2/servers/%s/ips?%s", args[0], PaginationQuery(cmd)))
 	if err != nil {
 		return err
 	}
 
 	format := cmd.Root().String("output")
 	if format != "auto" {
-		return ShowResult(os.Stdout, res, format, cmd.Root().String("transform"))
+		return ShowList(os.Stdout, res, format, cmd.Root().String("transform"), []string{
+			"ip", "version", "type", "reverseLookup", "nullRouted",
+		})
 	}
 
 	ips := res.Get("ips")
@@ -262,10 +238,10 @@ func handleDSIPs(ctx context.Context, cmd *cli.Command) error {
 }
 
 var dsIPGetCmd = cli.Command{
-	Name:      "ip-get",
-	Usage:     "Get IP details for a dedicated server",
-	ArgsUsage: "<server-id> <ip>",
-	Action:    handleDSIPGet,
+	Name:            "ip-get",
+	Usage:           "Get IP details for a dedicated server",
+	ArgsUsage:       "<server-id> <ip>",
+	Action:          handleDSIPGet,
 	HideHelpCommand: true,
 }
 
@@ -286,10 +262,10 @@ func handleDSIPGet(ctx context.Context, cmd *cli.Command) error {
 }
 
 var dsPowerOnCmd = cli.Command{
-	Name:      "power-on",
-	Usage:     "Power on a dedicated server",
-	ArgsUsage: "<server-id>",
-	Action:    handleDSPowerOn,
+	Name:            "power-on",
+	Usage:           "Power on a dedicated server",
+	ArgsUsage:       "<server-id>",
+	Action:          handleDSPowerOn,
 	HideHelpCommand: true,
 }
 
@@ -302,8 +278,7 @@ func handleDSPowerOn(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	_, err = client.Post(ctx, "/bareMetals/v2/servers/"+args[0]+"/powerOn", "")
-	if err != nil {
+	if err := client.API().BareMetal.PowerOn(ctx, args[0]); err != nil {
 		return err
 	}
 	fmt.Fprintf(os.Stderr, "Power on initiated for %s\n", args[0])
@@ -311,10 +286,10 @@ func handleDSPowerOn(ctx context.Context, cmd *cli.Command) error {
 }
 
 var dsPowerOffCmd = cli.Command{
-	Name:      "power-off",
-	Usage:     "Power off a dedicated server",
-	ArgsUsage: "<server-id>",
-	Action:    handleDSPowerOff,
+	Name:            "power-off",
+	Usage:           "Power off a dedicated server",
+	ArgsUsage:       "<server-id>",
+	Action:          confirm(destructive{"power off server %s", "/bareMetals/v2/servers/%s", serverDetails}, handleDSPowerOff),
 	HideHelpCommand: true,
 }
 
@@ -327,8 +302,7 @@ func handleDSPowerOff(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	_, err = client.Post(ctx, "/bareMetals/v2/servers/"+args[0]+"/powerOff", "")
-	if err != nil {
+	if err := client.API().BareMetal.PowerOff(ctx, args[0]); err != nil {
 		return err
 	}
 	fmt.Fprintf(os.Stderr, "Power off initiated for %s\n", args[0])
@@ -336,10 +310,10 @@ func handleDSPowerOff(ctx context.Context, cmd *cli.Command) error {
 }
 
 var dsPowerCycleCmd = cli.Command{
-	Name:      "power-cycle",
-	Usage:     "Power cycle a dedicated server",
-	ArgsUsage: "<server-id>",
-	Action:    handleDSPowerCycle,
+	Name:            "power-cycle",
+	Usage:           "Power cycle a dedicated server",
+	ArgsUsage:       "<server-id>",
+	Action:          handleDSPowerCycle,
 	HideHelpCommand: true,
 }
 
@@ -352,8 +326,7 @@ func handleDSPowerCycle(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	_, err = client.Post(ctx, "/bareMetals/v2/servers/"+args[0]+"/powerCycle", "")
-	if err != nil {
+	if err := client.API().BareMetal.PowerCycle(ctx, args[0]); err != nil {
 		return err
 	}
 	fmt.Fprintf(os.Stderr, "Power cycle initiated for %s\n", args[0])
@@ -361,10 +334,10 @@ func handleDSPowerCycle(ctx context.Context, cmd *cli.Command) error {
 }
 
 var dsPowerStatusCmd = cli.Command{
-	Name:      "power-status",
-	Usage:     "Show power status of a dedicated server",
-	ArgsUsage: "<server-id>",
-	Action:    handleDSPowerStatus,
+	Name:            "power-status",
+	Usage:           "Show power status of a dedicated server",
+	ArgsUsage:       "<server-id>",
+	Action:          handleDSPowerStatus,
 	HideHelpCommand: true,
 }
 
@@ -443,7 +416,7 @@ var dsInstallCmd = cli.Command{
 			Usage: "Boot device (SATA_SAS, NVME, or a disk set ID)",
 		},
 	},
-	Action:          handleDSInstall,
+	Action:          confirm(destructive{"install an operating system on server %s, erasing its disks", "/bareMetals/v2/servers/%s", serverDetails}, handleDSInstall),
 	HideHelpCommand: true,
 }
 
@@ -510,10 +483,10 @@ func handleDSCredentialsList(ctx context.Context, cmd *cli.Command) error {
 }
 
 var dsCredentialsGetCmd = cli.Command{
-	Name:      "credential-get",
-	Usage:     "Get specific credentials",
-	ArgsUsage: "<server-id> <type> <username>",
-	Action:    handleDSCredentialsGet,
+	Name:            "credential-get",
+	Usage:           "Get specific credentials",
+	ArgsUsage:       "<server-id> <type> <username>",
+	Action:          handleDSCredentialsGet,
 	HideHelpCommand: true,
 }
 
@@ -568,11 +541,11 @@ func handleDSCredentialsCreate(ctx context.Context, cmd *cli.Command) error {
 }
 
 var dsJobsListCmd = cli.Command{
-	Name:      "jobs",
-	Usage:     "List jobs for a dedicated server",
-	ArgsUsage: "<server-id>",
-	Flags:     PaginationFlags,
-	Action:    handleDSJobsList,
+	Name:            "jobs",
+	Usage:           "List jobs for a dedicated server",
+	ArgsUsage:       "<server-id>",
+	Flags:           PaginationFlags,
+	Action:          handleDSJobsList,
 	HideHelpCommand: true,
 }
 
@@ -585,14 +558,14 @@ func handleDSJobsList(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	res, err := client.Get(ctx, fmt.Sprintf("/bareMetals/v2/servers/%s/jobs?%s", args[0], PaginationQuery(cmd)))
+	res, err := GetList(ctx, client, cmd, fmt.Sprintf("/bareMetals/v2/servers/%s/jobs?%s", args[0], PaginationQuery(cmd)))
 	if err != nil {
 		return err
 	}
 
 	format := cmd.Root().String("output")
 	if format != "auto" {
-		return ShowResult(os.Stdout, res, format, cmd.Root().String("transform"))
+		return ShowList(os.Stdout, res, format, cmd.Root().String("transform"), []string{"uuid", "type", "status", "createdAt"})
 	}
 
 	jobs := res.Get("jobs")
@@ -616,10 +589,10 @@ func handleDSJobsList(ctx context.Context, cmd *cli.Command) error {
 }
 
 var dsJobGetCmd = cli.Command{
-	Name:      "job-get",
-	Usage:     "Get job details",
-	ArgsUsage: "<server-id> <job-id>",
-	Action:    handleDSJobGet,
+	Name:            "job-get",
+	Usage:           "Get job details",
+	ArgsUsage:       "<server-id> <job-id>",
+	Action:          handleDSJobGet,
 	HideHelpCommand: true,
 }
 
@@ -640,10 +613,10 @@ func handleDSJobGet(ctx context.Context, cmd *cli.Command) error {
 }
 
 var dsHardwareInfoCmd = cli.Command{
-	Name:      "hardware-info",
-	Usage:     "Show hardware information",
-	ArgsUsage: "<server-id>",
-	Action:    handleDSHardwareInfo,
+	Name:            "hardware-info",
+	Usage:           "Show hardware information",
+	ArgsUsage:       "<server-id>",
+	Action:          handleDSHardwareInfo,
 	HideHelpCommand: true,
 }
 
@@ -816,10 +789,10 @@ func handleDSIPUpdate(ctx context.Context, cmd *cli.Command) error {
 }
 
 var dsIPNullCmd = cli.Command{
-	Name:      "ip-null",
-	Usage:     "Null route an IP on a dedicated server",
-	ArgsUsage: "<server-id> <ip>",
-	Action:    handleDSIPNull,
+	Name:            "ip-null",
+	Usage:           "Null route an IP on a dedicated server",
+	ArgsUsage:       "<server-id> <ip>",
+	Action:          confirm(destructive{"null route IP %[2]s of server %[1]s", "/bareMetals/v2/servers/%s", serverDetails}, handleDSIPNull),
 	HideHelpCommand: true,
 }
 
@@ -841,10 +814,10 @@ func handleDSIPNull(ctx context.Context, cmd *cli.Command) error {
 }
 
 var dsIPUnnullCmd = cli.Command{
-	Name:      "ip-unnull",
-	Usage:     "Remove null route from an IP on a dedicated server",
-	ArgsUsage: "<server-id> <ip>",
-	Action:    handleDSIPUnnull,
+	Name:            "ip-unnull",
+	Usage:           "Remove null route from an IP on a dedicated server",
+	ArgsUsage:       "<server-id> <ip>",
+	Action:          handleDSIPUnnull,
 	HideHelpCommand: true,
 }
 
@@ -885,10 +858,10 @@ func handleDSRescueImages(ctx context.Context, cmd *cli.Command) error {
 }
 
 var dsIPMIResetCmd = cli.Command{
-	Name:      "ipmi-reset",
-	Usage:     "Launch IPMI reset for a dedicated server",
-	ArgsUsage: "<server-id>",
-	Action:    handleDSIPMIReset,
+	Name:            "ipmi-reset",
+	Usage:           "Launch IPMI reset for a dedicated server",
+	ArgsUsage:       "<server-id>",
+	Action:          handleDSIPMIReset,
 	HideHelpCommand: true,
 }
 
@@ -941,7 +914,7 @@ var dsCredentialsDeleteCmd = cli.Command{
 	Name:            "credential-delete",
 	Usage:           "Delete server credentials",
 	ArgsUsage:       "<server-id> <type> <username>",
-	Action:          handleDSCredentialsDelete,
+	Action:          confirm(destructive{"delete the %[2]s credential %[3]s of server %[1]s", "/bareMetals/v2/servers/%s", serverDetails}, handleDSCredentialsDelete),
 	HideHelpCommand: true,
 }
 
@@ -963,10 +936,10 @@ func handleDSCredentialsDelete(ctx context.Context, cmd *cli.Command) error {
 }
 
 var dsJobCancelCmd = cli.Command{
-	Name:      "job-cancel",
-	Usage:     "Cancel active job for a dedicated server",
-	ArgsUsage: "<server-id>",
-	Action:    handleDSJobCancel,
+	Name:            "job-cancel",
+	Usage:           "Cancel active job for a dedicated server",
+	ArgsUsage:       "<server-id>",
+	Action:          handleDSJobCancel,
 	HideHelpCommand: true,
 }
 
@@ -988,10 +961,10 @@ func handleDSJobCancel(ctx context.Context, cmd *cli.Command) error {
 }
 
 var dsJobExpireCmd = cli.Command{
-	Name:      "job-expire",
-	Usage:     "Expire active job for a dedicated server",
-	ArgsUsage: "<server-id>",
-	Action:    handleDSJobExpire,
+	Name:            "job-expire",
+	Usage:           "Expire active job for a dedicated server",
+	ArgsUsage:       "<server-id>",
+	Action:          handleDSJobExpire,
 	HideHelpCommand: true,
 }
 
@@ -1013,10 +986,10 @@ func handleDSJobExpire(ctx context.Context, cmd *cli.Command) error {
 }
 
 var dsJobRetryCmd = cli.Command{
-	Name:      "job-retry",
-	Usage:     "Retry a job",
-	ArgsUsage: "<server-id> <job-id>",
-	Action:    handleDSJobRetry,
+	Name:            "job-retry",
+	Usage:           "Retry a job",
+	ArgsUsage:       "<server-id> <job-id>",
+	Action:          handleDSJobRetry,
 	HideHelpCommand: true,
 }
 
@@ -1081,10 +1054,10 @@ func handleDSHardwareMonitoringAll(ctx context.Context, cmd *cli.Command) error
 }
 
 var dsHardwareScanCmd = cli.Command{
-	Name:      "hardware-scan",
-	Usage:     "Launch hardware scan for a dedicated server",
-	ArgsUsage: "<server-id>",
-	Action:    handleDSHardwareScan,
+	Name:            "hardware-scan",
+	Usage:           "Launch hardware scan for a dedicated server",
+	ArgsUsage:       "<server-id>",
+	Action:          handleDSHardwareScan,
 	HideHelpCommand: true,
 }
 
@@ -1130,12 +1103,10 @@ func handleDSLeasesList(ctx context.Context, cmd *cli.Command) error {
 }
 
 var dsLeasesCreateCmd = cli.Command{
-	Name:      "lease-create",
-	Usage:     "Create a DHCP reservation for a dedicated server",
-	ArgsUsage: "<server-id>",
-	Flags: []cli.Flag{
-		&cli.StringFlag{Name: "payload", Usage: "JSON payload for the reservation", Required: true},
-	},
+	Name:            "lease-create",
+	Usage:           "Create a DHCP reservation for a dedicated server",
+	ArgsUsage:       "<server-id>",
+	Flags:           payloadFlags("JSON payload for the reservation"),
 	Action:          handleDSLeasesCreate,
 	HideHelpCommand: true,
 }
@@ -1149,7 +1120,11 @@ func handleDSLeasesCreate(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	res, err := client.PostJSON(ctx, "/bareMetals/v2/servers/"+args[0]+"/leases", []byte(cmd.String("payload")))
+	body, err := loadPayload(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := client.PostJSON(ctx, "/bareMetals/v2/servers/"+args[0]+"/leases", body)
 	if err != nil {
 		return err
 	}
@@ -1161,7 +1136,7 @@ var dsLeasesDeleteCmd = cli.Command{
 	Name:            "lease-delete",
 	Usage:           "Delete a DHCP reservation for a dedicated server",
 	ArgsUsage:       "<server-id>",
-	Action:          handleDSLeasesDelete,
+	Action:          confirm(destructive{"delete the DHCP reservation of server %s", "/bareMetals/v2/servers/%s", serverDetails}, handleDSLeasesDelete),
 	HideHelpCommand: true,
 }
 
@@ -1255,12 +1230,10 @@ func handleDSNotifBandwidthGet(ctx context.Context, cmd *cli.Command) error {
 }
 
 var dsNotifBandwidthCreateCmd = cli.Command{
-	Name:      "notif-bandwidth-create",
-	Usage:     "Create a bandwidth notification setting",
-	ArgsUsage: "<server-id>",
-	Flags: []cli.Flag{
-		&cli.StringFlag{Name: "payload", Usage: "JSON payload", Required: true},
-	},
+	Name:            "notif-bandwidth-create",
+	Usage:           "Create a bandwidth notification setting",
+	ArgsUsage:       "<server-id>",
+	Flags:           payloadFlags("JSON payload"),
 	Action:          handleDSNotifBandwidthCreate,
 	HideHelpCommand: true,
 }
@@ -1274,7 +1247,11 @@ func handleDSNotifBandwidthCreate(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	res, err := client.PostJSON(ctx, fmt.Sprintf("/bareMetals/v2/servers/%s/notificationSettings/bandwidth", args[0]), []byte(cmd.String("payload")))
+	body, err := loadPayload(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := client.PostJSON(ctx, fmt.Sprintf("/bareMetals/v2/servers/%s/notificationSettings/bandwidth", args[0]), body)
 	if err != nil {
 		return err
 	}
@@ -1282,12 +1259,10 @@ func handleDSNotifBandwidthCreate(ctx context.Context, cmd *cli.Command) error {
 }
 
 var dsNotifBandwidthUpdateCmd = cli.Command{
-	Name:      "notif-bandwidth-update",
-	Usage:     "Update a bandwidth notification setting",
-	ArgsUsage: "<server-id> <notification-id>",
-	Flags: []cli.Flag{
-		&cli.StringFlag{Name: "payload", Usage: "JSON payload", Required: true},
-	},
+	Name:            "notif-bandwidth-update",
+	Usage:           "Update a bandwidth notification setting",
+	ArgsUsage:       "<server-id> <notification-id>",
+	Flags:           payloadFlags("JSON payload"),
 	Action:          handleDSNotifBandwidthUpdate,
 	HideHelpCommand: true,
 }
@@ -1301,7 +1276,11 @@ func handleDSNotifBandwidthUpdate(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	res, err := client.PutJSON(ctx, fmt.Sprintf("/bareMetals/v2/servers/%s/notificationSettings/bandwidth/%s", args[0], args[1]), []byte(cmd.String("payload")))
+	body, err := loadPayload(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := client.PutJSON(ctx, fmt.Sprintf("/bareMetals/v2/servers/%s/notificationSettings/bandwidth/%s", args[0], args[1]), body)
 	if err != nil {
 		return err
 	}
@@ -1312,7 +1291,7 @@ var dsNotifBandwidthDeleteCmd = cli.Command{
 	Name:            "notif-bandwidth-delete",
 	Usage:           "Delete a bandwidth notification setting",
 	ArgsUsage:       "<server-id> <notification-id>",
-	Action:          handleDSNotifBandwidthDelete,
+	Action:          confirm(destructive{"delete bandwidth notification setting %[2]s of server %[1]s", "/bareMetals/v2/servers/%s", serverDetails}, handleDSNotifBandwidthDelete),
 	HideHelpCommand: true,
 }
 
@@ -1382,12 +1361,10 @@ func handleDSNotifDatatrafficGet(ctx context.Context, cmd *cli.Command) error {
 }
 
 var dsNotifDatatrafficCreateCmd = cli.Command{
-	Name:      "notif-datatraffic-create",
-	Usage:     "Create a data traffic notification setting",
-	ArgsUsage: "<server-id>",
-	Flags: []cli.Flag{
-		&cli.StringFlag{Name: "payload", Usage: "JSON payload", Required: true},
-	},
+	Name:            "notif-datatraffic-create",
+	Usage:           "Create a data traffic notification setting",
+	ArgsUsage:       "<server-id>",
+	Flags:           payloadFlags("JSON payload"),
 	Action:          handleDSNotifDatatrafficCreate,
 	HideHelpCommand: true,
 }
@@ -1401,7 +1378,11 @@ func handleDSNotifDatatrafficCreate(ctx context.Context, cmd *cli.Command) error
 	if err != nil {
 		return err
 	}
-	res, err := client.PostJSON(ctx, fmt.Sprintf("/bareMetals/v2/servers/%s/notificationSettings/datatraffic", args[0]), []byte(cmd.String("payload")))
+	body, err := loadPayload(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := client.PostJSON(ctx, fmt.Sprintf("/bareMetals/v2/servers/%s/notificationSettings/datatraffic", args[0]), body)
 	if err != nil {
 		return err
 	}
@@ -1409,12 +1390,10 @@ func handleDSNotifDatatrafficCreate(ctx context.Context, cmd *cli.Command) error
 }
 
 var dsNotifDatatrafficUpdateCmd = cli.Command{
-	Name:      "notif-datatraffic-update",
-	Usage:     "Update a data traffic notification setting",
-	ArgsUsage: "<server-id> <notification-id>",
-	Flags: []cli.Flag{
-		&cli.StringFlag{Name: "payload", Usage: "JSON payload", Required: true},
-	},
+	Name:            "notif-datatraffic-update",
+	Usage:           "Update a data traffic notification setting",
+	ArgsUsage:       "<server-id> <notification-id>",
+	Flags:           payloadFlags("JSON payload"),
 	Action:          handleDSNotifDatatrafficUpdate,
 	HideHelpCommand: true,
 }
@@ -1428,7 +1407,11 @@ func handleDSNotifDatatrafficUpdate(ctx context.Context, cmd *cli.Command) error
 	if err != nil {
 		return err
 	}
-	res, err := client.PutJSON(ctx, fmt.Sprintf("/bareMetals/v2/servers/%s/notificationSettings/datatraffic/%s", args[0], args[1]), []byte(cmd.String("payload")))
+	body, err := loadPayload(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := client.PutJSON(ctx, fmt.Sprintf("/bareMetals/v2/servers/%s/notificationSettings/datatraffic/%s", args[0], args[1]), body)
 	if err != nil {
 		return err
 	}
@@ -1439,7 +1422,7 @@ var dsNotifDatatrafficDeleteCmd = cli.Command{
 	Name:            "notif-datatraffic-delete",
 	Usage:           "Delete a data traffic notification setting",
 	ArgsUsage:       "<server-id> <notification-id>",
-	Action:          handleDSNotifDatatrafficDelete,
+	Action:          confirm(destructive{"delete data traffic notification setting %[2]s of server %[1]s", "/bareMetals/v2/servers/%s", serverDetails}, handleDSNotifDatatrafficDelete),
 	HideHelpCommand: true,
 }
 
@@ -1485,12 +1468,10 @@ func handleDSNotifDDoSGet(ctx context.Context, cmd *cli.Command) error {
 }
 
 var dsNotifDDoSUpdateCmd = cli.Command{
-	Name:      "notif-ddos-update",
-	Usage:     "Update DDoS notification settings",
-	ArgsUsage: "<server-id>",
-	Flags: []cli.Flag{
-		&cli.StringFlag{Name: "payload", Usage: "JSON payload", Required: true},
-	},
+	Name:            "notif-ddos-update",
+	Usage:           "Update DDoS notification settings",
+	ArgsUsage:       "<server-id>",
+	Flags:           payloadFlags("JSON payload"),
 	Action:          handleDSNotifDDoSUpdate,
 	HideHelpCommand: true,
 }
@@ -1504,7 +1485,11 @@ func handleDSNotifDDoSUpdate(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	res, err := client.PutJSON(ctx, fmt.Sprintf("/bareMetals/v2/servers/%s/notificationSettings/ddos", args[0]), []byte(cmd.String("payload")))
+	body, err := loadPayload(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := client.PutJSON(ctx, fmt.Sprintf("/bareMetals/v2/servers/%s/notificationSettings/ddos", args[0]), body)
 	if err != nil {
 		return err
 	}
@@ -1524,7 +1509,7 @@ func handleDSOSList(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	res, err := client.Get(ctx, "/bareMetals/v2/operatingSystems?"+PaginationQuery(cmd))
+	res, err := GetList(ctx, client, cmd, "/bareMetals/v2/operatingSystems?"+PaginationQuery(cmd))
 	if err != nil {
 		return err
 	}
@@ -1630,7 +1615,7 @@ var dsPrivateNetworkRemoveCmd = cli.Command{
 	Name:            "private-network-remove",
 	Usage:           "Remove a server from a private network",
 	ArgsUsage:       "<server-id> <private-network-id>",
-	Action:          handleDSPrivateNetworkRemove,
+	Action:          confirm(destructive{"remove server %[1]s from private network %[2]s", "/bareMetals/v2/servers/%s", serverDetails}, handleDSPrivateNetworkRemove),
 	HideHelpCommand: true,
 }
 
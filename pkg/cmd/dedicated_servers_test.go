@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDSUpdate(t *testing.T) {
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"PUT /bareMetals/v2/servers/12345": func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			jsonResponse(w, 200, map[string]any{"id": "12345", "reference": body["reference"]})
+		},
+	})
+	defer srv.Close()
+
+	stdout, _, err := runCLI(t, srv.URL, []string{"-o", "raw", "--transform", "reference", "ds", "update", "12345", "--reference", "web-01"})
+	require.NoError(t, err)
+	assert.Equal(t, `"web-01"`, strings.TrimSpace(stdout))
+}
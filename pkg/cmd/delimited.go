@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/pretty"
+)
+
+// arraySeparator joins the elements of an array of scalars in one cell.
+const arraySeparator = ";"
+
+// writeDelimited writes the items of res as CSV (RFC 4180) or, for tsv,
+// tab-separated values, one row per item under a header of the column
+// paths. A list response contributes the items of its collection array; any
+// other object is a single item. columns are dotted JSON paths; when there
+// are none, every leaf of the items becomes a column.
+func writeDelimited(w io.Writer, res gjson.Result, format string, columns []string) error {
+	items := delimitedItems(res)
+	if len(columns) == 0 {
+		columns = leafPaths(items)
+	}
+
+	rows := make([][]string, 0, len(items)+1)
+	rows = append(rows, columns)
+	for _, item := range items {
+		row := make([]string, len(columns))
+		for i, c := range columns {
+			row[i] = cellValue(item.Get(c))
+		}
+		rows = append(rows, row)
+	}
+
+	if format == "tsv" {
+		for _, row := range rows {
+			for i, cell := range row {
+				row[i] = tsvEscaper.Replace(cell)
+			}
+			if _, err := io.WriteString(w, strings.Join(row, "\t")+"\n"); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	cw := csv.NewWriter(w)
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+// tsvEscaper escapes the characters that would break a tab-separated row.
+var tsvEscaper = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+
+// delimitedItems returns the rows of res: the elements of an array, the
+// collection of a list response such as {"servers": [...], "_metadata":
+// {...}}, or res itself.
+func delimitedItems(res gjson.Result) []gjson.Result {
+	switch {
+	case res.IsArray():
+		return res.Array()
+	case !res.IsObject():
+		if !res.Exists() {
+			return nil
+		}
+		return []gjson.Result{res}
+	}
+	var collection gjson.Result
+	others := 0
+	res.ForEach(func(key, val gjson.Result) bool {
+		switch k := key.String(); {
+		case k == "_metadata" || k == "_links":
+		case val.IsArray() && !collection.Exists():
+			collection = val
+		default:
+			others++
+		}
+		return true
+	})
+	if collection.Exists() && others == 0 {
+		return collection.Array()
+	}
+	return []gjson.Result{res}
+}
+
+// leafPaths returns the paths of the values in items that are not objects,
+// in the order they first appear.
+func leafPaths(items []gjson.Result) []string {
+	var paths []string
+	seen := map[string]bool{}
+	var walk func(prefix string, v gjson.Result)
+	walk = func(prefix string, v gjson.Result) {
+		if !v.IsObject() || len(v.Map()) == 0 {
+			if prefix != "" && !seen[prefix] {
+				seen[prefix] = true
+				paths = append(paths, prefix)
+			}
+			return
+		}
+		v.ForEach(func(key, val gjson.Result) bool {
+			p := pathEscaper.Replace(key.String())
+			if prefix != "" {
+				p = prefix + "." + p
+			}
+			walk(p, val)
+			return true
+		})
+	}
+	for _, item := range items {
+		walk("", item)
+	}
+	return paths
+}
+
+// pathEscaper escapes the characters with a meaning in GJSON paths.
+var pathEscaper = strings.NewReplacer(`\`, `\\`, ".", `\.`, "*", `\*`, "?", `\?`, "|", `\|`, "#", `\#`, "@", `\@`)
+
+// cellValue renders v for a cell. Arrays of scalars are joined with
+// arraySeparator; other arrays and objects are written as compact JSON.
+func cellValue(v gjson.Result) string {
+	switch {
+	case !v.Exists() || v.Type == gjson.Null:
+		return ""
+	case v.Type == gjson.String:
+		return v.Str
+	case v.IsArray():
+		elems := v.Array()
+		cells := make([]string, len(elems))
+		for i, e := range elems {
+			if e.IsObject() || e.IsArray() {
+				return string(pretty.Ugly([]byte(v.Raw)))
+			}
+			cells[i] = cellValue(e)
+		}
+		return strings.Join(cells, arraySeparator)
+	case v.IsObject():
+		return string(pretty.Ugly([]byte(v.Raw)))
+	}
+	return v.Raw
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDelimitedOutput(t *testing.T) {
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"GET /bareMetals/v2/servers": func(w http.ResponseWriter, r *http.Request) {
+			jsonResponse(w, 200, map[string]any{
+				"servers": []map[string]any{{
+					"id":        "12345",
+					"reference": `web "front", 1`,
+					"location":  map[string]any{"site": "AMS-01"},
+					"specs": map[string]any{
+						"chassis": "Dell R640",
+						"cpu":     map[string]any{"type": "Xeon"},
+						"ram":     map[string]any{"size": 64, "unit": "GB"},
+						"hdd":     []map[string]any{{"amount": 2, "size": 480, "unit": "GB", "type": "SSD"}},
+					},
+					"networkInterfaces": map[string]any{"public": map[string]any{"ip": "192.0.2.10/24"}},
+					"privateNetworks":   []map[string]any{},
+				}},
+				"_metadata": map[string]any{"totalCount": 1, "limit": 20, "offset": 0},
+			})
+		},
+		"GET /hosting/v2/domains": func(w http.ResponseWriter, r *http.Request) {
+			jsonResponse(w, 200, map[string]any{
+				"domains":   []map[string]any{{"domainName": "example.com", "status": "ACTIVE", "nameServers": []string{"ns1.example.net", "ns2.example.net"}}},
+				"_metadata": map[string]any{"totalCount": 1},
+			})
+		},
+	})
+	defer srv.Close()
+	t.Setenv("HOME", t.TempDir())
+
+	// The columns of the table, with RFC 4180 quoting.
+	stdout, _, err := runCLI(t, srv.URL, []string{"-o", "csv", "ds", "list"})
+	require.NoError(t, err)
+	assert.Equal(t, "id,reference,location.site,specs.chassis,specs.cpu.type,specs.ram.size,specs.hdd,networkInterfaces.public.ip\n"+
+		`12345,"web ""front"", 1",AMS-01,Dell R640,Xeon,64,"[{""amount"":2,""size"":480,""type"":""SSD"",""unit"":""GB""}]",192.0.2.10/24`+"\n", stdout)
+
+	stdout, _, err = runCLI(t, srv.URL, []string{"-o", "tsv", "--columns", "id,specs.ram.unit,contract.id", "ds", "list"})
+	require.NoError(t, err)
+	assert.Equal(t, "id\tspecs.ram.unit\tcontract.id\n12345\tGB\t\n", stdout)
+
+	// Arrays of scalars are joined with semicolons.
+	stdout, _, err = runCLI(t, srv.URL, []string{"-o", "csv", "domains", "list"})
+	require.NoError(t, err)
+	assert.Equal(t, "domainName,status,nameServers\nexample.com,ACTIVE,ns1.example.net;ns2.example.net\n", stdout)
+
+	// A transform picks the rows; without --columns every field is a column.
+	stdout, _, err = runCLI(t, srv.URL, []string{"-o", "csv", "--transform", "servers.#.location", "ds", "list"})
+	require.NoError(t, err)
+	assert.Equal(t, "site\nAMS-01\n", stdout)
+}
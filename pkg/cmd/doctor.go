@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v3"
+)
+
+// doctorTimeout bounds each request `lw config doctor` makes, so a host
+// that does not answer fails its check instead of hanging the command.
+const doctorTimeout = 10 * time.Second
+
+// maxClockSkew is the largest difference from the API's clock that passes.
+const maxClockSkew = time.Minute
+
+var configDoctorCmd = cli.Command{
+	Name:            "doctor",
+	Usage:           "Check the config file, the connection to the API and the API key of every profile",
+	Action:          handleConfigDoctor,
+	HideHelpCommand: true,
+}
+
+// doctorCheck is one line of the `lw config doctor` report.
+type doctorCheck struct {
+	Check  string `json:"check"`
+	Status string `json:"status"`
+	Detail string `json:"detail"`
+}
+
+const (
+	checkOK   = "ok"
+	checkWarn = "warn"
+	checkFail = "fail"
+)
+
+func handleConfigDoctor(ctx context.Context, cmd *cli.Command) error {
+	checks := []doctorCheck{checkConfigFile()}
+	checks = append(checks, checkAPIServer(ctx, resolveBaseURL(cmd))...)
+	checks = append(checks, checkProfileKeys(ctx, cmd, loadConfig())...)
+
+	var failed []string
+	for _, c := range checks {
+		if c.Status == checkFail {
+			failed = append(failed, c.Check)
+		}
+	}
+
+	format := cmd.Root().String("output")
+	if strings.ToLower(format) == "auto" {
+		table := NewTableWriter(os.Stdout, "CHECK", "STATUS", "DETAIL")
+		for _, c := range checks {
+			table.AddRow(c.Check, c.Status, c.Detail)
+		}
+		table.Render()
+	} else {
+		b, err := json.Marshal(checks)
+		if err != nil {
+			return err
+		}
+		if err := ShowJSON(os.Stdout, string(b), format, cmd.Root().String("transform")); err != nil {
+			return err
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("%d of %d checks failed: %s", len(failed), len(checks), strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// checkConfigFile checks that the config file parses and that only its
+// owner can read it, since it may hold API keys.
+func checkConfigFile() doctorCheck {
+	c := doctorCheck{Check: "config file"}
+	path := getConfigPath()
+	info, err := os.Stat(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		c.Status, c.Detail = checkWarn, fmt.Sprintf("%s does not exist; run 'lw config init'", path)
+		return c
+	case err != nil:
+		c.Status, c.Detail = checkFail, err.Error()
+		return c
+	}
+	if _, err := readConfigDoc(); err != nil {
+		c.Status, c.Detail = checkFail, err.Error()
+		return c
+	}
+	if perm := info.Mode().Perm(); perm&0077 != 0 {
+		c.Status, c.Detail = checkFail, fmt.Sprintf("%s has mode %04o and may be read by others; run 'chmod 600 %s'", path, perm, path)
+		return c
+	}
+	c.Status, c.Detail = checkOK, path
+	return c
+}
+
+// checkAPIServer checks that baseURL answers and that the local clock
+// agrees with the Date header of its answer.
+func checkAPIServer(ctx context.Context, baseURL string) []doctorCheck {
+	reach := doctorCheck{Check: "base URL"}
+	skew := doctorCheck{Check: "clock skew"}
+
+	ctx, cancel := context.WithTimeout(ctx, doctorTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL, nil)
+	if err != nil {
+		reach.Status, reach.Detail = checkFail, err.Error()
+		skew.Status, skew.Detail = checkWarn, "not checked"
+		return []doctorCheck{reach, skew}
+	}
+	req.Header.Set("User-Agent", userAgent())
+	sent := time.Now()
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		reach.Status, reach.Detail = checkFail, err.Error()
+		skew.Status, skew.Detail = checkWarn, "not checked"
+		return []doctorCheck{reach, skew}
+	}
+	resp.Body.Close()
+	elapsed := time.Since(sent)
+	reach.Status = checkOK
+	reach.Detail = fmt.Sprintf("%s answered %s in %s", baseURL, resp.Status, elapsed.Round(time.Millisecond))
+
+	serverTime, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		skew.Status, skew.Detail = checkWarn, "the API sent no Date header"
+		return []doctorCheck{reach, skew}
+	}
+	// The server stamped its answer somewhere within the round trip.
+	diff := sent.Add(elapsed / 2).Sub(serverTime).Round(time.Second)
+	if diff.Abs() > maxClockSkew {
+		skew.Status = checkFail
+		skew.Detail = fmt.Sprintf("local clock is %s off the API's; API key expiry and signed requests may misbehave", diff.Abs())
+	} else {
+		skew.Status, skew.Detail = checkOK, diff.Abs().String()
+	}
+	return []doctorCheck{reach, skew}
+}
+
+// checkProfileKeys validates the API key of every profile against the base
+// URL that profile uses.
+func checkProfileKeys(ctx context.Context, cmd *cli.Command, cfg *CLIConfig) []doctorCheck {
+	names := profileNames(cfg)
+	sort.Strings(names)
+	checks := make([]doctorCheck, 0, len(names))
+	for _, name := range names {
+		p := cfg.Profiles[name]
+		c := doctorCheck{Check: "profile " + name}
+		key, err := profileAPIKey(name, p)
+		switch {
+		case err != nil:
+			c.Status, c.Detail = checkFail, err.Error()
+		case key == "":
+			c.Status, c.Detail = checkFail, "no API key source; run 'lw config init --profile "+name+"'"
+		default:
+			if err := validateProfileKey(ctx, cmd, name, key); err != nil {
+				c.Status, c.Detail = checkFail, "key failed validation: "+err.Error()
+			} else {
+				source, _ := describeKeySource(p)
+				c.Status, c.Detail = checkOK, "key from "+source+" is valid"
+			}
+		}
+		checks = append(checks, c)
+	}
+	return checks
+}
+
+// validateProfileKey validates key, the API key of profile name, with a
+// client built from that profile's settings and the global flags.
+func validateProfileKey(ctx context.Context, cmd *cli.Command, name, key string) error {
+	client, err := newProfileClient(cmd, name)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, doctorTimeout)
+	defer cancel()
+	_, err = validateAPIKey(ctx, client, key)
+	return err
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 
+	"github.com/kernel/leaseweb-cli/pkg/leaseweb"
 	"github.com/tidwall/gjson"
 	"github.com/urfave/cli/v3"
 )
@@ -54,41 +56,18 @@ var domainsListCmd = cli.Command{
 }
 
 func handleDomainsList(ctx context.Context, cmd *cli.Command) error {
-	client, err := NewClient(cmd)
-	if err != nil {
-		return err
-	}
-	res, err := client.Get(ctx, "/hosting/v2/domains?"+PaginationQuery(cmd))
-	if err != nil {
-		return err
-	}
-
-	format := cmd.Root().String("output")
-	if format != "auto" {
-		return ShowResult(os.Stdout, res, format, cmd.Root().String("transform"))
-	}
-
-	domains := res.Get("domains")
-	if !domains.Exists() || len(domains.Array()) == 0 {
-		fmt.Fprintln(os.Stderr, "No domains found.")
-		return nil
-	}
-
-	table := NewTableWriter(os.Stdout, "DOMAIN", "STATUS", "NAMESERVERS")
-	domains.ForEach(func(_, d gjson.Result) bool {
-		ns := ""
-		d.Get("nameServers").ForEach(func(_, n gjson.Result) bool {
-			if ns != "" {
-				ns += ", "
-			}
-			ns += n.String()
-			return true
+	return runList(ctx, cmd, func(ctx context.Context, client *Client) ([]leaseweb.Domain, gjson.Result, error) {
+		return ListPages(ctx, cmd, func(opts leaseweb.ListOptions) (*leaseweb.Page[leaseweb.Domain], error) {
+			return client.API().Hosting.ListDomains(ctx, &opts)
 		})
-		table.AddRow(d.Get("domainName").String(), d.Get("status").String(), ns)
-		return true
+	}, listView[leaseweb.Domain]{
+		empty:   "No domains found.",
+		headers: []string{"DOMAIN", "STATUS", "NAMESERVERS"},
+		columns: []string{"domainName", "status", "nameServers"},
+		row: func(d leaseweb.Domain) []string {
+			return []string{d.DomainName, d.Status, strings.Join(d.NameServers, ", ")}
+		},
 	})
-	table.Render()
-	return nil
 }
 
 var domainsGetCmd = cli.Command{
@@ -132,41 +111,26 @@ func handleDomainsDNS(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	res, err := client.Get(ctx, "/hosting/v2/domains/"+args[0]+"/resourceRecordSets")
+	page, err := client.API().Hosting.ListResourceRecordSets(ctx, args[0])
 	if err != nil {
 		return err
 	}
 
 	format := cmd.Root().String("output")
 	if format != "auto" {
-		return ShowResult(os.Stdout, res, format, cmd.Root().String("transform"))
+		return ShowList(os.Stdout, gjson.ParseBytes(page.Raw), format, cmd.Root().String("transform"), []string{"name", "type", "ttl", "content"})
 	}
 
-	records := res.Get("resourceRecordSets")
-	if !records.Exists() || len(records.Array()) == 0 {
+	if len(page.Items) == 0 {
 		fmt.Fprintln(os.Stderr, "No DNS records found.")
 		return nil
 	}
 
 	table := NewTableWriter(os.Stdout, "NAME", "TYPE", "TTL", "CONTENT")
 	table.TruncOrder = []int{3, 0}
-	records.ForEach(func(_, r gjson.Result) bool {
-		content := ""
-		r.Get("content").ForEach(func(_, c gjson.Result) bool {
-			if content != "" {
-				content += ", "
-			}
-			content += c.String()
-			return true
-		})
-		table.AddRow(
-			r.Get("name").String(),
-			r.Get("type").String(),
-			fmt.Sprintf("%d", r.Get("ttl").Int()),
-			content,
-		)
-		return true
-	})
+	for _, r := range page.Items {
+		table.AddRow(r.Name, r.Type, fmt.Sprintf("%d", r.TTL), strings.Join(r.Content, ", "))
+	}
 	table.Render()
 	return nil
 }
@@ -237,7 +201,7 @@ var domainsDNSDeleteCmd = cli.Command{
 	Name:            "dns-delete",
 	Usage:           "Delete a DNS record set",
 	ArgsUsage:       "<domain> <name> <type>",
-	Action:          handleDomainsDNSDelete,
+	Action:          confirm(destructive{"delete the %[3]s records of %[2]s in %[1]s", "/hosting/v2/domains/%s", domainDetails}, handleDomainsDNSDelete),
 	HideHelpCommand: true,
 }
 
@@ -307,12 +271,10 @@ func handleDomainsContacts(ctx context.Context, cmd *cli.Command) error {
 }
 
 var domainsContactsUpdateCmd = cli.Command{
-	Name:      "contacts-update",
-	Usage:     "Update all contacts for a domain",
-	ArgsUsage: "<domain>",
-	Flags: []cli.Flag{
-		&cli.StringFlag{Name: "payload", Usage: "JSON payload", Required: true},
-	},
+	Name:            "contacts-update",
+	Usage:           "Update all contacts for a domain",
+	ArgsUsage:       "<domain>",
+	Flags:           payloadFlags("JSON payload"),
 	Action:          handleDomainsContactsUpdate,
 	HideHelpCommand: true,
 }
@@ -326,7 +288,11 @@ func handleDomainsContactsUpdate(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	res, err := client.PutJSON(ctx, "/hosting/v2/domains/"+args[0]+"/contacts", []byte(cmd.String("payload")))
+	body, err := loadPayload(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := client.PutJSON(ctx, "/hosting/v2/domains/"+args[0]+"/contacts", body)
 	if err != nil {
 		return err
 	}
@@ -334,12 +300,10 @@ func handleDomainsContactsUpdate(ctx context.Context, cmd *cli.Command) error {
 }
 
 var domainsContactUpdateCmd = cli.Command{
-	Name:      "contact-update",
-	Usage:     "Update a specific contact type for a domain",
-	ArgsUsage: "<domain> <type>",
-	Flags: []cli.Flag{
-		&cli.StringFlag{Name: "payload", Usage: "JSON payload", Required: true},
-	},
+	Name:            "contact-update",
+	Usage:           "Update a specific contact type for a domain",
+	ArgsUsage:       "<domain> <type>",
+	Flags:           payloadFlags("JSON payload"),
 	Action:          handleDomainsContactUpdate,
 	HideHelpCommand: true,
 }
@@ -353,7 +317,11 @@ func handleDomainsContactUpdate(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	res, err := client.PutJSON(ctx, fmt.Sprintf("/hosting/v2/domains/%s/contacts/%s", args[0], args[1]), []byte(cmd.String("payload")))
+	body, err := loadPayload(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := client.PutJSON(ctx, fmt.Sprintf("/hosting/v2/domains/%s/contacts/%s", args[0], args[1]), body)
 	if err != nil {
 		return err
 	}
@@ -361,12 +329,10 @@ func handleDomainsContactUpdate(ctx context.Context, cmd *cli.Command) error {
 }
 
 var domainsContactVerifyCmd = cli.Command{
-	Name:      "contact-verify",
-	Usage:     "Verify a contact for a domain",
-	ArgsUsage: "<domain> <type>",
-	Flags: []cli.Flag{
-		&cli.StringFlag{Name: "payload", Usage: "JSON payload", Required: true},
-	},
+	Name:            "contact-verify",
+	Usage:           "Verify a contact for a domain",
+	ArgsUsage:       "<domain> <type>",
+	Flags:           payloadFlags("JSON payload"),
 	Action:          handleDomainsContactVerify,
 	HideHelpCommand: true,
 }
@@ -380,7 +346,11 @@ func handleDomainsContactVerify(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	res, err := client.PutJSON(ctx, fmt.Sprintf("/hosting/v2/domains/%s/contacts/%s/verify", args[0], args[1]), []byte(cmd.String("payload")))
+	body, err := loadPayload(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := client.PutJSON(ctx, fmt.Sprintf("/hosting/v2/domains/%s/contacts/%s/verify", args[0], args[1]), body)
 	if err != nil {
 		return err
 	}
@@ -412,12 +382,10 @@ func handleDomainsDNSSECGet(ctx context.Context, cmd *cli.Command) error {
 }
 
 var domainsDNSSECUpdateCmd = cli.Command{
-	Name:      "dnssec-update",
-	Usage:     "Update DNSSEC settings for a domain",
-	ArgsUsage: "<domain>",
-	Flags: []cli.Flag{
-		&cli.StringFlag{Name: "payload", Usage: "JSON payload", Required: true},
-	},
+	Name:            "dnssec-update",
+	Usage:           "Update DNSSEC settings for a domain",
+	ArgsUsage:       "<domain>",
+	Flags:           payloadFlags("JSON payload"),
 	Action:          handleDomainsDNSSECUpdate,
 	HideHelpCommand: true,
 }
@@ -431,7 +399,11 @@ func handleDomainsDNSSECUpdate(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	res, err := client.PutJSON(ctx, "/hosting/v2/domains/"+args[0]+"/dnssec", []byte(cmd.String("payload")))
+	body, err := loadPayload(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := client.PutJSON(ctx, "/hosting/v2/domains/"+args[0]+"/dnssec", body)
 	if err != nil {
 		return err
 	}
@@ -487,12 +459,10 @@ func handleDomainsLocksGet(ctx context.Context, cmd *cli.Command) error {
 }
 
 var domainsLocksSetCmd = cli.Command{
-	Name:      "locks-set",
-	Usage:     "Set domain locks",
-	ArgsUsage: "<domain>",
-	Flags: []cli.Flag{
-		&cli.StringFlag{Name: "payload", Usage: "JSON payload", Required: true},
-	},
+	Name:            "locks-set",
+	Usage:           "Set domain locks",
+	ArgsUsage:       "<domain>",
+	Flags:           payloadFlags("JSON payload"),
 	Action:          handleDomainsLocksSet,
 	HideHelpCommand: true,
 }
@@ -506,7 +476,11 @@ func handleDomainsLocksSet(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	res, err := client.PostJSON(ctx, "/hosting/v2/domains/"+args[0]+"/locks", []byte(cmd.String("payload")))
+	body, err := loadPayload(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := client.PostJSON(ctx, "/hosting/v2/domains/"+args[0]+"/locks", body)
 	if err != nil {
 		return err
 	}
@@ -538,12 +512,10 @@ func handleDomainsNameservers(ctx context.Context, cmd *cli.Command) error {
 }
 
 var domainsNameserversUpdateCmd = cli.Command{
-	Name:      "nameservers-update",
-	Usage:     "Update nameservers for a domain",
-	ArgsUsage: "<domain>",
-	Flags: []cli.Flag{
-		&cli.StringFlag{Name: "payload", Usage: "JSON payload", Required: true},
-	},
+	Name:            "nameservers-update",
+	Usage:           "Update nameservers for a domain",
+	ArgsUsage:       "<domain>",
+	Flags:           payloadFlags("JSON payload"),
 	Action:          handleDomainsNameserversUpdate,
 	HideHelpCommand: true,
 }
@@ -557,7 +529,11 @@ func handleDomainsNameserversUpdate(ctx context.Context, cmd *cli.Command) error
 	if err != nil {
 		return err
 	}
-	res, err := client.PutJSON(ctx, "/hosting/v2/domains/"+args[0]+"/nameservers", []byte(cmd.String("payload")))
+	body, err := loadPayload(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := client.PutJSON(ctx, "/hosting/v2/domains/"+args[0]+"/nameservers", body)
 	if err != nil {
 		return err
 	}
@@ -589,12 +565,10 @@ func handleDomainsOverview(ctx context.Context, cmd *cli.Command) error {
 }
 
 var domainsDNSUpdateAllCmd = cli.Command{
-	Name:      "dns-update-all",
-	Usage:     "Update all DNS records for a domain",
-	ArgsUsage: "<domain>",
-	Flags: []cli.Flag{
-		&cli.StringFlag{Name: "payload", Usage: "JSON payload with all records", Required: true},
-	},
+	Name:            "dns-update-all",
+	Usage:           "Update all DNS records for a domain",
+	ArgsUsage:       "<domain>",
+	Flags:           payloadFlags("JSON payload with all records"),
 	Action:          handleDomainsDNSUpdateAll,
 	HideHelpCommand: true,
 }
@@ -608,7 +582,11 @@ func handleDomainsDNSUpdateAll(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	res, err := client.PutJSON(ctx, "/hosting/v2/domains/"+args[0]+"/resourceRecordSets", []byte(cmd.String("payload")))
+	body, err := loadPayload(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := client.PutJSON(ctx, "/hosting/v2/domains/"+args[0]+"/resourceRecordSets", body)
 	if err != nil {
 		return err
 	}
@@ -619,7 +597,7 @@ var domainsDNSDeleteAllCmd = cli.Command{
 	Name:            "dns-delete-all",
 	Usage:           "Delete all DNS records for a domain",
 	ArgsUsage:       "<domain>",
-	Action:          handleDomainsDNSDeleteAll,
+	Action:          confirm(destructive{"delete all DNS records of %s", "/hosting/v2/domains/%s", domainDetails}, handleDomainsDNSDeleteAll),
 	HideHelpCommand: true,
 }
 
@@ -641,12 +619,10 @@ func handleDomainsDNSDeleteAll(ctx context.Context, cmd *cli.Command) error {
 }
 
 var domainsDNSUpdateCmd = cli.Command{
-	Name:      "dns-update",
-	Usage:     "Update a specific DNS record",
-	ArgsUsage: "<domain> <name> <type>",
-	Flags: []cli.Flag{
-		&cli.StringFlag{Name: "payload", Usage: "JSON payload", Required: true},
-	},
+	Name:            "dns-update",
+	Usage:           "Update a specific DNS record",
+	ArgsUsage:       "<domain> <name> <type>",
+	Flags:           payloadFlags("JSON payload"),
 	Action:          handleDomainsDNSUpdate,
 	HideHelpCommand: true,
 }
@@ -660,7 +636,11 @@ func handleDomainsDNSUpdate(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	res, err := client.PutJSON(ctx, fmt.Sprintf("/hosting/v2/domains/%s/resourceRecordSets/%s/%s", args[0], args[1], args[2]), []byte(cmd.String("payload")))
+	body, err := loadPayload(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := client.PutJSON(ctx, fmt.Sprintf("/hosting/v2/domains/%s/resourceRecordSets/%s/%s", args[0], args[1], args[2]), body)
 	if err != nil {
 		return err
 	}
@@ -692,12 +672,10 @@ func handleDomainsDNSExport(ctx context.Context, cmd *cli.Command) error {
 }
 
 var domainsDNSImportCmd = cli.Command{
-	Name:      "dns-import",
-	Usage:     "Import DNS records from bind file content",
-	ArgsUsage: "<domain>",
-	Flags: []cli.Flag{
-		&cli.StringFlag{Name: "payload", Usage: "JSON payload with bind content", Required: true},
-	},
+	Name:            "dns-import",
+	Usage:           "Import DNS records from bind file content",
+	ArgsUsage:       "<domain>",
+	Flags:           payloadFlags("JSON payload with bind content"),
 	Action:          handleDomainsDNSImport,
 	HideHelpCommand: true,
 }
@@ -711,7 +689,11 @@ func handleDomainsDNSImport(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	res, err := client.PostJSON(ctx, "/hosting/v2/domains/"+args[0]+"/resourceRecordSets/import", []byte(cmd.String("payload")))
+	body, err := loadPayload(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := client.PostJSON(ctx, "/hosting/v2/domains/"+args[0]+"/resourceRecordSets/import", body)
 	if err != nil {
 		return err
 	}
@@ -719,12 +701,10 @@ func handleDomainsDNSImport(ctx context.Context, cmd *cli.Command) error {
 }
 
 var domainsDNSValidateSetCmd = cli.Command{
-	Name:      "dns-validate-set",
-	Usage:     "Validate a resource record set",
-	ArgsUsage: "<domain>",
-	Flags: []cli.Flag{
-		&cli.StringFlag{Name: "payload", Usage: "JSON payload", Required: true},
-	},
+	Name:            "dns-validate-set",
+	Usage:           "Validate a resource record set",
+	ArgsUsage:       "<domain>",
+	Flags:           payloadFlags("JSON payload"),
 	Action:          handleDomainsDNSValidateSet,
 	HideHelpCommand: true,
 }
@@ -738,7 +718,11 @@ func handleDomainsDNSValidateSet(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	res, err := client.PostJSON(ctx, "/hosting/v2/domains/"+args[0]+"/resourceRecordSets/validateSet", []byte(cmd.String("payload")))
+	body, err := loadPayload(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := client.PostJSON(ctx, "/hosting/v2/domains/"+args[0]+"/resourceRecordSets/validateSet", body)
 	if err != nil {
 		return err
 	}
@@ -746,12 +730,10 @@ func handleDomainsDNSValidateSet(ctx context.Context, cmd *cli.Command) error {
 }
 
 var domainsValidateZoneCmd = cli.Command{
-	Name:      "validate-zone",
-	Usage:     "Validate zone for a domain",
-	ArgsUsage: "<domain>",
-	Flags: []cli.Flag{
-		&cli.StringFlag{Name: "payload", Usage: "JSON payload", Required: true},
-	},
+	Name:            "validate-zone",
+	Usage:           "Validate zone for a domain",
+	ArgsUsage:       "<domain>",
+	Flags:           payloadFlags("JSON payload"),
 	Action:          handleDomainsValidateZone,
 	HideHelpCommand: true,
 }
@@ -765,7 +747,11 @@ func handleDomainsValidateZone(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	res, err := client.PostJSON(ctx, "/hosting/v2/domains/"+args[0]+"/validateZone", []byte(cmd.String("payload")))
+	body, err := loadPayload(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := client.PostJSON(ctx, "/hosting/v2/domains/"+args[0]+"/validateZone", body)
 	if err != nil {
 		return err
 	}
@@ -773,12 +759,10 @@ func handleDomainsValidateZone(ctx context.Context, cmd *cli.Command) error {
 }
 
 var domainsKeyRolloverCmd = cli.Command{
-	Name:      "key-rollover",
-	Usage:     "Perform key rollover for a domain",
-	ArgsUsage: "<domain>",
-	Flags: []cli.Flag{
-		&cli.StringFlag{Name: "payload", Usage: "JSON payload", Required: true},
-	},
+	Name:            "key-rollover",
+	Usage:           "Perform key rollover for a domain",
+	ArgsUsage:       "<domain>",
+	Flags:           payloadFlags("JSON payload"),
 	Action:          handleDomainsKeyRollover,
 	HideHelpCommand: true,
 }
@@ -792,7 +776,11 @@ func handleDomainsKeyRollover(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	res, err := client.PostJSON(ctx, "/hosting/v2/domains/"+args[0]+"/keyRollover", []byte(cmd.String("payload")))
+	body, err := loadPayload(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := client.PostJSON(ctx, "/hosting/v2/domains/"+args[0]+"/keyRollover", body)
 	if err != nil {
 		return err
 	}
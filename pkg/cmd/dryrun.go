@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/kernel/leaseweb-cli/pkg/leaseweb"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/pretty"
+	"github.com/urfave/cli/v3"
+)
+
+// ErrDryRun is returned once a mutating request has been printed instead of
+// sent; the command is then considered successful.
+var ErrDryRun = leaseweb.ErrDryRun
+
+// dryRunModes are the values accepted by --dry-run. A bare --dry-run
+// selects "request".
+var dryRunModes = []string{"request", "curl"}
+
+// dryRunFlag is a string flag that may also be given without a value.
+type dryRunFlag = cli.FlagBase[string, cli.NoConfig, dryRunValue]
+
+type dryRunValue struct {
+	destination *string
+}
+
+func (v dryRunValue) Create(val string, p *string, _ cli.NoConfig) cli.Value {
+	*p = val
+	return &dryRunValue{destination: p}
+}
+
+func (v dryRunValue) ToString(val string) string {
+	return val
+}
+
+func (v *dryRunValue) Set(s string) error {
+	switch s {
+	case "true":
+		s = "request"
+	case "false":
+		s = ""
+	default:
+		if !slices.Contains(dryRunModes, s) {
+			return fmt.Errorf("dry-run must be one of: %s", strings.Join(dryRunModes, ", "))
+		}
+	}
+	*v.destination = s
+	return nil
+}
+
+func (v *dryRunValue) Get() any { return *v.destination }
+
+func (v *dryRunValue) String() string {
+	if v.destination == nil {
+		return ""
+	}
+	return *v.destination
+}
+
+// IsBoolFlag lets --dry-run be given without a value.
+func (v *dryRunValue) IsBoolFlag() bool { return true }
+
+// dryRunPrinter returns the function that prints held back requests to out
+// in the given --dry-run mode and output format. API keys sent to the auth
+// endpoints are scrubbed from the body as in cassettes.
+func dryRunPrinter(out *os.File, mode, format string) func(*http.Request, []byte) error {
+	return func(req *http.Request, body []byte) error {
+		body = scrubBody(req.URL.Path, body)
+		if mode == "curl" {
+			_, err := io.WriteString(out, curlCommand(req, body))
+			return err
+		}
+		if strings.ToLower(format) == "auto" {
+			fmt.Fprintf(out, "%s %s\n", req.Method, req.URL)
+			if len(body) > 0 {
+				if gjson.ValidBytes(body) {
+					body = pretty.Pretty(body)
+				}
+				_, err := out.Write(body)
+				return err
+			}
+			return nil
+		}
+
+		doc := map[string]any{
+			"method": req.Method,
+			"url":    req.URL.String(),
+		}
+		if h := dryRunHeaders(req.Header); len(h) > 0 {
+			doc["headers"] = h
+		}
+		if len(body) > 0 {
+			if gjson.ValidBytes(body) {
+				doc["body"] = json.RawMessage(body)
+			} else {
+				doc["body"] = string(body)
+			}
+		}
+		b, err := json.Marshal(doc)
+		if err != nil {
+			return err
+		}
+		return ShowJSON(out, string(b), format, "")
+	}
+}
+
+// dryRunHeaders returns the request headers worth showing: the API key is
+// masked and the User-Agent left out.
+func dryRunHeaders(h http.Header) map[string]string {
+	res := map[string]string{}
+	for k := range h {
+		switch k {
+		case "User-Agent":
+		case "X-Lsw-Auth":
+			res[k] = maskKey(h.Get(k))
+		default:
+			res[k] = strings.Join(h.Values(k), ", ")
+		}
+	}
+	return res
+}
+
+// curlCommand renders the request as a curl invocation that reads the API
+// key from LEASEWEB_API_KEY.
+func curlCommand(req *http.Request, body []byte) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "curl -X %s %s", req.Method, shellQuote(req.URL.String()))
+	b.WriteString(` \` + "\n" + `  -H "X-LSW-Auth: $LEASEWEB_API_KEY"`)
+	keys := make([]string, 0, len(req.Header))
+	for k := range req.Header {
+		if k != "X-Lsw-Auth" && k != "User-Agent" {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		for _, v := range req.Header.Values(k) {
+			fmt.Fprintf(&b, " \\\n  -H %s", shellQuote(k+": "+v))
+		}
+	}
+	if len(body) > 0 {
+		fmt.Fprintf(&b, " \\\n  --data-raw %s", shellQuote(string(body)))
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// shellQuote quotes s for POSIX shells.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
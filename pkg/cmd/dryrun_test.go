@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func TestDryRun(t *testing.T) {
+	var mutating int
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"POST /bareMetals/v2/servers/12345/powerOff": func(w http.ResponseWriter, r *http.Request) {
+			mutating++
+		},
+		"PUT /hosting/v2/domains/example.com/resourceRecordSets/www/A": func(w http.ResponseWriter, r *http.Request) {
+			mutating++
+		},
+	})
+	defer srv.Close()
+
+	stdout, _, err := runCLI(t, srv.URL, []string{"--dry-run", "ds", "power-off", "12345"})
+	require.ErrorIs(t, err, ErrDryRun)
+	assert.Equal(t, "POST "+srv.URL+"/bareMetals/v2/servers/12345/powerOff\n", stdout)
+
+	stdout, _, err = runCLI(t, srv.URL, []string{
+		"--dry-run=curl", "domains", "dns-update", "example.com", "www", "A",
+		"--set", "ttl=300", "--set-json", `content=["1.2.3.4"]`,
+	})
+	require.ErrorIs(t, err, ErrDryRun)
+	assert.Equal(t, "curl -X PUT '"+srv.URL+"/hosting/v2/domains/example.com/resourceRecordSets/www/A' \\\n"+
+		"  -H \"X-LSW-Auth: $LEASEWEB_API_KEY\" \\\n"+
+		"  -H 'Content-Type: application/json' \\\n"+
+		"  --data-raw '{\"content\":[\"1.2.3.4\"],\"ttl\":300}'\n", stdout)
+
+	stdout, _, err = runCLI(t, srv.URL, []string{"-o", "raw", "--dry-run", "ds", "power-off", "12345"})
+	require.ErrorIs(t, err, ErrDryRun)
+	assert.Equal(t, "POST", gjson.Get(stdout, "method").String())
+	assert.Equal(t, "********", gjson.Get(stdout, "headers.X-Lsw-Auth").String())
+
+	// Fields named like credentials are only scrubbed on API key endpoints.
+	stdout, _, err = runCLI(t, srv.URL, []string{"--dry-run", "api", "/publicCloud/v1/instances/abc/tags", "-f", "key=env"})
+	require.ErrorIs(t, err, ErrDryRun)
+	assert.Contains(t, stdout, `"key": "env"`)
+	stdout, _, err = runCLI(t, srv.URL, []string{"--dry-run", "api", "/auth/v2/apiKeys/validate", "-f", "apiKey=secret-key"})
+	require.ErrorIs(t, err, ErrDryRun)
+	assert.NotContains(t, stdout, "secret-key")
+
+	assert.Equal(t, 0, mutating)
+}
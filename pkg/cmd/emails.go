@@ -32,7 +32,7 @@ var emailDomainsListCmd = cli.Command{Name: "domains", Usage: "List email domain
 	if err != nil {
 		return err
 	}
-	res, err := client.Get(ctx, "/email/v2/domains?"+PaginationQuery(cmd))
+	res, err := GetList(ctx, client, cmd, "/email/v2/domains?"+PaginationQuery(cmd))
 	if err != nil {
 		return err
 	}
@@ -44,7 +44,11 @@ var emailDomainCreateCmd = cli.Command{Name: "domain-create", Flags: payloadFlag
 	if err != nil {
 		return err
 	}
-	res, err := client.PostJSON(ctx, "/email/v2/domains", []byte(cmd.String("payload")))
+	body, err := loadPayload(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := client.PostJSON(ctx, "/email/v2/domains", body)
 	if err != nil {
 		return err
 	}
@@ -76,14 +80,18 @@ var emailDomainUpdateCmd = cli.Command{Name: "domain-update", ArgsUsage: "<domai
 	if err != nil {
 		return err
 	}
-	res, err := client.PutJSON(ctx, emailDomainPath(args), []byte(cmd.String("payload")))
+	body, err := loadPayload(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := client.PutJSON(ctx, emailDomainPath(args), body)
 	if err != nil {
 		return err
 	}
 	return ShowResult(os.Stdout, res, cmd.Root().String("output"), cmd.Root().String("transform"))
 }, HideHelpCommand: true}
 
-var emailDomainDeleteCmd = cli.Command{Name: "domain-delete", ArgsUsage: "<domain>", Action: func(ctx context.Context, cmd *cli.Command) error {
+var emailDomainDeleteCmd = cli.Command{Name: "domain-delete", ArgsUsage: "<domain>", Action: confirm(destructive{"delete the email domain %s", "/hosting/v2/domains/%s", domainDetails}, func(ctx context.Context, cmd *cli.Command) error {
 	args := cmd.Args().Slice()
 	if len(args) < 1 {
 		return fmt.Errorf("domain required")
@@ -98,7 +106,7 @@ var emailDomainDeleteCmd = cli.Command{Name: "domain-delete", ArgsUsage: "<domai
 	}
 	fmt.Fprintf(os.Stderr, "Deleted domain %s\n", args[0])
 	return nil
-}, HideHelpCommand: true}
+}), HideHelpCommand: true}
 
 var emailDomainVerifyCmd = cli.Command{Name: "domain-verify", ArgsUsage: "<domain>", Action: func(ctx context.Context, cmd *cli.Command) error {
 	args := cmd.Args().Slice()
@@ -146,7 +154,7 @@ var emailMailboxesListCmd = cli.Command{Name: "mailboxes", ArgsUsage: "<domain>"
 	if err != nil {
 		return err
 	}
-	res, err := client.Get(ctx, emailDomainPath(args)+"/mailboxes?"+PaginationQuery(cmd))
+	res, err := GetList(ctx, client, cmd, emailDomainPath(args)+"/mailboxes?"+PaginationQuery(cmd))
 	if err != nil {
 		return err
 	}
@@ -162,7 +170,11 @@ var emailMailboxCreateCmd = cli.Command{Name: "mailbox-create", ArgsUsage: "<dom
 	if err != nil {
 		return err
 	}
-	res, err := client.PostJSON(ctx, emailDomainPath(args)+"/mailboxes", []byte(cmd.String("payload")))
+	body, err := loadPayload(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := client.PostJSON(ctx, emailDomainPath(args)+"/mailboxes", body)
 	if err != nil {
 		return err
 	}
@@ -194,14 +206,18 @@ var emailMailboxUpdateCmd = cli.Command{Name: "mailbox-update", ArgsUsage: "<dom
 	if err != nil {
 		return err
 	}
-	res, err := client.PutJSON(ctx, emailMailboxPath(args), []byte(cmd.String("payload")))
+	body, err := loadPayload(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := client.PutJSON(ctx, emailMailboxPath(args), body)
 	if err != nil {
 		return err
 	}
 	return ShowResult(os.Stdout, res, cmd.Root().String("output"), cmd.Root().String("transform"))
 }, HideHelpCommand: true}
 
-var emailMailboxDeleteCmd = cli.Command{Name: "mailbox-delete", ArgsUsage: "<domain> <mailbox>", Action: func(ctx context.Context, cmd *cli.Command) error {
+var emailMailboxDeleteCmd = cli.Command{Name: "mailbox-delete", ArgsUsage: "<domain> <mailbox>", Action: confirm(destructive{"delete mailbox %[2]s of %[1]s", "/hosting/v2/domains/%s", domainDetails}, func(ctx context.Context, cmd *cli.Command) error {
 	args := cmd.Args().Slice()
 	if len(args) < 2 {
 		return fmt.Errorf("domain and mailbox required")
@@ -216,7 +232,7 @@ var emailMailboxDeleteCmd = cli.Command{Name: "mailbox-delete", ArgsUsage: "<dom
 	}
 	fmt.Fprintf(os.Stderr, "Deleted mailbox %s\n", args[1])
 	return nil
-}, HideHelpCommand: true}
+}), HideHelpCommand: true}
 
 var emailMailboxAutoReplyCmd = cli.Command{Name: "mailbox-autoreply", ArgsUsage: "<domain> <mailbox>", Action: func(ctx context.Context, cmd *cli.Command) error {
 	args := cmd.Args().Slice()
@@ -243,7 +259,11 @@ var emailMailboxAutoReplyUpdateCmd = cli.Command{Name: "mailbox-autoreply-update
 	if err != nil {
 		return err
 	}
-	res, err := client.PutJSON(ctx, emailMailboxPath(args)+"/autoReply", []byte(cmd.String("payload")))
+	body, err := loadPayload(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := client.PutJSON(ctx, emailMailboxPath(args)+"/autoReply", body)
 	if err != nil {
 		return err
 	}
@@ -260,7 +280,7 @@ var emailForwardsListCmd = cli.Command{Name: "forwards", ArgsUsage: "<domain>",
 	if err != nil {
 		return err
 	}
-	res, err := client.Get(ctx, emailDomainPath(args)+"/forwards?"+PaginationQuery(cmd))
+	res, err := GetList(ctx, client, cmd, emailDomainPath(args)+"/forwards?"+PaginationQuery(cmd))
 	if err != nil {
 		return err
 	}
@@ -276,7 +296,11 @@ var emailForwardCreateCmd = cli.Command{Name: "forward-create", ArgsUsage: "<dom
 	if err != nil {
 		return err
 	}
-	res, err := client.PostJSON(ctx, emailDomainPath(args)+"/forwards", []byte(cmd.String("payload")))
+	body, err := loadPayload(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := client.PostJSON(ctx, emailDomainPath(args)+"/forwards", body)
 	if err != nil {
 		return err
 	}
@@ -308,14 +332,18 @@ var emailForwardUpdateCmd = cli.Command{Name: "forward-update", ArgsUsage: "<dom
 	if err != nil {
 		return err
 	}
-	res, err := client.PutJSON(ctx, fmt.Sprintf("%s/forwards/%s", emailDomainPath(args), args[1]), []byte(cmd.String("payload")))
+	body, err := loadPayload(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := client.PutJSON(ctx, fmt.Sprintf("%s/forwards/%s", emailDomainPath(args), args[1]), body)
 	if err != nil {
 		return err
 	}
 	return ShowResult(os.Stdout, res, cmd.Root().String("output"), cmd.Root().String("transform"))
 }, HideHelpCommand: true}
 
-var emailForwardDeleteCmd = cli.Command{Name: "forward-delete", ArgsUsage: "<domain> <forward-id>", Action: func(ctx context.Context, cmd *cli.Command) error {
+var emailForwardDeleteCmd = cli.Command{Name: "forward-delete", ArgsUsage: "<domain> <forward-id>", Action: confirm(destructive{"delete forward %[2]s of %[1]s", "/hosting/v2/domains/%s", domainDetails}, func(ctx context.Context, cmd *cli.Command) error {
 	args := cmd.Args().Slice()
 	if len(args) < 2 {
 		return fmt.Errorf("domain and forward ID required")
@@ -330,7 +358,7 @@ var emailForwardDeleteCmd = cli.Command{Name: "forward-delete", ArgsUsage: "<dom
 	}
 	fmt.Fprintf(os.Stderr, "Deleted forward %s\n", args[1])
 	return nil
-}, HideHelpCommand: true}
+}), HideHelpCommand: true}
 
 // Aliases
 var emailAliasesListCmd = cli.Command{Name: "aliases", ArgsUsage: "<domain>", Flags: PaginationFlags, Action: func(ctx context.Context, cmd *cli.Command) error {
@@ -342,7 +370,7 @@ var emailAliasesListCmd = cli.Command{Name: "aliases", ArgsUsage: "<domain>", Fl
 	if err != nil {
 		return err
 	}
-	res, err := client.Get(ctx, emailDomainPath(args)+"/aliases?"+PaginationQuery(cmd))
+	res, err := GetList(ctx, client, cmd, emailDomainPath(args)+"/aliases?"+PaginationQuery(cmd))
 	if err != nil {
 		return err
 	}
@@ -358,7 +386,11 @@ var emailAliasCreateCmd = cli.Command{Name: "alias-create", ArgsUsage: "<domain>
 	if err != nil {
 		return err
 	}
-	res, err := client.PostJSON(ctx, emailDomainPath(args)+"/aliases", []byte(cmd.String("payload")))
+	body, err := loadPayload(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := client.PostJSON(ctx, emailDomainPath(args)+"/aliases", body)
 	if err != nil {
 		return err
 	}
@@ -381,7 +413,7 @@ var emailAliasGetCmd = cli.Command{Name: "alias-get", ArgsUsage: "<domain> <alia
 	return ShowResult(os.Stdout, res, cmd.Root().String("output"), cmd.Root().String("transform"))
 }, HideHelpCommand: true}
 
-var emailAliasDeleteCmd = cli.Command{Name: "alias-delete", ArgsUsage: "<domain> <alias>", Action: func(ctx context.Context, cmd *cli.Command) error {
+var emailAliasDeleteCmd = cli.Command{Name: "alias-delete", ArgsUsage: "<domain> <alias>", Action: confirm(destructive{"delete alias %[2]s of %[1]s", "/hosting/v2/domains/%s", domainDetails}, func(ctx context.Context, cmd *cli.Command) error {
 	args := cmd.Args().Slice()
 	if len(args) < 2 {
 		return fmt.Errorf("domain and alias required")
@@ -396,7 +428,7 @@ var emailAliasDeleteCmd = cli.Command{Name: "alias-delete", ArgsUsage: "<domain>
 	}
 	fmt.Fprintf(os.Stderr, "Deleted alias %s\n", args[1])
 	return nil
-}, HideHelpCommand: true}
+}), HideHelpCommand: true}
 
 // Spam Filter
 var emailSpamFilterCmd = cli.Command{Name: "spam-filter", ArgsUsage: "<domain>", Action: func(ctx context.Context, cmd *cli.Command) error {
@@ -424,7 +456,11 @@ var emailSpamFilterUpdateCmd = cli.Command{Name: "spam-filter-update", ArgsUsage
 	if err != nil {
 		return err
 	}
-	res, err := client.PutJSON(ctx, emailDomainPath(args)+"/spamFilter", []byte(cmd.String("payload")))
+	body, err := loadPayload(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := client.PutJSON(ctx, emailDomainPath(args)+"/spamFilter", body)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"os/exec"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/kernel/leaseweb-cli/pkg/leaseweb"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/pretty"
+)
+
+// Exit codes of lw. They are part of its interface: scripts branch on them.
+const (
+	ExitError       = 1 // any failure not listed below
+	ExitAuth        = 2 // missing, invalid or insufficient API key (401, 403)
+	ExitNotFound    = 3 // the resource does not exist (404)
+	ExitValidation  = 4 // the request was rejected as invalid (400, 422 or schema check)
+	ExitRateLimited = 5 // still rate limited after retrying (429)
+	ExitServer      = 6 // the API failed (5xx)
+	ExitNetwork     = 7 // the API could not be reached
+)
+
+// errorKinds names the class of error behind each exit code in
+// machine-readable error output.
+var errorKinds = map[int]string{
+	ExitError:       "error",
+	ExitAuth:        "auth",
+	ExitNotFound:    "not_found",
+	ExitValidation:  "validation",
+	ExitRateLimited: "rate_limited",
+	ExitServer:      "server",
+	ExitNetwork:     "network",
+}
+
+// ExitCode returns the exit code lw uses for err.
+func ExitCode(err error) int {
+	var validationErr *ValidationError
+	var urlErr *url.Error
+	var netErr net.Error
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		return 0
+	case errors.As(err, &validationErr), errors.Is(err, leaseweb.ErrBadRequest):
+		return ExitValidation
+	case errors.Is(err, leaseweb.ErrUnauthorized), errors.Is(err, leaseweb.ErrForbidden), errors.Is(err, ErrNoAPIKey):
+		return ExitAuth
+	case errors.Is(err, leaseweb.ErrNotFound):
+		return ExitNotFound
+	case errors.Is(err, leaseweb.ErrRateLimited):
+		return ExitRateLimited
+	case errors.Is(err, leaseweb.ErrServer):
+		return ExitServer
+	case errors.As(err, &urlErr), errors.As(err, &netErr):
+		return ExitNetwork
+	case errors.As(err, &exitErr):
+		// A shell alias passes on the exit code of its script.
+		return exitErr.ExitCode()
+	}
+	return ExitError
+}
+
+// ShowError prints err to out: as readable text for the auto, csv and tsv
+// formats and as an {"error": {...}} document for the others.
+func ShowError(out *os.File, err error, format string) {
+	var apiErr *APIError
+	isAPI := errors.As(err, &apiErr)
+
+	format = strings.ToLower(format)
+	if format == "auto" || format == "csv" || format == "tsv" || !slices.Contains(OutputFormats, format) {
+		fmt.Fprintf(out, "Error: %s\n", err)
+		if isAPI {
+			showAPIErrorDetail(out, apiErr)
+		}
+		return
+	}
+
+	code := ExitCode(err)
+	doc := map[string]any{
+		"type":     errorKinds[code],
+		"exitCode": code,
+		"message":  err.Error(),
+	}
+	var validationErr *ValidationError
+	switch {
+	case isAPI:
+		doc["method"] = apiErr.Method
+		doc["url"] = apiErr.URL
+		doc["status"] = apiErr.StatusCode
+		if apiErr.Message != "" {
+			doc["message"] = apiErr.Message
+		} else {
+			doc["message"] = apiErr.Status
+		}
+		if apiErr.Code != "" {
+			doc["errorCode"] = apiErr.Code
+		}
+		if apiErr.CorrelationID != "" {
+			doc["correlationId"] = apiErr.CorrelationID
+		}
+		if len(apiErr.Details) > 0 {
+			doc["errorDetails"] = apiErr.Details
+		}
+		if apiErr.Message == "" && apiErr.Body != "" {
+			if gjson.Valid(apiErr.Body) {
+				doc["body"] = json.RawMessage(apiErr.Body)
+			} else {
+				doc["body"] = apiErr.Body
+			}
+		}
+	case errors.As(err, &validationErr):
+		doc["operation"] = validationErr.Operation
+		problems := make([]map[string]string, len(validationErr.Problems))
+		for i, p := range validationErr.Problems {
+			problems[i] = map[string]string{"pointer": p.Pointer, "message": p.Message}
+		}
+		doc["problems"] = problems
+	}
+
+	b, mErr := json.Marshal(map[string]any{"error": doc})
+	if mErr != nil || ShowJSON(out, string(b), format, "") != nil {
+		fmt.Fprintf(out, "Error: %s\n", err)
+	}
+}
+
+// showAPIErrorDetail prints the error details and correlation ID of an API
+// error, or its body when the API sent no error message.
+func showAPIErrorDetail(out *os.File, e *APIError) {
+	fields := make([]string, 0, len(e.Details))
+	for field := range e.Details {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		fmt.Fprintf(out, "  %s: %s\n", field, strings.Join(e.Details[field], "; "))
+	}
+	if e.Message == "" && strings.TrimSpace(e.Body) != "" {
+		body := []byte(e.Body)
+		if gjson.ValidBytes(body) {
+			body = pretty.Pretty(body)
+		}
+		fmt.Fprintf(out, "%s\n", strings.TrimRight(string(body), "\n"))
+	}
+	if e.Code != "" {
+		fmt.Fprintf(out, "Error code: %s\n", e.Code)
+	}
+	if e.CorrelationID != "" {
+		fmt.Fprintf(out, "Correlation ID: %s\n", e.CorrelationID)
+	}
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func TestErrorOutputAndExitCodes(t *testing.T) {
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"GET /bareMetals/v2/servers/missing": func(w http.ResponseWriter, r *http.Request) {
+			jsonResponse(w, 404, map[string]any{
+				"errorCode":     "404",
+				"errorMessage":  "Server with id missing not found",
+				"correlationId": "corr-1",
+			})
+		},
+		"PUT /bareMetals/v2/servers/12345": func(w http.ResponseWriter, r *http.Request) {
+			jsonResponse(w, 400, map[string]any{
+				"errorCode":    "APP00800",
+				"errorMessage": "The supplied data is invalid.",
+				"errorDetails": map[string]any{"reference": []string{"This value is too long."}},
+			})
+		},
+		"GET /invoices/v1/invoices": func(w http.ResponseWriter, r *http.Request) {
+			jsonResponse(w, 401, map[string]any{"errorMessage": "Invalid API key"})
+		},
+	})
+	defer srv.Close()
+
+	showError := func(err error, format string) string {
+		f, ferr := os.CreateTemp(t.TempDir(), "stderr")
+		require.NoError(t, ferr)
+		defer f.Close()
+		ShowError(f, err, format)
+		b, ferr := os.ReadFile(f.Name())
+		require.NoError(t, ferr)
+		return string(b)
+	}
+
+	_, _, err := runCLI(t, srv.URL, []string{"ds", "get", "missing"})
+	assert.Equal(t, ExitNotFound, ExitCode(err))
+	out := showError(err, "auto")
+	assert.Contains(t, out, "Error: GET "+srv.URL+"/bareMetals/v2/servers/missing: 404 Not Found: Server with id missing not found\n")
+	assert.Contains(t, out, "Correlation ID: corr-1\n")
+
+	out = showError(err, "raw")
+	assert.Equal(t, "not_found", gjson.Get(out, "error.type").String())
+	assert.Equal(t, int64(ExitNotFound), gjson.Get(out, "error.exitCode").Int())
+	assert.Equal(t, "Server with id missing not found", gjson.Get(out, "error.message").String())
+	assert.Equal(t, "corr-1", gjson.Get(out, "error.correlationId").String())
+
+	_, _, err = runCLI(t, srv.URL, []string{"ds", "update", "12345", "--reference", "web-01"})
+	assert.Equal(t, ExitValidation, ExitCode(err))
+	assert.Contains(t, showError(err, "auto"), "  reference: This value is too long.\n")
+	assert.Equal(t, "This value is too long.", gjson.Get(showError(err, "raw"), "error.errorDetails.reference.0").String())
+
+	_, _, err = runCLI(t, srv.URL, []string{"invoices", "list"})
+	assert.Equal(t, ExitAuth, ExitCode(err))
+
+	_, _, err = runCLI(t, srv.URL, []string{"domains", "dns-update", "example.com", "www", "A", "--payload", `{"content":["1.2.3.4"],"ttl":"x"}`})
+	assert.Equal(t, ExitValidation, ExitCode(err))
+	out = showError(err, "raw")
+	assert.Equal(t, "/ttl", gjson.Get(out, "error.problems.0.pointer").String())
+
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+	_, _, err = runCLI(t, closed.URL, []string{"--max-retries", "0", "invoices", "list"})
+	assert.Equal(t, ExitNetwork, ExitCode(err))
+}
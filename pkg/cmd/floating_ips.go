@@ -25,7 +25,7 @@ var fipListCmd = cli.Command{Name: "list", Usage: "List floating IPs", Flags: Pa
 	if err != nil {
 		return err
 	}
-	res, err := client.Get(ctx, "/floatingIps/v2/ranges?"+PaginationQuery(cmd))
+	res, err := GetList(ctx, client, cmd, "/floatingIps/v2/ranges?"+PaginationQuery(cmd))
 	if err != nil {
 		return err
 	}
@@ -37,7 +37,11 @@ var fipCreateCmd = cli.Command{Name: "create", Usage: "Create floating IP range"
 	if err != nil {
 		return err
 	}
-	res, err := client.PostJSON(ctx, "/floatingIps/v2/ranges", []byte(cmd.String("payload")))
+	body, err := loadPayload(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := client.PostJSON(ctx, "/floatingIps/v2/ranges", body)
 	if err != nil {
 		return err
 	}
@@ -77,7 +81,7 @@ var fipUpdateCmd = cli.Command{Name: "update", Usage: "Update floating IP range"
 	return ShowResult(os.Stdout, res, cmd.Root().String("output"), cmd.Root().String("transform"))
 }, HideHelpCommand: true}
 
-var fipDeleteCmd = cli.Command{Name: "delete", Usage: "Delete floating IP range", ArgsUsage: "<id>", Action: func(ctx context.Context, cmd *cli.Command) error {
+var fipDeleteCmd = cli.Command{Name: "delete", Usage: "Delete floating IP range", ArgsUsage: "<id>", Action: confirm(destructive{"delete floating IP range %s", "/floatingIps/v2/ranges/%s", rangeDetails}, func(ctx context.Context, cmd *cli.Command) error {
 	args := cmd.Args().Slice()
 	if len(args) < 1 {
 		return fmt.Errorf("range ID required")
@@ -92,14 +96,14 @@ var fipDeleteCmd = cli.Command{Name: "delete", Usage: "Delete floating IP range"
 	}
 	fmt.Fprintf(os.Stderr, "Deleted range %s\n", args[0])
 	return nil
-}, HideHelpCommand: true}
+}), HideHelpCommand: true}
 
 var fipDefinitionsCmd = cli.Command{Name: "definitions", Usage: "List floating IP definitions", Flags: PaginationFlags, Action: func(ctx context.Context, cmd *cli.Command) error {
 	client, err := NewClient(cmd)
 	if err != nil {
 		return err
 	}
-	res, err := client.Get(ctx, "/floatingIps/v2/ranges/definitions?"+PaginationQuery(cmd))
+	res, err := GetList(ctx, client, cmd, "/floatingIps/v2/ranges/definitions?"+PaginationQuery(cmd))
 	if err != nil {
 		return err
 	}
@@ -12,7 +12,7 @@ import (
 	"golang.org/x/term"
 )
 
-var OutputFormats = []string{"auto", "json", "jsonline", "pretty", "raw", "yaml"}
+var OutputFormats = []string{"auto", "json", "jsonline", "pretty", "raw", "yaml", "csv", "tsv"}
 
 func isTerminal(w io.Writer) bool {
 	switch v := w.(type) {
@@ -45,6 +45,9 @@ func ShowJSON(out *os.File, raw string, format string, transform string) error {
 			res = transformed
 		}
 	}
+	if kind, arg, ok := strings.Cut(format, "="); ok {
+		return showTemplated(out, res, kind, arg)
+	}
 	switch strings.ToLower(format) {
 	case "auto":
 		ShowDetail(out, res)
@@ -67,6 +70,8 @@ func ShowJSON(out *os.File, raw string, format string, transform string) error {
 	case "raw":
 		_, err := out.Write([]byte(res.Raw + "\n"))
 		return err
+	case "csv", "tsv":
+		return writeDelimited(out, res, strings.ToLower(format), outputColumns)
 	case "yaml":
 		input := strings.NewReader(res.Raw)
 		var yamlOut strings.Builder
@@ -84,3 +89,14 @@ func ShowJSON(out *os.File, raw string, format string, transform string) error {
 func ShowResult(out *os.File, res gjson.Result, format string, transform string) error {
 	return ShowJSON(out, res.Raw, format, transform)
 }
+
+// ShowList displays the response of a list command in the requested format.
+// columns are the paths of the fields its table shows, which csv and tsv use
+// unless --columns is given or the output is transformed.
+func ShowList(out *os.File, res gjson.Result, format string, transform string, columns []string) error {
+	lower := strings.ToLower(format)
+	if (lower != "csv" && lower != "tsv") || len(outputColumns) > 0 || transform != "" {
+		return ShowResult(out, res, format, transform)
+	}
+	return writeDelimited(out, res, lower, columns)
+}
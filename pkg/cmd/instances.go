@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/kernel/leaseweb-cli/pkg/leaseweb"
 	"github.com/tidwall/gjson"
 	"github.com/urfave/cli/v3"
 )
@@ -74,66 +75,34 @@ var instancesCmd = cli.Command{
 }
 
 var instancesListCmd = cli.Command{
-	Name:  "list",
-	Usage: "List public cloud instances",
-	Flags: PaginationFlags,
+	Name:            "list",
+	Usage:           "List public cloud instances",
+	Flags:           PaginationFlags,
 	Action:          handleInstancesList,
 	HideHelpCommand: true,
 }
 
 func handleInstancesList(ctx context.Context, cmd *cli.Command) error {
-	client, err := NewClient(cmd)
-	if err != nil {
-		return err
-	}
-	res, err := client.Get(ctx, "/publicCloud/v1/instances?"+PaginationQuery(cmd))
-	if err != nil {
-		return err
-	}
-
-	format := cmd.Root().String("output")
-	if format != "auto" {
-		return ShowResult(os.Stdout, res, format, cmd.Root().String("transform"))
-	}
-
-	instances := res.Get("instances")
-	if !instances.Exists() || len(instances.Array()) == 0 {
-		fmt.Fprintln(os.Stderr, "No instances found.")
-		return nil
-	}
-
-	table := NewTableWriter(os.Stdout, "ID", "REFERENCE", "TYPE", "REGION", "STATE", "IMAGE", "PUBLIC IP")
-	table.TruncOrder = []int{0, 5, 6}
-	instances.ForEach(func(_, inst gjson.Result) bool {
-		id := inst.Get("id").String()
-		ref := inst.Get("reference").String()
-		itype := inst.Get("type.name").String()
-		region := inst.Get("region").String()
-		state := inst.Get("state").String()
-		image := inst.Get("image.id").String()
-		ip := ""
-		ips := inst.Get("ips")
-		if ips.Exists() && ips.IsArray() {
-			ips.ForEach(func(_, ipObj gjson.Result) bool {
-				if ipObj.Get("version").Int() == 4 {
-					ip = ipObj.Get("ip").String()
-					return false
-				}
-				return true
-			})
-		}
-		table.AddRow(id, ref, itype, region, state, image, ip)
-		return true
+	return runList(ctx, cmd, func(ctx context.Context, client *Client) ([]leaseweb.Instance, gjson.Result, error) {
+		return ListPages(ctx, cmd, func(opts leaseweb.ListOptions) (*leaseweb.Page[leaseweb.Instance], error) {
+			return client.API().PublicCloud.ListInstances(ctx, &leaseweb.ListInstancesOptions{ListOptions: opts})
+		})
+	}, listView[leaseweb.Instance]{
+		empty:      "No instances found.",
+		headers:    []string{"ID", "REFERENCE", "TYPE", "REGION", "STATE", "IMAGE", "PUBLIC IP"},
+		truncOrder: []int{0, 5, 6},
+		columns:    []string{"id", "reference", "type", "region", "state", "image.id", "ips.#(version==4).ip"},
+		row: func(inst leaseweb.Instance) []string {
+			return []string{inst.ID, inst.Reference, inst.Type.Name, inst.Region, inst.State, inst.Image.ID, inst.PublicIPv4()}
+		},
 	})
-	table.Render()
-	return nil
 }
 
 var instancesGetCmd = cli.Command{
-	Name:      "get",
-	Usage:     "Get instance details",
-	ArgsUsage: "<instance-id>",
-	Action:    handleInstancesGet,
+	Name:            "get",
+	Usage:           "Get instance details",
+	ArgsUsage:       "<instance-id>",
+	Action:          handleInstancesGet,
 	HideHelpCommand: true,
 }
 
@@ -157,7 +126,7 @@ var instancesLaunchCmd = cli.Command{
 	Name:  "launch",
 	Usage: "Launch a new instance",
 	Flags: []cli.Flag{
-		&cli.StringFlag{Name: "region", Usage: "Region (e.g., eu-west-3)", Required: true},
+		&cli.StringFlag{Name: "region", Usage: "Region, e.g. eu-west-3 (default: default_region of the profile)"},
 		&cli.StringFlag{Name: "type", Usage: "Instance type (e.g., lsw.c3.large)", Required: true},
 		&cli.StringFlag{Name: "image", Usage: "Image ID (e.g., UBUNTU_22_04_64BIT)", Required: true},
 		&cli.StringFlag{Name: "contract-type", Usage: "HOURLY or MONTHLY", Required: true},
@@ -173,13 +142,17 @@ var instancesLaunchCmd = cli.Command{
 }
 
 func handleInstancesLaunch(ctx context.Context, cmd *cli.Command) error {
+	region, err := resolveRegion(cmd)
+	if err != nil {
+		return err
+	}
 	client, err := NewClient(cmd)
 	if err != nil {
 		return err
 	}
 
 	payload := map[string]any{
-		"region":              cmd.String("region"),
+		"region":              region,
 		"type":                cmd.String("type"),
 		"imageId":             cmd.String("image"),
 		"contractType":        cmd.String("contract-type"),
@@ -208,10 +181,10 @@ func handleInstancesLaunch(ctx context.Context, cmd *cli.Command) error {
 }
 
 var instancesTerminateCmd = cli.Command{
-	Name:      "terminate",
-	Usage:     "Terminate an instance",
-	ArgsUsage: "<instance-id>",
-	Action:    handleInstancesTerminate,
+	Name:            "terminate",
+	Usage:           "Terminate an instance",
+	ArgsUsage:       "<instance-id>",
+	Action:          confirm(destructive{"terminate instance %s", "/publicCloud/v1/instances/%s", instanceDetails}, handleInstancesTerminate),
 	HideHelpCommand: true,
 }
 
@@ -224,7 +197,7 @@ func handleInstancesTerminate(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	_, err = client.Delete(ctx, "/publicCloud/v1/instances/"+args[0])
+	err = client.API().PublicCloud.TerminateInstance(ctx, args[0])
 	if err != nil {
 		return err
 	}
@@ -233,10 +206,10 @@ func handleInstancesTerminate(ctx context.Context, cmd *cli.Command) error {
 }
 
 var instancesStartCmd = cli.Command{
-	Name:      "start",
-	Usage:     "Start a stopped instance",
-	ArgsUsage: "<instance-id>",
-	Action:    handleInstancesStart,
+	Name:            "start",
+	Usage:           "Start a stopped instance",
+	ArgsUsage:       "<instance-id>",
+	Action:          handleInstancesStart,
 	HideHelpCommand: true,
 }
 
@@ -249,7 +222,7 @@ func handleInstancesStart(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	_, err = client.Post(ctx, "/publicCloud/v1/instances/"+args[0]+"/start", "")
+	err = client.API().PublicCloud.StartInstance(ctx, args[0])
 	if err != nil {
 		return err
 	}
@@ -258,10 +231,10 @@ func handleInstancesStart(ctx context.Context, cmd *cli.Command) error {
 }
 
 var instancesStopCmd = cli.Command{
-	Name:      "stop",
-	Usage:     "Stop a running instance",
-	ArgsUsage: "<instance-id>",
-	Action:    handleInstancesStop,
+	Name:            "stop",
+	Usage:           "Stop a running instance",
+	ArgsUsage:       "<instance-id>",
+	Action:          handleInstancesStop,
 	HideHelpCommand: true,
 }
 
@@ -274,7 +247,7 @@ func handleInstancesStop(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	_, err = client.Post(ctx, "/publicCloud/v1/instances/"+args[0]+"/stop", "")
+	err = client.API().PublicCloud.StopInstance(ctx, args[0])
 	if err != nil {
 		return err
 	}
@@ -283,10 +256,10 @@ func handleInstancesStop(ctx context.Context, cmd *cli.Command) error {
 }
 
 var instancesRebootCmd = cli.Command{
-	Name:      "reboot",
-	Usage:     "Reboot an instance",
-	ArgsUsage: "<instance-id>",
-	Action:    handleInstancesReboot,
+	Name:            "reboot",
+	Usage:           "Reboot an instance",
+	ArgsUsage:       "<instance-id>",
+	Action:          handleInstancesReboot,
 	HideHelpCommand: true,
 }
 
@@ -299,7 +272,7 @@ func handleInstancesReboot(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	_, err = client.Post(ctx, "/publicCloud/v1/instances/"+args[0]+"/reboot", "")
+	err = client.API().PublicCloud.RebootInstance(ctx, args[0])
 	if err != nil {
 		return err
 	}
@@ -350,10 +323,10 @@ func handleInstancesUpdate(ctx context.Context, cmd *cli.Command) error {
 }
 
 var instancesConsoleCmd = cli.Command{
-	Name:      "console",
-	Usage:     "Get console access URL",
-	ArgsUsage: "<instance-id>",
-	Action:    handleInstancesConsole,
+	Name:            "console",
+	Usage:           "Get console access URL",
+	ArgsUsage:       "<instance-id>",
+	Action:          handleInstancesConsole,
 	HideHelpCommand: true,
 }
 
@@ -407,10 +380,10 @@ func handleInstancesCredentials(ctx context.Context, cmd *cli.Command) error {
 }
 
 var instancesIPsCmd = cli.Command{
-	Name:      "ips",
-	Usage:     "List IPs for an instance",
-	ArgsUsage: "<instance-id>",
-	Action:    handleInstancesIPs,
+	Name:            "ips",
+	Usage:           "List IPs for an instance",
+	ArgsUsage:       "<instance-id>",
+	Action:          handleInstancesIPs,
 	HideHelpCommand: true,
 }
 
@@ -431,10 +404,10 @@ func handleInstancesIPs(ctx context.Context, cmd *cli.Command) error {
 }
 
 var instancesSnapshotsListCmd = cli.Command{
-	Name:      "snapshots",
-	Usage:     "List snapshots for an instance",
-	ArgsUsage: "<instance-id>",
-	Action:    handleInstancesSnapshotsList,
+	Name:            "snapshots",
+	Usage:           "List snapshots for an instance",
+	ArgsUsage:       "<instance-id>",
+	Action:          handleInstancesSnapshotsList,
 	HideHelpCommand: true,
 }
 
@@ -455,10 +428,10 @@ func handleInstancesSnapshotsList(ctx context.Context, cmd *cli.Command) error {
 }
 
 var instancesSnapshotCreateCmd = cli.Command{
-	Name:      "snapshot-create",
-	Usage:     "Create snapshot of an instance",
-	ArgsUsage: "<instance-id>",
-	Action:    handleInstancesSnapshotCreate,
+	Name:            "snapshot-create",
+	Usage:           "Create snapshot of an instance",
+	ArgsUsage:       "<instance-id>",
+	Action:          handleInstancesSnapshotCreate,
 	HideHelpCommand: true,
 }
 
@@ -516,9 +489,9 @@ func handleInstancesMetrics(ctx context.Context, cmd *cli.Command) error {
 }
 
 var instancesRegionsCmd = cli.Command{
-	Name:   "regions",
-	Usage:  "List available regions",
-	Action: handleInstancesRegions,
+	Name:            "regions",
+	Usage:           "List available regions",
+	Action:          handleInstancesRegions,
 	HideHelpCommand: true,
 }
 
@@ -534,7 +507,7 @@ func handleInstancesRegions(ctx context.Context, cmd *cli.Command) error {
 
 	format := cmd.Root().String("output")
 	if format != "auto" {
-		return ShowResult(os.Stdout, res, format, cmd.Root().String("transform"))
+		return ShowList(os.Stdout, res, format, cmd.Root().String("transform"), []string{"name", "location"})
 	}
 
 	regions := res.Get("regions")
@@ -556,25 +529,31 @@ var instancesTypesCmd = cli.Command{
 	Name:  "types",
 	Usage: "List available instance types",
 	Flags: []cli.Flag{
-		&cli.StringFlag{Name: "region", Usage: "Region name", Required: true},
+		&cli.StringFlag{Name: "region", Usage: "Region name (default: default_region of the profile)"},
 	},
 	Action:          handleInstancesTypes,
 	HideHelpCommand: true,
 }
 
 func handleInstancesTypes(ctx context.Context, cmd *cli.Command) error {
+	region, err := resolveRegion(cmd)
+	if err != nil {
+		return err
+	}
 	client, err := NewClient(cmd)
 	if err != nil {
 		return err
 	}
-	res, err := client.Get(ctx, "/publicCloud/v1/instanceTypes?region="+cmd.String("region"))
+	res, err := client.Get(ctx, "/publicCloud/v1/instanceTypes?region="+region)
 	if err != nil {
 		return err
 	}
 
 	format := cmd.Root().String("output")
 	if format != "auto" {
-		return ShowResult(os.Stdout, res, format, cmd.Root().String("transform"))
+		return ShowList(os.Stdout, res, format, cmd.Root().String("transform"), []string{
+			"name", "resources.cpu.value", "resources.memory.value", "prices.hourly", "prices.monthly",
+		})
 	}
 
 	types := res.Get("instanceTypes")
@@ -655,7 +634,7 @@ var instancesCredentialDeleteAllCmd = cli.Command{
 	Name:            "credential-delete-all",
 	Usage:           "Delete all credentials for an instance",
 	ArgsUsage:       "<instance-id>",
-	Action:          handleInstancesCredentialDeleteAll,
+	Action:          confirm(destructive{"delete all credentials of instance %s", "/publicCloud/v1/instances/%s", instanceDetails}, handleInstancesCredentialDeleteAll),
 	HideHelpCommand: true,
 }
 
@@ -736,7 +715,7 @@ var instancesCredentialDeleteCmd = cli.Command{
 	Name:            "credential-delete",
 	Usage:           "Delete a credential for a given type and username",
 	ArgsUsage:       "<instance-id> <type> <username>",
-	Action:          handleInstancesCredentialDelete,
+	Action:          confirm(destructive{"delete the %[2]s credential %[3]s of instance %[1]s", "/publicCloud/v1/instances/%s", instanceDetails}, handleInstancesCredentialDelete),
 	HideHelpCommand: true,
 }
 
@@ -813,7 +792,7 @@ var instancesIPNullCmd = cli.Command{
 	Name:            "ip-null",
 	Usage:           "Null route an IP for an instance",
 	ArgsUsage:       "<instance-id> <ip>",
-	Action:          handleInstancesIPNull,
+	Action:          confirm(destructive{"null route IP %[2]s of instance %[1]s", "/publicCloud/v1/instances/%s", instanceDetails}, handleInstancesIPNull),
 	HideHelpCommand: true,
 }
 
@@ -912,7 +891,7 @@ var instancesSnapshotDeleteCmd = cli.Command{
 	Name:            "snapshot-delete",
 	Usage:           "Delete an instance snapshot",
 	ArgsUsage:       "<instance-id> <snapshot-id>",
-	Action:          handleInstancesSnapshotDelete,
+	Action:          confirm(destructive{"delete snapshot %[2]s of instance %[1]s", "/publicCloud/v1/instances/%s", instanceDetails}, handleInstancesSnapshotDelete),
 	HideHelpCommand: true,
 }
 
@@ -940,7 +919,7 @@ var instancesReinstallCmd = cli.Command{
 	Flags: []cli.Flag{
 		&cli.StringFlag{Name: "image", Usage: "Image ID for reinstall", Required: true},
 	},
-	Action:          handleInstancesReinstall,
+	Action:          confirm(destructive{"reinstall instance %s, erasing its disk", "/publicCloud/v1/instances/%s", instanceDetails}, handleInstancesReinstall),
 	HideHelpCommand: true,
 }
 
@@ -1059,12 +1038,10 @@ func handleInstancesSecurityGroups(ctx context.Context, cmd *cli.Command) error
 }
 
 var instancesAttachSecurityGroupsCmd = cli.Command{
-	Name:      "attach-security-groups",
-	Usage:     "Attach security groups to instance",
-	ArgsUsage: "<instance-id>",
-	Flags: []cli.Flag{
-		&cli.StringFlag{Name: "payload", Usage: "JSON payload with security group IDs", Required: true},
-	},
+	Name:            "attach-security-groups",
+	Usage:           "Attach security groups to instance",
+	ArgsUsage:       "<instance-id>",
+	Flags:           payloadFlags("JSON payload with security group IDs"),
 	Action:          handleInstancesAttachSecurityGroups,
 	HideHelpCommand: true,
 }
@@ -1078,7 +1055,11 @@ func handleInstancesAttachSecurityGroups(ctx context.Context, cmd *cli.Command)
 	if err != nil {
 		return err
 	}
-	res, err := client.PostJSON(ctx, fmt.Sprintf("/publicCloud/v1/instances/%s/attachSecurityGroups", args[0]), []byte(cmd.String("payload")))
+	body, err := loadPayload(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := client.PostJSON(ctx, fmt.Sprintf("/publicCloud/v1/instances/%s/attachSecurityGroups", args[0]), body)
 	if err != nil {
 		return err
 	}
@@ -1087,12 +1068,10 @@ func handleInstancesAttachSecurityGroups(ctx context.Context, cmd *cli.Command)
 }
 
 var instancesDetachSecurityGroupsCmd = cli.Command{
-	Name:      "detach-security-groups",
-	Usage:     "Detach security groups from instance",
-	ArgsUsage: "<instance-id>",
-	Flags: []cli.Flag{
-		&cli.StringFlag{Name: "payload", Usage: "JSON payload with security group IDs", Required: true},
-	},
+	Name:            "detach-security-groups",
+	Usage:           "Detach security groups from instance",
+	ArgsUsage:       "<instance-id>",
+	Flags:           payloadFlags("JSON payload with security group IDs"),
 	Action:          handleInstancesDetachSecurityGroups,
 	HideHelpCommand: true,
 }
@@ -1106,7 +1085,11 @@ func handleInstancesDetachSecurityGroups(ctx context.Context, cmd *cli.Command)
 	if err != nil {
 		return err
 	}
-	res, err := client.PostJSON(ctx, fmt.Sprintf("/publicCloud/v1/instances/%s/detachSecurityGroups", args[0]), []byte(cmd.String("payload")))
+	body, err := loadPayload(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := client.PostJSON(ctx, fmt.Sprintf("/publicCloud/v1/instances/%s/detachSecurityGroups", args[0]), body)
 	if err != nil {
 		return err
 	}
@@ -1147,7 +1130,7 @@ var instancesRemoveFromPrivateNetworkCmd = cli.Command{
 	Name:            "remove-from-private-network",
 	Usage:           "Remove instance from private network",
 	ArgsUsage:       "<instance-id>",
-	Action:          handleInstancesRemoveFromPrivateNetwork,
+	Action:          confirm(destructive{"remove instance %s from its private network", "/publicCloud/v1/instances/%s", instanceDetails}, handleInstancesRemoveFromPrivateNetwork),
 	HideHelpCommand: true,
 }
 
@@ -1344,12 +1327,10 @@ func handleInstancesNotifDatatrafficGet(ctx context.Context, cmd *cli.Command) e
 }
 
 var instancesNotifDatatrafficCreateCmd = cli.Command{
-	Name:      "notif-datatraffic-create",
-	Usage:     "Create a data traffic notification setting",
-	ArgsUsage: "<instance-id> <notification-id>",
-	Flags: []cli.Flag{
-		&cli.StringFlag{Name: "payload", Usage: "JSON payload", Required: true},
-	},
+	Name:            "notif-datatraffic-create",
+	Usage:           "Create a data traffic notification setting",
+	ArgsUsage:       "<instance-id> <notification-id>",
+	Flags:           payloadFlags("JSON payload"),
 	Action:          handleInstancesNotifDatatrafficCreate,
 	HideHelpCommand: true,
 }
@@ -1363,7 +1344,11 @@ func handleInstancesNotifDatatrafficCreate(ctx context.Context, cmd *cli.Command
 	if err != nil {
 		return err
 	}
-	res, err := client.PostJSON(ctx, fmt.Sprintf("/publicCloud/v1/instances/%s/notificationSettings/dataTraffic/%s", args[0], args[1]), []byte(cmd.String("payload")))
+	body, err := loadPayload(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := client.PostJSON(ctx, fmt.Sprintf("/publicCloud/v1/instances/%s/notificationSettings/dataTraffic/%s", args[0], args[1]), body)
 	if err != nil {
 		return err
 	}
@@ -1371,12 +1356,10 @@ func handleInstancesNotifDatatrafficCreate(ctx context.Context, cmd *cli.Command
 }
 
 var instancesNotifDatatrafficUpdateCmd = cli.Command{
-	Name:      "notif-datatraffic-update",
-	Usage:     "Update a data traffic notification setting",
-	ArgsUsage: "<instance-id> <notification-id>",
-	Flags: []cli.Flag{
-		&cli.StringFlag{Name: "payload", Usage: "JSON payload", Required: true},
-	},
+	Name:            "notif-datatraffic-update",
+	Usage:           "Update a data traffic notification setting",
+	ArgsUsage:       "<instance-id> <notification-id>",
+	Flags:           payloadFlags("JSON payload"),
 	Action:          handleInstancesNotifDatatrafficUpdate,
 	HideHelpCommand: true,
 }
@@ -1390,7 +1373,11 @@ func handleInstancesNotifDatatrafficUpdate(ctx context.Context, cmd *cli.Command
 	if err != nil {
 		return err
 	}
-	res, err := client.PutJSON(ctx, fmt.Sprintf("/publicCloud/v1/instances/%s/notificationSettings/dataTraffic/%s", args[0], args[1]), []byte(cmd.String("payload")))
+	body, err := loadPayload(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := client.PutJSON(ctx, fmt.Sprintf("/publicCloud/v1/instances/%s/notificationSettings/dataTraffic/%s", args[0], args[1]), body)
 	if err != nil {
 		return err
 	}
@@ -1401,7 +1388,7 @@ var instancesNotifDatatrafficDeleteCmd = cli.Command{
 	Name:            "notif-datatraffic-delete",
 	Usage:           "Delete a data traffic notification setting",
 	ArgsUsage:       "<instance-id> <notification-id>",
-	Action:          handleInstancesNotifDatatrafficDelete,
+	Action:          confirm(destructive{"delete data traffic notification setting %[2]s of instance %[1]s", "/publicCloud/v1/instances/%s", instanceDetails}, handleInstancesNotifDatatrafficDelete),
 	HideHelpCommand: true,
 }
 
@@ -1447,11 +1434,9 @@ func handleInstancesTypesUpdate(ctx context.Context, cmd *cli.Command) error {
 }
 
 var instancesImageCreateCmd = cli.Command{
-	Name:  "image-create",
-	Usage: "Create a custom image",
-	Flags: []cli.Flag{
-		&cli.StringFlag{Name: "payload", Usage: "JSON payload for image creation", Required: true},
-	},
+	Name:            "image-create",
+	Usage:           "Create a custom image",
+	Flags:           payloadFlags("JSON payload for image creation"),
 	Action:          handleInstancesImageCreate,
 	HideHelpCommand: true,
 }
@@ -1461,7 +1446,11 @@ func handleInstancesImageCreate(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	res, err := client.PostJSON(ctx, "/publicCloud/v1/images", []byte(cmd.String("payload")))
+	body, err := loadPayload(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := client.PostJSON(ctx, "/publicCloud/v1/images", body)
 	if err != nil {
 		return err
 	}
@@ -1469,12 +1458,10 @@ func handleInstancesImageCreate(ctx context.Context, cmd *cli.Command) error {
 }
 
 var instancesImageUpdateCmd = cli.Command{
-	Name:      "image-update",
-	Usage:     "Update a custom image",
-	ArgsUsage: "<image-id>",
-	Flags: []cli.Flag{
-		&cli.StringFlag{Name: "payload", Usage: "JSON payload for image update", Required: true},
-	},
+	Name:            "image-update",
+	Usage:           "Update a custom image",
+	ArgsUsage:       "<image-id>",
+	Flags:           payloadFlags("JSON payload for image update"),
 	Action:          handleInstancesImageUpdate,
 	HideHelpCommand: true,
 }
@@ -1488,7 +1475,11 @@ func handleInstancesImageUpdate(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	res, err := client.PutJSON(ctx, "/publicCloud/v1/images/"+args[0], []byte(cmd.String("payload")))
+	body, err := loadPayload(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := client.PutJSON(ctx, "/publicCloud/v1/images/"+args[0], body)
 	if err != nil {
 		return err
 	}
@@ -1515,10 +1506,10 @@ func handleInstancesISOs(ctx context.Context, cmd *cli.Command) error {
 }
 
 var instancesExpensesCmd = cli.Command{
-	Name:      "expenses",
-	Usage:     "Get costs for an equipment",
-	ArgsUsage: "<equipment-id>",
-	Action:    handleInstancesExpenses,
+	Name:            "expenses",
+	Usage:           "Get costs for an equipment",
+	ArgsUsage:       "<equipment-id>",
+	Action:          handleInstancesExpenses,
 	HideHelpCommand: true,
 }
 
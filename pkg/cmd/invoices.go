@@ -7,6 +7,8 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/kernel/leaseweb-cli/pkg/leaseweb"
+	"github.com/tidwall/gjson"
 	"github.com/urfave/cli/v3"
 )
 
@@ -24,52 +26,38 @@ var invoicesCmd = cli.Command{
 }
 
 var invoicesListCmd = cli.Command{
-	Name:  "list",
-	Usage: "List invoices",
-	Flags: PaginationFlags,
+	Name:            "list",
+	Usage:           "List invoices",
+	Flags:           PaginationFlags,
 	Action:          handleInvoicesList,
 	HideHelpCommand: true,
 }
 
 func handleInvoicesList(ctx context.Context, cmd *cli.Command) error {
-	client, err := NewClient(cmd)
-	if err != nil {
-		return err
-	}
-	res, err := client.Get(ctx, "/invoices/v1/invoices?"+PaginationQuery(cmd))
-	if err != nil {
-		return err
-	}
-
-	format := cmd.Root().String("output")
-	if format != "auto" {
-		return ShowResult(os.Stdout, res, format, cmd.Root().String("transform"))
-	}
-
-	invoices := res.Get("invoices")
-	if !invoices.Exists() || len(invoices.Array()) == 0 {
-		fmt.Fprintln(os.Stderr, "No invoices found.")
-		return nil
-	}
-
-	items := invoices.Array()
-	sort.Slice(items, func(i, j int) bool {
-		return items[i].Get("date").String() > items[j].Get("date").String()
+	return runList(ctx, cmd, func(ctx context.Context, client *Client) ([]leaseweb.Invoice, gjson.Result, error) {
+		return ListPages(ctx, cmd, func(opts leaseweb.ListOptions) (*leaseweb.Page[leaseweb.Invoice], error) {
+			return client.API().Invoices.ListInvoices(ctx, &opts)
+		})
+	}, listView[leaseweb.Invoice]{
+		empty:   "No invoices found.",
+		headers: []string{"ID", "DATE", "STATUS", "TOTAL", "CURRENCY", "DUE DATE"},
+		columns: []string{"id", "date", "status", "total", "currency", "dueDate"},
+		row: func(inv leaseweb.Invoice) []string {
+			return []string{
+				inv.ID,
+				dateOnly(inv.Date),
+				inv.Status,
+				fmt.Sprintf("%.2f", inv.Total),
+				inv.Currency,
+				dateOnly(inv.DueDate),
+			}
+		},
+		sort: func(invoices []leaseweb.Invoice) {
+			sort.Slice(invoices, func(i, j int) bool {
+				return invoices[i].Date > invoices[j].Date
+			})
+		},
 	})
-
-	table := NewTableWriter(os.Stdout, "ID", "DATE", "STATUS", "TOTAL", "CURRENCY", "DUE DATE")
-	for _, inv := range items {
-		table.AddRow(
-			inv.Get("id").String(),
-			dateOnly(inv.Get("date").String()),
-			inv.Get("status").String(),
-			fmt.Sprintf("%.2f", inv.Get("total").Float()),
-			inv.Get("currency").String(),
-			dateOnly(inv.Get("dueDate").String()),
-		)
-	}
-	table.Render()
-	return nil
 }
 
 func dateOnly(s string) string {
@@ -80,10 +68,10 @@ func dateOnly(s string) string {
 }
 
 var invoicesGetCmd = cli.Command{
-	Name:      "get",
-	Usage:     "Get invoice details",
-	ArgsUsage: "<invoice-id>",
-	Action:    handleInvoicesGet,
+	Name:            "get",
+	Usage:           "Get invoice details",
+	ArgsUsage:       "<invoice-id>",
+	Action:          handleInvoicesGet,
 	HideHelpCommand: true,
 }
 
@@ -128,7 +116,7 @@ func handleInvoicesPDF(ctx context.Context, cmd *cli.Command) error {
 		return err
 	}
 
-	data, _, err := client.DoRaw(ctx, "GET", "/invoices/v1/invoices/"+args[0]+"/pdf")
+	data, err := client.API().Invoices.DownloadPDF(ctx, args[0])
 	if err != nil {
 		return err
 	}
@@ -164,7 +152,7 @@ func handleInvoicesExportCSV(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	data, _, err := client.DoRaw(ctx, "GET", "/invoices/v1/invoices/export/csv")
+	data, err := client.API().Invoices.ExportCSV(ctx)
 	if err != nil {
 		return err
 	}
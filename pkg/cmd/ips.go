@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/kernel/leaseweb-cli/pkg/leaseweb"
 	"github.com/tidwall/gjson"
 	"github.com/urfave/cli/v3"
 )
@@ -42,60 +43,38 @@ var ipsListCmd = cli.Command{
 }
 
 func handleIPsList(ctx context.Context, cmd *cli.Command) error {
-	client, err := NewClient(cmd)
-	if err != nil {
-		return err
-	}
-
-	q := PaginationQuery(cmd)
-	if v := cmd.String("version"); v != "" {
-		q += "&version=" + v
-	}
-	if t := cmd.String("type"); t != "" {
-		q += "&type=" + t
-	}
-	if nr := cmd.String("null-routed"); nr != "" {
-		q += "&nullRouted=" + nr
-	}
-
-	res, err := client.Get(ctx, "/ipMgmt/v2/ips?"+q)
-	if err != nil {
-		return err
-	}
-
-	format := cmd.Root().String("output")
-	if format != "auto" {
-		return ShowResult(os.Stdout, res, format, cmd.Root().String("transform"))
-	}
-
-	ips := res.Get("ips")
-	if !ips.Exists() || len(ips.Array()) == 0 {
-		fmt.Fprintln(os.Stderr, "No IPs found.")
-		return nil
-	}
-
-	table := NewTableWriter(os.Stdout, "IP", "VERSION", "TYPE", "REVERSE LOOKUP", "NULL ROUTED", "EQUIPMENT")
-	table.TruncOrder = []int{3, 5}
-	ips.ForEach(func(_, ip gjson.Result) bool {
-		table.AddRow(
-			ip.Get("ip").String(),
-			fmt.Sprintf("v%d", ip.Get("version").Int()),
-			ip.Get("type").String(),
-			ip.Get("reverseLookup").String(),
-			fmt.Sprintf("%t", ip.Get("nullRouted").Bool()),
-			ip.Get("equipmentId").String(),
-		)
-		return true
+	return runList(ctx, cmd, func(ctx context.Context, client *Client) ([]leaseweb.IP, gjson.Result, error) {
+		return ListPages(ctx, cmd, func(opts leaseweb.ListOptions) (*leaseweb.Page[leaseweb.IP], error) {
+			return client.API().IPMgmt.ListIPs(ctx, &leaseweb.ListIPsOptions{
+				ListOptions: opts,
+				Version:     cmd.String("version"),
+				Type:        cmd.String("type"),
+				NullRouted:  cmd.String("null-routed"),
+			})
+		})
+	}, listView[leaseweb.IP]{
+		empty:      "No IPs found.",
+		headers:    []string{"IP", "VERSION", "TYPE", "REVERSE LOOKUP", "NULL ROUTED", "EQUIPMENT"},
+		truncOrder: []int{3, 5},
+		columns:    []string{"ip", "version", "type", "reverseLookup", "nullRouted", "equipmentId"},
+		row: func(ip leaseweb.IP) []string {
+			return []string{
+				ip.IP,
+				fmt.Sprintf("v%d", ip.Version),
+				ip.Type,
+				ip.ReverseLookup,
+				fmt.Sprintf("%t", ip.NullRouted),
+				ip.EquipmentID,
+			}
+		},
 	})
-	table.Render()
-	return nil
 }
 
 var ipsGetCmd = cli.Command{
-	Name:      "get",
-	Usage:     "Get IP details",
-	ArgsUsage: "<ip>",
-	Action:    handleIPsGet,
+	Name:            "get",
+	Usage:           "Get IP details",
+	ArgsUsage:       "<ip>",
+	Action:          handleIPsGet,
 	HideHelpCommand: true,
 }
 
@@ -152,7 +131,7 @@ var ipsNullRouteCmd = cli.Command{
 	Flags: []cli.Flag{
 		&cli.StringFlag{Name: "comment", Usage: "Comment for the null route"},
 	},
-	Action:          handleIPsNullRoute,
+	Action:          confirm(destructive{"null route IP %s", "/ipMgmt/v2/ips/%s", ipDetails}, handleIPsNullRoute),
 	HideHelpCommand: true,
 }
 
@@ -183,10 +162,10 @@ func handleIPsNullRoute(ctx context.Context, cmd *cli.Command) error {
 }
 
 var ipsRemoveNullRouteCmd = cli.Command{
-	Name:      "remove-null-route",
-	Usage:     "Remove null route from an IP",
-	ArgsUsage: "<ip>",
-	Action:    handleIPsRemoveNullRoute,
+	Name:            "remove-null-route",
+	Usage:           "Remove null route from an IP",
+	ArgsUsage:       "<ip>",
+	Action:          handleIPsRemoveNullRoute,
 	HideHelpCommand: true,
 }
 
@@ -208,9 +187,9 @@ func handleIPsRemoveNullRoute(ctx context.Context, cmd *cli.Command) error {
 }
 
 var ipsNullRouteHistoryCmd = cli.Command{
-	Name:  "null-route-history",
-	Usage: "List null route history",
-	Flags: PaginationFlags,
+	Name:            "null-route-history",
+	Usage:           "List null route history",
+	Flags:           PaginationFlags,
 	Action:          handleIPsNullRouteHistory,
 	HideHelpCommand: true,
 }
@@ -220,7 +199,7 @@ func handleIPsNullRouteHistory(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	res, err := client.Get(ctx, "/ipMgmt/v2/nullRoutes?"+PaginationQuery(cmd))
+	res, err := GetList(ctx, client, cmd, "/ipMgmt/v2/nullRoutes?"+PaginationQuery(cmd))
 	if err != nil {
 		return err
 	}
@@ -304,10 +283,10 @@ func handleIPsNullRoutedIPv6(ctx context.Context, cmd *cli.Command) error {
 }
 
 var ipsReverseLookupCmd = cli.Command{
-	Name:      "reverse-lookup",
-	Usage:     "List reverse lookup records for an IPv6 range",
-	ArgsUsage: "<ip>",
-	Action:    handleIPsReverseLookup,
+	Name:            "reverse-lookup",
+	Usage:           "List reverse lookup records for an IPv6 range",
+	ArgsUsage:       "<ip>",
+	Action:          handleIPsReverseLookup,
 	HideHelpCommand: true,
 }
 
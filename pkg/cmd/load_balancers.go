@@ -53,14 +53,16 @@ func handleLBList(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	res, err := client.Get(ctx, "/publicCloud/v1/loadBalancers?"+PaginationQuery(cmd))
+	res, err := GetList(ctx, client, cmd, "/publicCloud/v1/loadBalancers?"+PaginationQuery(cmd))
 	if err != nil {
 		return err
 	}
 
 	format := cmd.Root().String("output")
 	if format != "auto" {
-		return ShowResult(os.Stdout, res, format, cmd.Root().String("transform"))
+		return ShowList(os.Stdout, res, format, cmd.Root().String("transform"), []string{
+			"id", "reference", "type", "region", "state", "ips.#(version==4).ip",
+		})
 	}
 
 	lbs := res.Get("loadBalancers")
@@ -122,7 +124,7 @@ var lbCreateCmd = cli.Command{
 	Name:  "create",
 	Usage: "Create a load balancer",
 	Flags: []cli.Flag{
-		&cli.StringFlag{Name: "region", Usage: "Region", Required: true},
+		&cli.StringFlag{Name: "region", Usage: "Region (default: default_region of the profile)"},
 		&cli.StringFlag{Name: "type", Usage: "Load balancer type", Required: true},
 		&cli.StringFlag{Name: "contract-type", Usage: "HOURLY or MONTHLY", Required: true},
 		&cli.StringFlag{Name: "reference", Usage: "Reference name"},
@@ -132,12 +134,16 @@ var lbCreateCmd = cli.Command{
 }
 
 func handleLBCreate(ctx context.Context, cmd *cli.Command) error {
+	region, err := resolveRegion(cmd)
+	if err != nil {
+		return err
+	}
 	client, err := NewClient(cmd)
 	if err != nil {
 		return err
 	}
 	payload := map[string]any{
-		"region":       cmd.String("region"),
+		"region":       region,
 		"type":         cmd.String("type"),
 		"contractType": cmd.String("contract-type"),
 	}
@@ -192,7 +198,7 @@ var lbDeleteCmd = cli.Command{
 	Name:            "delete",
 	Usage:           "Delete a load balancer",
 	ArgsUsage:       "<lb-id>",
-	Action:          handleLBDelete,
+	Action:          confirm(destructive{"delete load balancer %s", "/publicCloud/v1/loadBalancers/%s", lbDetails}, handleLBDelete),
 	HideHelpCommand: true,
 }
 
@@ -313,12 +319,10 @@ func handleLBReboot(ctx context.Context, cmd *cli.Command) error {
 }
 
 var lbListenerCreateCmd = cli.Command{
-	Name:      "listener-create",
-	Usage:     "Create a listener",
-	ArgsUsage: "<lb-id>",
-	Flags: []cli.Flag{
-		&cli.StringFlag{Name: "payload", Usage: "JSON payload for the listener", Required: true},
-	},
+	Name:            "listener-create",
+	Usage:           "Create a listener",
+	ArgsUsage:       "<lb-id>",
+	Flags:           payloadFlags("JSON payload for the listener"),
 	Action:          handleLBListenerCreate,
 	HideHelpCommand: true,
 }
@@ -332,7 +336,11 @@ func handleLBListenerCreate(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	res, err := client.PostJSON(ctx, "/publicCloud/v1/loadBalancers/"+args[0]+"/listeners", []byte(cmd.String("payload")))
+	body, err := loadPayload(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := client.PostJSON(ctx, "/publicCloud/v1/loadBalancers/"+args[0]+"/listeners", body)
 	if err != nil {
 		return err
 	}
@@ -364,12 +372,10 @@ func handleLBListenerGet(ctx context.Context, cmd *cli.Command) error {
 }
 
 var lbListenerUpdateCmd = cli.Command{
-	Name:      "listener-update",
-	Usage:     "Update a listener",
-	ArgsUsage: "<lb-id> <listener-id>",
-	Flags: []cli.Flag{
-		&cli.StringFlag{Name: "payload", Usage: "JSON payload for the listener", Required: true},
-	},
+	Name:            "listener-update",
+	Usage:           "Update a listener",
+	ArgsUsage:       "<lb-id> <listener-id>",
+	Flags:           payloadFlags("JSON payload for the listener"),
 	Action:          handleLBListenerUpdate,
 	HideHelpCommand: true,
 }
@@ -383,7 +389,11 @@ func handleLBListenerUpdate(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	res, err := client.PutJSON(ctx, fmt.Sprintf("/publicCloud/v1/loadBalancers/%s/listeners/%s", args[0], args[1]), []byte(cmd.String("payload")))
+	body, err := loadPayload(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := client.PutJSON(ctx, fmt.Sprintf("/publicCloud/v1/loadBalancers/%s/listeners/%s", args[0], args[1]), body)
 	if err != nil {
 		return err
 	}
@@ -394,7 +404,7 @@ var lbListenerDeleteCmd = cli.Command{
 	Name:            "listener-delete",
 	Usage:           "Delete a listener",
 	ArgsUsage:       "<lb-id> <listener-id>",
-	Action:          handleLBListenerDelete,
+	Action:          confirm(destructive{"delete listener %[2]s of load balancer %[1]s", "/publicCloud/v1/loadBalancers/%s", lbDetails}, handleLBListenerDelete),
 	HideHelpCommand: true,
 }
 
@@ -495,7 +505,7 @@ var lbIPNullCmd = cli.Command{
 	Name:            "ip-null",
 	Usage:           "Null route an IP on a load balancer",
 	ArgsUsage:       "<lb-id> <ip>",
-	Action:          handleLBIPNull,
+	Action:          confirm(destructive{"null route IP %[2]s of load balancer %[1]s", "/publicCloud/v1/loadBalancers/%s", lbDetails}, handleLBIPNull),
 	HideHelpCommand: true,
 }
 
@@ -0,0 +1,511 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/urfave/cli/v3"
+)
+
+var mockServerCmd = cli.Command{
+	Name:  "mock-server",
+	Usage: "Serve a local mock of the Leaseweb API",
+	Description: `Serves every operation of the Leaseweb OpenAPI spec from its examples.
+Objects created, changed or deleted through the mock are kept in memory for
+as long as it runs, so a DNS record added with POST shows up in the next
+list and a terminated instance answers 404 afterwards.
+
+Any non-empty API key is accepted. Point lw, or anything else, at the mock
+with LEASEWEB_BASE_URL:
+
+  lw mock-server --addr 127.0.0.1:8080 &
+  export LEASEWEB_BASE_URL=http://127.0.0.1:8080 LEASEWEB_API_KEY=mock
+  lw ds list`,
+	Flags: []cli.Flag{
+		&cli.StringFlag{Name: "addr", Usage: "Address to listen on", Value: "127.0.0.1:8080"},
+	},
+	Action:          handleMockServer,
+	HideHelpCommand: true,
+}
+
+func handleMockServer(ctx context.Context, cmd *cli.Command) error {
+	ln, err := net.Listen("tcp", cmd.String("addr"))
+	if err != nil {
+		return err
+	}
+	srv := &http.Server{Handler: NewMockServer()}
+	go func() {
+		<-ctx.Done()
+		srv.Close()
+	}()
+	fmt.Fprintf(os.Stderr, "Mock Leaseweb API listening on http://%s\n", ln.Addr())
+	fmt.Fprintf(os.Stderr, "export LEASEWEB_BASE_URL=http://%s LEASEWEB_API_KEY=mock\n", ln.Addr())
+	if err := srv.Serve(ln); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// mockServer answers requests from the examples in the spec. Collections
+// are loaded from their list example the first time they are touched; after
+// that the items in memory are the source of truth.
+type mockServer struct {
+	// ops indexes the spec operations by "METHOD /template".
+	ops map[string]*specOperation
+
+	mu      sync.Mutex
+	items   map[string]*mockItem
+	deleted map[string]bool
+	seeded  map[string]bool
+	seq     int
+}
+
+// mockItem is an object of a collection, stored under its concrete path.
+type mockItem struct {
+	seq      int
+	template string
+	value    map[string]any
+}
+
+// NewMockServer returns a handler serving a stateful mock of the Leaseweb
+// API, for use with httptest or by lw mock-server.
+func NewMockServer() http.Handler {
+	m := &mockServer{
+		ops:     map[string]*specOperation{},
+		items:   map[string]*mockItem{},
+		deleted: map[string]bool{},
+		seeded:  map[string]bool{},
+	}
+	for _, op := range specOperations {
+		m.ops[op.Method+" "+op.Path] = op
+	}
+	return m
+}
+
+func (m *mockServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("X-LSW-Auth") == "" {
+		mockError(w, http.StatusUnauthorized, "You are not authorized to view this resource.", nil)
+		return
+	}
+	op := operationForRequest(r.Method, r.URL.Path)
+	if op == nil {
+		mockError(w, http.StatusNotFound, fmt.Sprintf("No operation serves %s %s.", r.Method, r.URL.Path), nil)
+		return
+	}
+
+	var body map[string]any
+	if raw, _ := readBody(&r.Body); len(bytes.TrimSpace(raw)) > 0 {
+		if err := validateBody(op, raw); err != nil {
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				mockError(w, http.StatusBadRequest, err.Error(), nil)
+				return
+			}
+			details := map[string][]string{}
+			for _, p := range verr.Problems {
+				details[p.Pointer] = append(details[p.Pointer], p.Message)
+			}
+			mockError(w, http.StatusBadRequest, "The supplied data is invalid.", details)
+			return
+		}
+		if v, err := decodeJSON(raw); err == nil {
+			body, _ = v.(map[string]any)
+		}
+	}
+
+	m.mu.Lock()
+	status, res := m.handle(op, r, body)
+	m.mu.Unlock()
+
+	if status == http.StatusNotFound {
+		mockError(w, status, fmt.Sprintf("Resource %s not found.", r.URL.Path), nil)
+		return
+	}
+	if res == nil || status == http.StatusNoContent {
+		w.WriteHeader(status)
+		return
+	}
+	b, _ := json.Marshal(res)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(b)
+}
+
+func (m *mockServer) handle(op *specOperation, r *http.Request, body map[string]any) (int, any) {
+	path := strings.TrimRight(r.URL.Path, "/")
+	if m.isDeleted(path) {
+		return http.StatusNotFound, nil
+	}
+	list, key := m.listOperation(op.Path)
+	m.seedParents(op.Path, path)
+
+	switch {
+	case list != nil && r.Method == http.MethodGet:
+		m.seed(list, path)
+		return op.Status, m.listResponse(list, key, path, r)
+	case list != nil && r.Method == http.MethodPost:
+		m.seed(list, path)
+		v := m.create(op, list, path, body)
+		return op.Status, v
+	case list != nil && r.Method == http.MethodDelete:
+		m.seed(list, path)
+		m.removeUnder(path)
+		return op.Status, exampleValueOf(op, nil)
+	case list != nil && (r.Method == http.MethodPut || r.Method == http.MethodPatch):
+		items, ok := body[key].([]any)
+		if !ok {
+			return op.Status, exampleValueOf(op, nil)
+		}
+		m.seed(list, path)
+		m.removeUnder(path)
+		for _, item := range items {
+			if obj, ok := item.(map[string]any); ok {
+				m.create(op, list, path, obj)
+			}
+		}
+		return op.Status, body
+	case r.Method == http.MethodDelete:
+		m.removeUnder(path)
+		delete(m.items, path)
+		m.deleted[path] = true
+		return op.Status, exampleValueOf(op, nil)
+	case r.Method == http.MethodPut || r.Method == http.MethodPatch:
+		it := m.items[path]
+		if it == nil {
+			m.seq++
+			it = &mockItem{seq: m.seq, template: op.Path, value: map[string]any{}}
+			m.items[path] = it
+		}
+		it.value = mergeJSON(it.value, body).(map[string]any)
+		if op.Response == "" {
+			return op.Status, nil
+		}
+		return op.Status, m.itemView(op.Path, path)
+	case r.Method == http.MethodGet:
+		return op.Status, m.itemView(op.Path, path)
+	}
+	return op.Status, exampleValueOf(op, pathValues(op.Path, path))
+}
+
+// listOperation returns the GET operation of template and the property of
+// its example holding the items, when template is a collection: its example
+// is an array, or an object with an array of objects and either paging
+// metadata or an item route below it.
+func (m *mockServer) listOperation(template string) (*specOperation, string) {
+	get := m.ops["GET "+template]
+	if get == nil || get.Response == "" {
+		return nil, ""
+	}
+	v, err := decodeJSON([]byte(get.Response))
+	if err != nil {
+		return nil, ""
+	}
+	switch v := v.(type) {
+	case []any:
+		return get, ""
+	case map[string]any:
+		if _, ok := v["_metadata"]; !ok && m.itemTemplate(template) == "" {
+			return nil, ""
+		}
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		// Prefer the property named like the collection, e.g. "servers".
+		last := template[strings.LastIndexByte(template, '/')+1:]
+		if i := slices.Index(keys, last); i > 0 {
+			keys[0], keys[i] = keys[i], keys[0]
+		}
+		for _, k := range keys {
+			if list, ok := v[k].([]any); ok && (len(list) == 0 || jsonType(list[0]) == "object") {
+				return get, k
+			}
+		}
+	}
+	return nil, ""
+}
+
+// itemTemplate returns the template of the items of a collection: the
+// longest one extending it with parameters only.
+func (m *mockServer) itemTemplate(collection string) string {
+	best, bestLen := "", 0
+	for _, op := range specOperations {
+		rest, ok := strings.CutPrefix(op.Path, collection+"/")
+		if !ok {
+			continue
+		}
+		segments := strings.Split(rest, "/")
+		if allParams(segments) && len(segments) > bestLen {
+			best, bestLen = op.Path, len(segments)
+		}
+	}
+	return best
+}
+
+// seed loads the items of a collection from its list example once.
+func (m *mockServer) seed(list *specOperation, path string) {
+	if m.seeded[path] {
+		return
+	}
+	m.seeded[path] = true
+	_, key := m.listOperation(list.Path)
+	example, _ := decodeJSON([]byte(list.Response))
+	items, _ := example.([]any)
+	if obj, ok := example.(map[string]any); ok {
+		items, _ = obj[key].([]any)
+	}
+	template := m.itemTemplate(list.Path)
+	for i, item := range items {
+		obj, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		itemPath := path + "/" + strings.Join(itemKey(template, list.Path, obj, strconv.Itoa(i)), "/")
+		if m.deleted[itemPath] || m.items[itemPath] != nil {
+			continue
+		}
+		m.seq++
+		m.items[itemPath] = &mockItem{seq: m.seq, template: template, value: obj}
+	}
+}
+
+// seedParents seeds every collection path lies in, so changing an item of
+// the list example keeps the rest of the list.
+func (m *mockServer) seedParents(template, path string) {
+	tsegs := strings.Split(strings.Trim(template, "/"), "/")
+	psegs := strings.Split(strings.Trim(path, "/"), "/")
+	for k := 1; k < len(tsegs) && strings.HasPrefix(tsegs[len(tsegs)-k], "{"); k++ {
+		parent := "/" + strings.Join(tsegs[:len(tsegs)-k], "/")
+		if list, _ := m.listOperation(parent); list != nil {
+			m.seed(list, "/"+strings.Join(psegs[:len(psegs)-k], "/"))
+		}
+	}
+}
+
+// create stores a new item of the collection at path.
+func (m *mockServer) create(op, list *specOperation, path string, body map[string]any) map[string]any {
+	template := m.itemTemplate(list.Path)
+	base, _ := exampleValueOf(op, nil).(map[string]any)
+	if base == nil {
+		base = map[string]any{}
+	}
+	value := mergeJSON(base, body).(map[string]any)
+
+	m.seq++
+	id := mockID(base["id"], m.seq)
+	key := itemKey(template, list.Path, body, id)
+	if _, ok := body["id"]; !ok && template != "" && len(key) == 1 && key[0] == id {
+		value["id"] = id
+	}
+	itemPath := path + "/" + strings.Join(key, "/")
+	delete(m.deleted, itemPath)
+	m.items[itemPath] = &mockItem{seq: m.seq, template: template, value: value}
+	return value
+}
+
+// removeUnder deletes every item below path.
+func (m *mockServer) removeUnder(path string) {
+	for p := range m.items {
+		if strings.HasPrefix(p, path+"/") {
+			delete(m.items, p)
+			m.deleted[p] = true
+		}
+	}
+}
+
+func (m *mockServer) isDeleted(path string) bool {
+	for p := path; p != ""; p = p[:strings.LastIndexByte(p, '/')] {
+		if m.deleted[p] {
+			return true
+		}
+	}
+	return false
+}
+
+// listResponse renders the list example with the items in memory, paged
+// by the limit and offset query parameters.
+func (m *mockServer) listResponse(list *specOperation, key, path string, r *http.Request) any {
+	var items []*mockItem
+	for p, it := range m.items {
+		rest, ok := strings.CutPrefix(p, path+"/")
+		if !ok {
+			continue
+		}
+		n := len(strings.Split(rest, "/"))
+		tsegs := strings.Split(it.template, "/")
+		if it.template == "" || (n < len(tsegs) && allParams(tsegs[len(tsegs)-n:])) {
+			items = append(items, it)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].seq < items[j].seq })
+
+	values := make([]any, 0, len(items))
+	for _, it := range items {
+		values = append(values, it.value)
+	}
+	total := len(values)
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	offset = min(max(offset, 0), total)
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = total
+	}
+	values = values[offset:min(offset+limit, total)]
+
+	example, _ := decodeJSON([]byte(list.Response))
+	obj, ok := example.(map[string]any)
+	if !ok {
+		return values
+	}
+	obj[key] = values
+	if meta, ok := obj["_metadata"].(map[string]any); ok {
+		meta["totalCount"] = total
+		meta["offset"] = offset
+		meta["limit"] = limit
+	}
+	return obj
+}
+
+// itemView is the item at path: its GET example with the path parameters
+// filled in, overlaid with what is stored for it.
+func (m *mockServer) itemView(template, path string) any {
+	var view any
+	if get := m.ops["GET "+template]; get != nil {
+		view = exampleValueOf(get, pathValues(template, path))
+	}
+	if it := m.items[path]; it != nil {
+		view = mergeJSON(view, it.value)
+	}
+	return view
+}
+
+// exampleValueOf decodes the response example of op, setting the fields
+// named after path parameters, and id for the last one, to their values.
+func exampleValueOf(op *specOperation, params [][2]string) any {
+	if op.Response == "" {
+		return nil
+	}
+	v, err := decodeJSON([]byte(op.Response))
+	if err != nil {
+		return nil
+	}
+	obj, ok := v.(map[string]any)
+	if !ok {
+		return v
+	}
+	for i, p := range params {
+		if _, ok := obj[p[0]]; ok {
+			obj[p[0]] = p[1]
+		} else if _, ok := obj["id"]; ok && i == len(params)-1 {
+			obj["id"] = p[1]
+		}
+	}
+	return obj
+}
+
+// pathValues pairs the parameters of template with their values in path.
+func pathValues(template, path string) [][2]string {
+	tsegs := strings.Split(strings.Trim(template, "/"), "/")
+	psegs := strings.Split(strings.Trim(path, "/"), "/")
+	var res [][2]string
+	for i, t := range tsegs {
+		if strings.HasPrefix(t, "{") && i < len(psegs) {
+			res = append(res, [2]string{paramName(t), psegs[i]})
+		}
+	}
+	return res
+}
+
+// itemKey returns the path segments naming obj in a collection: the fields
+// named after the trailing parameters of the item template, id standing in
+// for the last one, or fallback.
+func itemKey(template, collection string, obj map[string]any, fallback string) []string {
+	rest, ok := strings.CutPrefix(template, collection+"/")
+	if !ok {
+		return []string{fallback}
+	}
+	params := strings.Split(rest, "/")
+	key := make([]string, len(params))
+	for i, p := range params {
+		v, ok := obj[paramName(p)]
+		if !ok && i == len(params)-1 {
+			v, ok = obj["id"]
+		}
+		if !ok || jsonType(v) == "object" || jsonType(v) == "array" {
+			key[i] = fallback
+			continue
+		}
+		key[i] = fmt.Sprint(v)
+	}
+	return key
+}
+
+// mockID makes an ID in the style of the example's, numeric or UUID.
+func mockID(example any, n int) string {
+	if s, ok := example.(string); ok && len(s) == 36 && strings.Count(s, "-") == 4 {
+		return fmt.Sprintf("00000000-0000-4000-8000-%012d", n)
+	}
+	return strconv.Itoa(900000 + n)
+}
+
+func paramName(segment string) string {
+	return strings.Trim(segment, "{}[")
+}
+
+func allParams(segments []string) bool {
+	for _, s := range segments {
+		if !strings.HasPrefix(s, "{") {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeJSON overlays b onto a, merging nested objects.
+func mergeJSON(a, b any) any {
+	am, aok := a.(map[string]any)
+	bm, bok := b.(map[string]any)
+	if !aok || !bok {
+		return b
+	}
+	res := make(map[string]any, len(am)+len(bm))
+	for k, v := range am {
+		res[k] = v
+	}
+	for k, v := range bm {
+		if v == nil {
+			res[k] = nil
+		} else {
+			res[k] = mergeJSON(am[k], v)
+		}
+	}
+	return res
+}
+
+// mockError writes an error body in the format the Leaseweb API uses.
+func mockError(w http.ResponseWriter, status int, message string, details map[string][]string) {
+	body := map[string]any{
+		"errorCode":     strconv.Itoa(status),
+		"errorMessage":  message,
+		"correlationId": "mock-" + strconv.Itoa(status),
+	}
+	if len(details) > 0 {
+		body["errorDetails"] = details
+	}
+	b, _ := json.Marshal(body)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(b)
+}
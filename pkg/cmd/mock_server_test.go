@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func TestMockServer(t *testing.T) {
+	srv := httptest.NewServer(NewMockServer())
+	defer srv.Close()
+
+	stdout, _, err := runCLI(t, srv.URL, []string{"-o", "raw", "ds", "list"})
+	require.NoError(t, err)
+	assert.Equal(t, "12345", gjson.Get(stdout, "servers.0.id").String())
+
+	_, _, err = runCLI(t, srv.URL, []string{"domains", "dns-create", "example.com", "--name", "www.example.com.", "--type", "A", "--content", "192.0.2.1"})
+	require.NoError(t, err)
+	stdout, _, err = runCLI(t, srv.URL, []string{"-o", "raw", "domains", "dns", "example.com", "--transform", `resourceRecordSets.#(name=="www.example.com.").content.0`})
+	require.NoError(t, err)
+	assert.Equal(t, `"192.0.2.1"`, strings.TrimSpace(stdout))
+
+	_, _, err = runCLI(t, srv.URL, []string{"--yes", "domains", "dns-delete-all", "example.com"})
+	require.NoError(t, err)
+	stdout, _, err = runCLI(t, srv.URL, []string{"-o", "raw", "domains", "dns", "example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), gjson.Get(stdout, "resourceRecordSets.#").Int())
+
+	_, _, err = runCLI(t, srv.URL, []string{"--yes", "instances", "terminate", "ace712e9-a166-47f1-9065-4af0f7e7fce1"})
+	require.NoError(t, err)
+	_, _, err = runCLI(t, srv.URL, []string{"instances", "get", "ace712e9-a166-47f1-9065-4af0f7e7fce1"})
+	assert.Equal(t, ExitNotFound, ExitCode(err))
+
+	_, _, err = runCLI(t, srv.URL, []string{"--skip-validation", "domains", "dns-update", "example.com", "www", "A", "--payload", `{"ttl":"soon"}`})
+	assert.Equal(t, ExitValidation, ExitCode(err))
+}
@@ -0,0 +1,248 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/tidwall/gjson"
+	"github.com/urfave/cli/v3"
+)
+
+// multiProfileCommands are the commands that accept --profiles and
+// --all-profiles.
+var multiProfileCommands = []string{"ds list", "instances list", "ips list", "invoices list", "services list"}
+
+// listView is how a list command shows its items in a table.
+type listView[T any] struct {
+	// empty is printed to stderr when there is nothing to show.
+	empty      string
+	headers    []string
+	truncOrder []int
+	row        func(T) []string
+	// columns are the JSON paths of the fields shown, for csv and tsv.
+	columns []string
+	// sort, if set, orders the items of each profile before they are shown.
+	sort func([]T)
+}
+
+// listFetcher fetches a collection with client, returning its items and the
+// raw response for non-table output.
+type listFetcher[T any] func(ctx context.Context, client *Client) ([]T, gjson.Result, error)
+
+// runList fetches a collection for the active profile and shows it. With
+// --profiles or --all-profiles it fetches it for each profile at once and
+// shows the results merged, with the profile of every item.
+func runList[T any](ctx context.Context, cmd *cli.Command, fetch listFetcher[T], view listView[T]) error {
+	profiles, err := selectedProfiles(cmd)
+	if err != nil {
+		return err
+	}
+	if profiles != nil {
+		return runListProfiles(ctx, cmd, profiles, fetch, view)
+	}
+
+	client, err := NewClient(cmd)
+	if err != nil {
+		return err
+	}
+	items, res, err := fetch(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	format := cmd.Root().String("output")
+	if format != "auto" {
+		return ShowList(os.Stdout, res, format, cmd.Root().String("transform"), view.columns)
+	}
+
+	if len(items) == 0 {
+		fmt.Fprintln(os.Stderr, view.empty)
+		return nil
+	}
+	if view.sort != nil {
+		view.sort(items)
+	}
+	table := NewTableWriter(os.Stdout, view.headers...)
+	table.TruncOrder = view.truncOrder
+	for _, item := range items {
+		table.AddRow(view.row(item)...)
+	}
+	table.Render()
+	return nil
+}
+
+// profileList is the result of a list command for one profile.
+type profileList[T any] struct {
+	items []T
+	res   gjson.Result
+	err   error
+}
+
+func runListProfiles[T any](ctx context.Context, cmd *cli.Command, profiles []string, fetch listFetcher[T], view listView[T]) error {
+	root := cmd.Root()
+	if root.String("record") != "" || root.String("replay") != "" {
+		return fmt.Errorf("--record and --replay cannot be used with --profiles or --all-profiles")
+	}
+
+	results := make([]profileList[T], len(profiles))
+	var wg sync.WaitGroup
+	for i, name := range profiles {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client, err := newProfileClient(cmd, name)
+			if err != nil {
+				results[i].err = err
+				return
+			}
+			results[i].items, results[i].res, results[i].err = fetch(ctx, client)
+		}()
+	}
+	wg.Wait()
+
+	format := root.String("output")
+	var failed []string
+	var pages []gjson.Result
+	total := 0
+	for i, name := range profiles {
+		r := results[i]
+		if r.err != nil {
+			failed = append(failed, name)
+			ShowError(os.Stderr, fmt.Errorf("profile %s: %w", name, r.err), format)
+			continue
+		}
+		pages = append(pages, withProfileField(r.res, name))
+		total += len(r.items)
+	}
+
+	if format != "auto" {
+		if len(pages) > 0 {
+			columns := append([]string{"_profile"}, view.columns...)
+			if err := ShowList(os.Stdout, mergePages(pages, total), format, root.String("transform"), columns); err != nil {
+				return err
+			}
+		}
+	} else if total == 0 {
+		if len(failed) < len(profiles) {
+			fmt.Fprintln(os.Stderr, view.empty)
+		}
+	} else {
+		table := NewTableWriter(os.Stdout, append([]string{"PROFILE"}, view.headers...)...)
+		for _, i := range view.truncOrder {
+			table.TruncOrder = append(table.TruncOrder, i+1)
+		}
+		for i, name := range profiles {
+			items := results[i].items
+			if results[i].err != nil {
+				continue
+			}
+			if view.sort != nil {
+				view.sort(items)
+			}
+			for _, item := range items {
+				table.AddRow(append([]string{name}, view.row(item)...)...)
+			}
+		}
+		table.Render()
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("%d of %d profiles failed: %s", len(failed), len(profiles), strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// profilesRequested reports whether --profiles or --all-profiles is given.
+func profilesRequested(cmd *cli.Command) bool {
+	root := cmd.Root()
+	return len(root.StringSlice("profiles")) > 0 || root.Bool("all-profiles")
+}
+
+// selectedProfiles returns the profiles named by --profiles or, with
+// --all-profiles, every configured profile. It returns nil when neither is
+// given.
+func selectedProfiles(cmd *cli.Command) ([]string, error) {
+	root := cmd.Root()
+	named, all := root.StringSlice("profiles"), root.Bool("all-profiles")
+	switch {
+	case len(named) == 0 && !all:
+		return nil, nil
+	case len(named) > 0 && all:
+		return nil, fmt.Errorf("--profiles and --all-profiles cannot be used together")
+	case root.String("profile") != "":
+		return nil, fmt.Errorf("--profile cannot be used with --profiles or --all-profiles")
+	}
+
+	cfg := loadConfig()
+	configured := profileNames(cfg)
+	sort.Strings(configured)
+	if all {
+		if len(configured) == 0 {
+			return nil, fmt.Errorf("no profiles configured. Run 'lw config init'")
+		}
+		return configured, nil
+	}
+
+	var profiles []string
+	for _, name := range named {
+		name = strings.TrimSpace(name)
+		if name == "" || slices.Contains(profiles, name) {
+			continue
+		}
+		if _, ok := cfg.Profiles[name]; !ok {
+			return nil, fmt.Errorf("profile %q not found; configured profiles: %s", name, strings.Join(configured, ", "))
+		}
+		profiles = append(profiles, name)
+	}
+	return profiles, nil
+}
+
+// withProfileField adds a "_profile" field holding name to every object in
+// the collection arrays of res.
+func withProfileField(res gjson.Result, name string) gjson.Result {
+	if !res.IsObject() {
+		return res
+	}
+	field, _ := json.Marshal(name)
+	var b strings.Builder
+	b.WriteByte('{')
+	i := 0
+	res.ForEach(func(key, val gjson.Result) bool {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		i++
+		b.WriteString(key.Raw)
+		b.WriteByte(':')
+		if key.String() == "_metadata" || !val.IsArray() {
+			b.WriteString(val.Raw)
+			return true
+		}
+		b.WriteByte('[')
+		for j, item := range val.Array() {
+			if j > 0 {
+				b.WriteByte(',')
+			}
+			if !item.IsObject() {
+				b.WriteString(item.Raw)
+				continue
+			}
+			fmt.Fprintf(&b, `{"_profile":%s`, field)
+			item.ForEach(func(k, v gjson.Result) bool {
+				fmt.Fprintf(&b, ",%s:%s", k.Raw, v.Raw)
+				return true
+			})
+			b.WriteByte('}')
+		}
+		b.WriteByte(']')
+		return true
+	})
+	b.WriteByte('}')
+	return gjson.Parse(b.String())
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func TestMultiProfileList(t *testing.T) {
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"GET /bareMetals/v2/servers": func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get("X-LSW-Auth")
+			if key == "ca-key" {
+				jsonResponse(w, 401, map[string]any{"errorMessage": "Invalid API key"})
+				return
+			}
+			if key == "empty-key" {
+				jsonResponse(w, 200, map[string]any{"_metadata": map[string]any{"totalCount": 0, "limit": 20, "offset": 0}})
+				return
+			}
+			jsonResponse(w, 200, map[string]any{
+				"servers":   []map[string]any{{"id": strings.TrimSuffix(key, "-key") + "-1"}},
+				"_metadata": map[string]any{"totalCount": 1, "limit": 20, "offset": 0},
+			})
+		},
+	})
+	defer srv.Close()
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".config", "lw"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".config", "lw", "config.yaml"), []byte(`default_profile: us
+profiles:
+  us:
+    api_key: us-key
+  eu:
+    api_key: eu-key
+  ca:
+    api_key: ca-key
+  empty:
+    api_key: empty-key
+`), 0600))
+
+	stdout, _, err := runCLI(t, srv.URL, []string{"--profiles", "us,eu", "-o", "json", "ds", "list"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), gjson.Get(stdout, "_metadata.totalCount").Int())
+	assert.Equal(t, "us", gjson.Get(stdout, "servers.0._profile").String())
+	assert.Equal(t, "us-1", gjson.Get(stdout, "servers.0.id").String())
+	assert.Equal(t, "eu", gjson.Get(stdout, "servers.1._profile").String())
+	assert.Equal(t, "eu-1", gjson.Get(stdout, "servers.1.id").String())
+
+	// The first profile's page has no collection; the others' items are kept.
+	stdout, _, err = runCLI(t, srv.URL, []string{"--profiles", "empty,us,eu", "-o", "json", "ds", "list"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), gjson.Get(stdout, "_metadata.totalCount").Int())
+	assert.Equal(t, "us-1", gjson.Get(stdout, "servers.0.id").String())
+	assert.Equal(t, "eu-1", gjson.Get(stdout, "servers.1.id").String())
+
+	// A failing profile is reported; the others are still listed.
+	stdout, stderr, err := runCLI(t, srv.URL, []string{"--all-profiles", "ds", "list"})
+	require.Error(t, err)
+	assert.Equal(t, "1 of 4 profiles failed: ca", err.Error())
+	assert.Contains(t, stderr, "profile ca:")
+	assert.Contains(t, stdout, "PROFILE")
+	assert.Regexp(t, `eu\s+eu-1`, stdout)
+	assert.Regexp(t, `us\s+us-1`, stdout)
+
+	_, _, err = runCLI(t, srv.URL, []string{"--profiles", "us,mars", "ds", "list"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `profile "mars" not found`)
+
+	_, _, err = runCLI(t, srv.URL, []string{"--all-profiles", "ds", "get", "1"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "work only with ds list")
+}
@@ -36,14 +36,14 @@ func handleOrdersList(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	res, err := client.Get(ctx, "/account/v1/orders?"+PaginationQuery(cmd))
+	res, err := GetList(ctx, client, cmd, "/account/v1/orders?"+PaginationQuery(cmd))
 	if err != nil {
 		return err
 	}
 
 	format := cmd.Root().String("output")
 	if format != "auto" {
-		return ShowResult(os.Stdout, res, format, cmd.Root().String("transform"))
+		return ShowList(os.Stdout, res, format, cmd.Root().String("transform"), []string{"id", "type", "origin", "createdAt"})
 	}
 
 	orders := res.Get("orders")
@@ -120,7 +120,7 @@ var ordersProductsDSListCmd = cli.Command{
 	Name:  "list",
 	Usage: "List available dedicated server configurations",
 	Flags: append(PaginationFlags, []cli.Flag{
-		&cli.StringFlag{Name: "location", Usage: "Filter by location"},
+		&cli.StringFlag{Name: "location", Usage: "Filter by location (default: default_location of the profile)"},
 		&cli.StringFlag{Name: "ram", Usage: "Filter by RAM"},
 		&cli.StringFlag{Name: "disk-size", Usage: "Filter by disk size"},
 		&cli.StringFlag{Name: "disk-amount", Usage: "Filter by disk amount"},
@@ -130,24 +130,28 @@ var ordersProductsDSListCmd = cli.Command{
 }
 
 func handleProductsDSList(ctx context.Context, cmd *cli.Command) error {
+	location, _ := resolveLocation(cmd)
 	client, err := NewClient(cmd)
 	if err != nil {
 		return err
 	}
 	q := PaginationQuery(cmd) + "&" + BuildQueryString(map[string]string{
-		"location":   cmd.String("location"),
+		"location":   location,
 		"ram":        cmd.String("ram"),
 		"diskSize":   cmd.String("disk-size"),
 		"diskAmount": cmd.String("disk-amount"),
 	})
-	res, err := client.Get(ctx, "/ordering/v1/products/dedicatedServers?"+q)
+	res, err := GetList(ctx, client, cmd, "/ordering/v1/products/dedicatedServers?"+q)
 	if err != nil {
 		return err
 	}
 
 	format := cmd.Root().String("output")
 	if format != "auto" {
-		return ShowResult(os.Stdout, res, format, cmd.Root().String("transform"))
+		return ShowList(os.Stdout, res, format, cmd.Root().String("transform"), []string{
+			"id", "name", "chassis", "cpu.quantity", "cpu.speed", "ram.amount", "ram.unit",
+			"storage.amount", "storage.size", "storage.type",
+		})
 	}
 
 	servers := res.Get("dedicatedServers")
@@ -179,7 +183,7 @@ var ordersProductsDSGetCmd = cli.Command{
 	Usage:     "Get dedicated server details and pricing",
 	ArgsUsage: "<server-id>",
 	Flags: []cli.Flag{
-		&cli.StringFlag{Name: "location", Usage: "Location for pricing", Required: true},
+		&cli.StringFlag{Name: "location", Usage: "Location for pricing (default: default_location of the profile)"},
 		&cli.BoolFlag{Name: "connected-to-aggregation-pool", Usage: "Include aggregation pool pricing"},
 	},
 	Action:          handleProductsDSGet,
@@ -191,12 +195,16 @@ func handleProductsDSGet(ctx context.Context, cmd *cli.Command) error {
 	if len(args) < 1 {
 		return fmt.Errorf("server ID required")
 	}
+	location, err := resolveLocation(cmd)
+	if err != nil {
+		return err
+	}
 	client, err := NewClient(cmd)
 	if err != nil {
 		return err
 	}
 	q := BuildQueryString(map[string]string{
-		"location": cmd.String("location"),
+		"location": location,
 	})
 	if cmd.Bool("connected-to-aggregation-pool") {
 		q += "&connectedToAggregationPool=true"
@@ -213,7 +221,7 @@ var ordersProductsDSOrderCmd = cli.Command{
 	Usage:     "Order a dedicated server",
 	ArgsUsage: "<server-id>",
 	Flags: []cli.Flag{
-		&cli.StringFlag{Name: "location", Usage: "Datacenter location (e.g. AMS-01)", Required: true},
+		&cli.StringFlag{Name: "location", Usage: "Datacenter location, e.g. AMS-01 (default: default_location of the profile)"},
 		&cli.StringFlag{Name: "contract-term", Usage: "Contract term (1_MONTH, 3_MONTHS, 6_MONTHS, 1_YEAR, 2_YEARS, 3_YEARS)", Value: "1_MONTH"},
 		&cli.BoolFlag{Name: "connected-to-aggregation-pool", Usage: "Connect to aggregation pool"},
 	},
@@ -226,12 +234,16 @@ func handleProductsDSOrder(ctx context.Context, cmd *cli.Command) error {
 	if len(args) < 1 {
 		return fmt.Errorf("server ID required")
 	}
+	location, err := resolveLocation(cmd)
+	if err != nil {
+		return err
+	}
 	client, err := NewClient(cmd)
 	if err != nil {
 		return err
 	}
 	payload := map[string]any{
-		"location":     cmd.String("location"),
+		"location":     location,
 		"contractTerm": cmd.String("contract-term"),
 	}
 	if cmd.Bool("connected-to-aggregation-pool") {
@@ -263,29 +275,32 @@ var ordersProductsVPSListCmd = cli.Command{
 	Name:  "list",
 	Usage: "List available VPS products",
 	Flags: append(PaginationFlags, []cli.Flag{
-		&cli.StringFlag{Name: "location", Usage: "Filter by location"},
+		&cli.StringFlag{Name: "location", Usage: "Filter by location (default: default_location of the profile)"},
 	}...),
 	Action:          handleProductsVPSList,
 	HideHelpCommand: true,
 }
 
 func handleProductsVPSList(ctx context.Context, cmd *cli.Command) error {
+	location, _ := resolveLocation(cmd)
 	client, err := NewClient(cmd)
 	if err != nil {
 		return err
 	}
 	q := PaginationQuery(cmd)
-	if loc := cmd.String("location"); loc != "" {
-		q += "&location=" + loc
+	if location != "" {
+		q += "&location=" + location
 	}
-	res, err := client.Get(ctx, "/ordering/v1/products/vps?"+q)
+	res, err := GetList(ctx, client, cmd, "/ordering/v1/products/vps?"+q)
 	if err != nil {
 		return err
 	}
 
 	format := cmd.Root().String("output")
 	if format != "auto" {
-		return ShowResult(os.Stdout, res, format, cmd.Root().String("transform"))
+		return ShowList(os.Stdout, res, format, cmd.Root().String("transform"), []string{
+			"id", "name", "vCpu", "vRam", "nvmeStorage", "traffic",
+		})
 	}
 
 	vpss := res.Get("vpss")
@@ -315,7 +330,7 @@ var ordersProductsVPSGetCmd = cli.Command{
 	Usage:     "Get VPS product details and pricing",
 	ArgsUsage: "<vps-id>",
 	Flags: []cli.Flag{
-		&cli.StringFlag{Name: "location", Usage: "Location for pricing", Required: true},
+		&cli.StringFlag{Name: "location", Usage: "Location for pricing (default: default_location of the profile)"},
 		&cli.StringFlag{Name: "disk-upgrade", Usage: "Disk upgrade option"},
 		&cli.StringFlag{Name: "operating-system", Usage: "Operating system option"},
 		&cli.StringFlag{Name: "control-panel", Usage: "Control panel option"},
@@ -332,12 +347,16 @@ func handleProductsVPSGet(ctx context.Context, cmd *cli.Command) error {
 	if len(args) < 1 {
 		return fmt.Errorf("VPS ID required")
 	}
+	location, err := resolveLocation(cmd)
+	if err != nil {
+		return err
+	}
 	client, err := NewClient(cmd)
 	if err != nil {
 		return err
 	}
 	q := BuildQueryString(map[string]string{
-		"location":              cmd.String("location"),
+		"location":              location,
 		"diskUpgrade":           cmd.String("disk-upgrade"),
 		"operatingSystem":       cmd.String("operating-system"),
 		"controlPanel":          cmd.String("control-panel"),
@@ -357,7 +376,7 @@ var ordersProductsVPSOrderCmd = cli.Command{
 	Usage:     "Order a VPS",
 	ArgsUsage: "<vps-id>",
 	Flags: []cli.Flag{
-		&cli.StringFlag{Name: "location", Usage: "Datacenter location (e.g. AMS-01)", Required: true},
+		&cli.StringFlag{Name: "location", Usage: "Datacenter location, e.g. AMS-01 (default: default_location of the profile)"},
 		&cli.StringFlag{Name: "disk-upgrade", Usage: "Disk upgrade option"},
 		&cli.StringFlag{Name: "operating-system", Usage: "Operating system"},
 		&cli.StringFlag{Name: "control-panel", Usage: "Control panel"},
@@ -374,12 +393,16 @@ func handleProductsVPSOrder(ctx context.Context, cmd *cli.Command) error {
 	if len(args) < 1 {
 		return fmt.Errorf("VPS ID required")
 	}
+	location, err := resolveLocation(cmd)
+	if err != nil {
+		return err
+	}
 	client, err := NewClient(cmd)
 	if err != nil {
 		return err
 	}
 	payload := map[string]string{
-		"location":     cmd.String("location"),
+		"location":     location,
 		"contractTerm": cmd.String("contract-term"),
 		"billingCycle": cmd.String("billing-cycle"),
 	}
@@ -1,8 +1,18 @@
 package cmd
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
 
+	"github.com/kernel/leaseweb-cli/pkg/leaseweb"
+	"github.com/tidwall/gjson"
 	"github.com/urfave/cli/v3"
 )
 
@@ -24,3 +34,209 @@ func PaginationQuery(cmd *cli.Command) string {
 	offset := cmd.Int("offset")
 	return fmt.Sprintf("limit=%d&offset=%d", limit, offset)
 }
+
+// GetList fetches a paginated collection. With the global --all flag every
+// page is fetched and the collection arrays are merged into a single result;
+// otherwise it is a plain GET of path.
+func GetList(ctx context.Context, client *Client, cmd *cli.Command, path string) (gjson.Result, error) {
+	if !cmd.Root().Bool("all") {
+		return client.Get(ctx, path)
+	}
+	progress := newPageProgress(isTerminal(os.Stderr))
+	defer progress.done()
+	return fetchAllPages(ctx, client, path, nil, cmd.Root().Int("page-size"), progress.update)
+}
+
+// ListPages fetches a collection through an SDK list method. Without the
+// global --all flag it returns the page selected by --limit/--offset;
+// otherwise it walks every page. The items are returned together with the
+// raw response, merged across pages, for non-table output.
+func ListPages[T any](ctx context.Context, cmd *cli.Command, list func(leaseweb.ListOptions) (*leaseweb.Page[T], error)) ([]T, gjson.Result, error) {
+	if !cmd.Root().Bool("all") {
+		page, err := list(leaseweb.ListOptions{Limit: cmd.Int("limit"), Offset: cmd.Int("offset")})
+		if err != nil {
+			return nil, gjson.Result{}, err
+		}
+		return page.Items, gjson.ParseBytes(page.Raw), nil
+	}
+
+	// Profiles fetched at once would garble each other's progress.
+	progress := newPageProgress(isTerminal(os.Stderr) && !profilesRequested(cmd))
+	defer progress.done()
+	items, pages, err := collectPages(leaseweb.Pages(cmd.Root().Int("page-size"), list), progress.update)
+	if err != nil {
+		return nil, gjson.Result{}, err
+	}
+	return items, mergePages(pages, len(items)), nil
+}
+
+// fetchAllPages walks path page by page using limit/offset. header is sent
+// with every page request.
+func fetchAllPages(ctx context.Context, client *Client, path string, header http.Header, pageSize int, onPage func(page, fetched, total int)) (gjson.Result, error) {
+	u, err := url.Parse(path)
+	if err != nil {
+		return gjson.Result{}, fmt.Errorf("parsing path: %w", err)
+	}
+	query := u.Query()
+
+	items, pages, err := collectPages(leaseweb.Pages(pageSize, func(opts leaseweb.ListOptions) (*leaseweb.Page[gjson.Result], error) {
+		query.Set("limit", strconv.Itoa(opts.Limit))
+		query.Set("offset", strconv.Itoa(opts.Offset))
+		u.RawQuery = query.Encode()
+		res, err := getPage(ctx, client, u.String(), header)
+		if err != nil {
+			return nil, err
+		}
+		return &leaseweb.Page[gjson.Result]{
+			Items:    pageItems(res),
+			Metadata: leaseweb.Metadata{TotalCount: int(res.Get("_metadata.totalCount").Int())},
+			Raw:      json.RawMessage(res.Raw),
+		}, nil
+	}), onPage)
+	if err != nil {
+		return gjson.Result{}, err
+	}
+	return mergePages(pages, len(items)), nil
+}
+
+// collectPages walks pages and returns their items and bodies, reporting
+// progress to onPage when it is not nil.
+func collectPages[T any](pages iter.Seq2[*leaseweb.Page[T], error], onPage func(page, fetched, total int)) ([]T, []gjson.Result, error) {
+	var items []T
+	var bodies []gjson.Result
+	for page, err := range pages {
+		if err != nil {
+			return nil, nil, err
+		}
+		items = append(items, page.Items...)
+		bodies = append(bodies, gjson.ParseBytes(page.Raw))
+		if onPage != nil {
+			onPage(len(bodies), len(items), page.Metadata.TotalCount)
+		}
+	}
+	return items, bodies, nil
+}
+
+// getPage fetches a page of a list. Pages requested with extra headers
+// bypass the response cache.
+func getPage(ctx context.Context, client *Client, path string, header http.Header) (gjson.Result, error) {
+	if len(header) == 0 {
+		return client.Get(ctx, path)
+	}
+	resp, err := client.send(ctx, http.MethodGet, path, nil, header)
+	if err != nil {
+		return gjson.Result{}, err
+	}
+	return gjson.ParseBytes(resp.Body), nil
+}
+
+// pageItems returns the largest collection array in a page.
+func pageItems(page gjson.Result) []gjson.Result {
+	var items []gjson.Result
+	page.ForEach(func(key, val gjson.Result) bool {
+		if key.String() != "_metadata" && val.IsArray() && len(val.Array()) > len(items) {
+			items = val.Array()
+		}
+		return true
+	})
+	return items
+}
+
+// mergePages concatenates the collection arrays of all pages and rewrites
+// _metadata to describe the merged result. The keys are taken from every
+// page in the order they are first seen, so a page without the collection,
+// such as an empty one from another profile, does not drop the items of the
+// pages after it.
+func mergePages(pages []gjson.Result, total int) gjson.Result {
+	type field struct {
+		key, raw string
+		first    gjson.Result
+		array    bool
+	}
+	var fields []*field
+	byKey := map[string]*field{}
+	for _, page := range pages {
+		if !page.IsObject() {
+			continue
+		}
+		page.ForEach(func(key, val gjson.Result) bool {
+			f, ok := byKey[key.String()]
+			if !ok {
+				f = &field{key: key.String(), raw: key.Raw, first: val}
+				byKey[key.String()] = f
+				fields = append(fields, f)
+			}
+			f.array = f.array || val.IsArray()
+			return true
+		})
+	}
+	if len(fields) == 0 {
+		if len(pages) == 0 {
+			return gjson.Result{}
+		}
+		return pages[0]
+	}
+
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, f := range fields {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(f.raw)
+		b.WriteByte(':')
+		switch {
+		case f.key == "_metadata":
+			fmt.Fprintf(&b, `{"totalCount":%d,"limit":%d,"offset":0}`, total, total)
+		case f.array:
+			b.WriteByte('[')
+			n := 0
+			for _, page := range pages {
+				list := page.Get(gjson.Escape(f.key))
+				if !list.IsArray() {
+					continue
+				}
+				for _, item := range list.Array() {
+					if n > 0 {
+						b.WriteByte(',')
+					}
+					n++
+					b.WriteString(item.Raw)
+				}
+			}
+			b.WriteByte(']')
+		default:
+			b.WriteString(f.first.Raw)
+		}
+	}
+	b.WriteByte('}')
+	return gjson.Parse(b.String())
+}
+
+// pageProgress reports pagination progress on stderr when it is a terminal.
+type pageProgress struct {
+	enabled bool
+	shown   bool
+}
+
+func newPageProgress(enabled bool) *pageProgress {
+	return &pageProgress{enabled: enabled}
+}
+
+func (p *pageProgress) update(page, fetched, total int) {
+	if !p.enabled {
+		return
+	}
+	p.shown = true
+	if total > 0 {
+		fmt.Fprintf(os.Stderr, "\rFetching page %d (%d/%d)...", page, fetched, total)
+	} else {
+		fmt.Fprintf(os.Stderr, "\rFetching page %d (%d)...", page, fetched)
+	}
+}
+
+func (p *pageProgress) done() {
+	if p.shown {
+		fmt.Fprint(os.Stderr, "\r\033[K")
+	}
+}
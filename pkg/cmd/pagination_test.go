@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaginationAll(t *testing.T) {
+	var offsets []string
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"GET /bareMetals/v2/servers": func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "2", r.URL.Query().Get("limit"))
+			assert.Equal(t, "web", r.URL.Query().Get("reference"))
+			offset := r.URL.Query().Get("offset")
+			offsets = append(offsets, offset)
+			var servers []map[string]any
+			switch offset {
+			case "0":
+				servers = []map[string]any{{"id": "1"}, {"id": "2"}}
+			case "2":
+				servers = []map[string]any{{"id": "3"}, {"id": "4"}}
+			case "4":
+				servers = []map[string]any{{"id": "5"}}
+			}
+			jsonResponse(w, 200, map[string]any{
+				"_metadata": map[string]any{"totalCount": 5, "limit": 2, "offset": offset},
+				"servers":   servers,
+			})
+		},
+	})
+	defer srv.Close()
+
+	stdout, _, err := runCLI(t, srv.URL, []string{
+		"--all", "--page-size", "2", "--output", "raw",
+		"dedicated-servers", "list", "--reference", "web",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"0", "2", "4"}, offsets)
+	assert.Equal(t,
+		`{"_metadata":{"totalCount":5,"limit":5,"offset":0},"servers":[{"id":"1"},{"id":"2"},{"id":"3"},{"id":"4"},{"id":"5"}]}`+"\n",
+		stdout)
+}
@@ -0,0 +1,340 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+	"go.yaml.in/yaml/v3"
+)
+
+// payloadFlag is the flag set of commands that send a JSON body, read
+// through loadPayload.
+var payloadFlag = payloadFlags("JSON payload")
+
+// payloadFlags returns the body flags with usage describing --payload.
+func payloadFlags(usage string) []cli.Flag {
+	return bodyFlags(&cli.StringFlag{Name: "payload", Usage: usage})
+}
+
+// bodyFlags returns body, which takes the body itself, followed by the
+// --set and --set-json flags.
+func bodyFlags(body *cli.StringFlag) []cli.Flag {
+	body.Usage += " (use @file.json, @file.yaml, or - for stdin)"
+	return []cli.Flag{
+		body,
+		&repeatedFlag{
+			Name:  "set",
+			Usage: "Set a body field in key.path=value format, typing true, false, null and numbers",
+		},
+		&repeatedFlag{
+			Name:  "set-json",
+			Usage: "Set a body field to a JSON value in key.path=json format",
+		},
+	}
+}
+
+// repeatedFlag is a string flag that may be given several times. Unlike
+// cli.StringSliceFlag it does not split its values on commas, which field
+// values and JSON arrays contain. Read it with cmd.StringSlice.
+type repeatedFlag = cli.FlagBase[[]string, cli.NoConfig, repeatedValue]
+
+type repeatedValue struct {
+	destination *[]string
+}
+
+func (v repeatedValue) Create(val []string, p *[]string, _ cli.NoConfig) cli.Value {
+	*p = slices.Clone(val)
+	return &repeatedValue{destination: p}
+}
+
+func (v repeatedValue) ToString(val []string) string {
+	return strings.Join(val, " ")
+}
+
+func (v *repeatedValue) Set(s string) error {
+	*v.destination = append(*v.destination, s)
+	return nil
+}
+
+func (v *repeatedValue) Get() any { return *v.destination }
+
+func (v *repeatedValue) String() string {
+	if v.destination == nil {
+		return ""
+	}
+	return strings.Join(*v.destination, " ")
+}
+
+// loadPayload builds the request body from --payload and the --set and
+// --set-json flags, which are applied in that order on top of it.
+func loadPayload(cmd *cli.Command) ([]byte, error) {
+	body, err := loadBody(cmd, "payload", os.Stdin)
+	if err != nil {
+		return nil, err
+	}
+	if body == nil {
+		return nil, fmt.Errorf("request body required (use --payload or --set)")
+	}
+	return body, nil
+}
+
+// loadBody reads the body flags registered under name by bodyFlags. It
+// returns nil when none of them is set.
+func loadBody(cmd *cli.Command, name string, stdin io.Reader) ([]byte, error) {
+	base, err := readPayloadSource("--"+name, cmd.String(name), stdin)
+	if err != nil {
+		return nil, err
+	}
+	sets, setJSONs := cmd.StringSlice("set"), cmd.StringSlice("set-json")
+	if len(sets) == 0 && len(setJSONs) == 0 {
+		return base, nil
+	}
+
+	var doc any = map[string]any{}
+	if base != nil {
+		if doc, err = decodeJSON(base); err != nil {
+			return nil, fmt.Errorf("--%s: %w", name, err)
+		}
+	}
+	for _, s := range sets {
+		key, value, ok := strings.Cut(s, "=")
+		if !ok {
+			return nil, fmt.Errorf("--set %s: must be in key.path=value format", s)
+		}
+		typed, err := typedFieldValue(value, stdin)
+		if err != nil {
+			return nil, fmt.Errorf("--set %s: %w", s, err)
+		}
+		if doc, err = setPath(doc, splitPath(key), typed); err != nil {
+			return nil, fmt.Errorf("--set %s: %w", s, err)
+		}
+	}
+	for _, s := range setJSONs {
+		key, value, ok := strings.Cut(s, "=")
+		if !ok {
+			return nil, fmt.Errorf("--set-json %s: must be in key.path=json format", s)
+		}
+		v, err := decodeJSON([]byte(value))
+		if err != nil {
+			return nil, fmt.Errorf("--set-json %s:%s", s, jsonErrorAt(err, []byte(value)))
+		}
+		if doc, err = setPath(doc, splitPath(key), v); err != nil {
+			return nil, fmt.Errorf("--set-json %s: %w", s, err)
+		}
+	}
+	return json.Marshal(doc)
+}
+
+// decodeJSON decodes data keeping numbers as json.Number, so large IDs
+// survive being re-encoded.
+func decodeJSON(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("unexpected data after JSON value")
+	}
+	return v, nil
+}
+
+// readPayloadSource resolves a body flag value: inline JSON, @file (YAML
+// when the extension is .yaml or .yml) or - for stdin. Syntax errors are
+// reported with the line and column in their source.
+func readPayloadSource(flag, value string, stdin io.Reader) ([]byte, error) {
+	source := flag
+	var data []byte
+	switch {
+	case value == "":
+		return nil, nil
+	case value == "-":
+		b, err := readInput("-", stdin)
+		if err != nil {
+			return nil, err
+		}
+		source, data = "<stdin>", b
+	case strings.HasPrefix(value, "@"):
+		b, err := readInput(value[1:], stdin)
+		if err != nil {
+			return nil, err
+		}
+		source, data = value[1:], b
+		switch strings.ToLower(filepath.Ext(source)) {
+		case ".yaml", ".yml":
+			return yamlToJSON(source, data)
+		}
+	default:
+		data = []byte(value)
+	}
+
+	if !json.Valid(data) {
+		var v any
+		err := json.Unmarshal(data, &v)
+		return nil, fmt.Errorf("%s:%s", source, jsonErrorAt(err, data))
+	}
+	return bytes.TrimSpace(data), nil
+}
+
+// jsonErrorAt formats a JSON decoding error with the line and column of
+// the offending byte when known.
+func jsonErrorAt(err error, data []byte) string {
+	var offset int64 = -1
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		offset = syntaxErr.Offset
+	case errors.As(err, &typeErr):
+		offset = typeErr.Offset
+	}
+	if offset < 0 {
+		return " " + err.Error()
+	}
+	line, col := 1, 1
+	for _, b := range data[:min(int(offset), len(data))] {
+		if b == '\n' {
+			line++
+			col = 1
+		} else {
+			col++
+		}
+	}
+	return fmt.Sprintf("%d:%d: invalid JSON: %s", line, col, err)
+}
+
+func yamlToJSON(source string, data []byte) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		msg := strings.TrimPrefix(err.Error(), "yaml: ")
+		if rest, ok := strings.CutPrefix(msg, "line "); ok {
+			if n, after, ok := strings.Cut(rest, ":"); ok {
+				return nil, fmt.Errorf("%s:%s: invalid YAML:%s", source, n, after)
+			}
+		}
+		return nil, fmt.Errorf("%s: invalid YAML: %s", source, msg)
+	}
+	v, err := yamlValue(&doc)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", source, err)
+	}
+	return json.Marshal(v)
+}
+
+// yamlValue converts a YAML node into values encoding/json accepts.
+// Timestamps are kept as written rather than reformatted.
+func yamlValue(n *yaml.Node) (any, error) {
+	switch n.Kind {
+	case yaml.DocumentNode:
+		if len(n.Content) == 0 {
+			return nil, nil
+		}
+		return yamlValue(n.Content[0])
+	case yaml.AliasNode:
+		return yamlValue(n.Alias)
+	case yaml.MappingNode:
+		m := make(map[string]any, len(n.Content)/2)
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			v, err := yamlValue(n.Content[i+1])
+			if err != nil {
+				return nil, err
+			}
+			m[n.Content[i].Value] = v
+		}
+		return m, nil
+	case yaml.SequenceNode:
+		list := make([]any, len(n.Content))
+		for i, item := range n.Content {
+			v, err := yamlValue(item)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = v
+		}
+		return list, nil
+	}
+	if n.Tag == "!!timestamp" {
+		return n.Value, nil
+	}
+	var v any
+	if err := n.Decode(&v); err != nil {
+		return nil, fmt.Errorf("%d:%d: %w", n.Line, n.Column, err)
+	}
+	return v, nil
+}
+
+// splitPath splits a dotted key path; a backslash escapes a literal dot.
+func splitPath(key string) []string {
+	var parts []string
+	var cur strings.Builder
+	for i := 0; i < len(key); i++ {
+		switch {
+		case key[i] == '\\' && i+1 < len(key) && key[i+1] == '.':
+			cur.WriteByte('.')
+			i++
+		case key[i] == '.':
+			parts = append(parts, cur.String())
+			cur.Reset()
+		default:
+			cur.WriteByte(key[i])
+		}
+	}
+	return append(parts, cur.String())
+}
+
+// setPath assigns value at path inside doc, creating objects as needed.
+// Numeric segments index arrays; an index equal to the length appends.
+func setPath(doc any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	seg := path[0]
+	if seg == "" {
+		return nil, fmt.Errorf("empty key in path")
+	}
+	switch d := doc.(type) {
+	case []any:
+		i, err := strconv.Atoi(seg)
+		if err != nil || i < 0 || i > len(d) {
+			return nil, fmt.Errorf("%q is not a valid index for an array of length %d", seg, len(d))
+		}
+		var cur any
+		if i < len(d) {
+			cur = d[i]
+		}
+		v, err := setPath(cur, path[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		if i == len(d) {
+			return append(d, v), nil
+		}
+		d[i] = v
+		return d, nil
+	case map[string]any:
+		v, err := setPath(d[seg], path[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		d[seg] = v
+		return d, nil
+	case nil:
+		v, err := setPath(nil, path[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{seg: v}, nil
+	default:
+		return nil, fmt.Errorf("cannot set %q inside a %s", seg, jsonType(d))
+	}
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPayloadSources(t *testing.T) {
+	var got []string
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"PUT /hosting/v2/domains/example.com/resourceRecordSets/www/A": func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			b, _ := json.Marshal(body)
+			got = append(got, string(b))
+			jsonResponse(w, 200, body)
+		},
+	})
+	defer srv.Close()
+
+	dir := t.TempDir()
+	yamlPath := dir + "/record.yaml"
+	require.NoError(t, os.WriteFile(yamlPath, []byte("content:\n  - 1.2.3.4\nttl: 300\n"), 0600))
+
+	_, _, err := runCLI(t, srv.URL, []string{
+		"domains", "dns-update", "example.com", "www", "A",
+		"--payload", "@" + yamlPath, "--set", "ttl=3600", "--set", "content.1=5.6.7.8",
+	})
+	require.NoError(t, err)
+
+	_, _, err = runCLI(t, srv.URL, []string{
+		"domains", "dns-update", "example.com", "www", "A",
+		"--set", "ttl=60", "--set-json", `content=["9.9.9.9"]`,
+	})
+	require.NoError(t, err)
+
+	// Values are not split on commas.
+	_, _, err = runCLI(t, srv.URL, []string{
+		"domains", "dns-update", "example.com", "www", "A",
+		"--set", "ttl=60", "--set-json", `content=["1.1.1.1","2.2.2.2"]`,
+	})
+	require.NoError(t, err)
+	_, _, err = runCLI(t, srv.URL, []string{
+		"domains", "dns-update", "example.com", "www", "A",
+		"--payload", `{"content":["1.1.1.1"],"ttl":60}`, "--set", "content.1=a, b",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		`{"content":["1.2.3.4","5.6.7.8"],"ttl":3600}`,
+		`{"content":["9.9.9.9"],"ttl":60}`,
+		`{"content":["1.1.1.1","2.2.2.2"],"ttl":60}`,
+		`{"content":["1.1.1.1","a, b"],"ttl":60}`,
+	}, got)
+
+	jsonPath := dir + "/record.json"
+	require.NoError(t, os.WriteFile(jsonPath, []byte("{\n  \"ttl\": 30x\n}"), 0600))
+	_, _, err = runCLI(t, srv.URL, []string{"domains", "dns-update", "example.com", "www", "A", "--payload", "@" + jsonPath})
+	assert.ErrorContains(t, err, jsonPath+":2:13: invalid JSON")
+
+	_, _, err = runCLI(t, srv.URL, []string{"domains", "dns-update", "example.com", "www", "A", "--set", "ttl=60", "--set", "ttl.x=1"})
+	assert.ErrorContains(t, err, `--set ttl.x=1: cannot set "x" inside a number`)
+
+	_, _, err = runCLI(t, srv.URL, []string{"domains", "dns-update", "example.com", "www", "A"})
+	assert.ErrorContains(t, err, "request body required")
+}
@@ -28,7 +28,7 @@ func handlePCList(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	res, err := client.Get(ctx, "/cloud/v2/privateClouds?"+PaginationQuery(cmd))
+	res, err := GetList(ctx, client, cmd, "/cloud/v2/privateClouds?"+PaginationQuery(cmd))
 	if err != nil {
 		return err
 	}
@@ -41,14 +41,16 @@ func handlePNList(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	res, err := client.Get(ctx, "/bareMetals/v2/privateNetworks?"+PaginationQuery(cmd))
+	res, err := GetList(ctx, client, cmd, "/bareMetals/v2/privateNetworks?"+PaginationQuery(cmd))
 	if err != nil {
 		return err
 	}
 
 	format := cmd.Root().String("output")
 	if format != "auto" {
-		return ShowResult(os.Stdout, res, format, cmd.Root().String("transform"))
+		return ShowList(os.Stdout, res, format, cmd.Root().String("transform"), []string{
+			"id", "name", "status", "subnet", "location.site",
+		})
 	}
 
 	networks := res.Get("privateNetworks")
@@ -151,7 +153,7 @@ var pnDeleteCmd = cli.Command{
 	Name:            "delete",
 	Usage:           "Delete a private network",
 	ArgsUsage:       "<network-id>",
-	Action:          handlePNDelete,
+	Action:          confirm(destructive{"delete private network %s", "/bareMetals/v2/privateNetworks/%s", networkDetails}, handlePNDelete),
 	HideHelpCommand: true,
 }
 
@@ -173,10 +175,10 @@ func handlePNDelete(ctx context.Context, cmd *cli.Command) error {
 }
 
 var pnServersCmd = cli.Command{
-	Name:      "servers",
-	Usage:     "List servers in a private network",
-	ArgsUsage: "<network-id>",
-	Action:    handlePNServers,
+	Name:            "servers",
+	Usage:           "List servers in a private network",
+	ArgsUsage:       "<network-id>",
+	Action:          handlePNServers,
 	HideHelpCommand: true,
 }
 
@@ -253,7 +255,7 @@ var pnReservationsDeleteCmd = cli.Command{
 	Name:            "delete-reservation",
 	Usage:           "Delete a DHCP reservation from a private network",
 	ArgsUsage:       "<network-id> <ip>",
-	Action:          handlePNReservationsDelete,
+	Action:          confirm(destructive{"delete the DHCP reservation of %[2]s in private network %[1]s", "/bareMetals/v2/privateNetworks/%s", networkDetails}, handlePNReservationsDelete),
 	HideHelpCommand: true,
 }
 
@@ -64,11 +64,9 @@ func handleRMProfileGet(ctx context.Context, cmd *cli.Command) error {
 }
 
 var rmChangeCredentialsCmd = cli.Command{
-	Name:  "change-credentials",
-	Usage: "Change OpenVPN credentials",
-	Flags: []cli.Flag{
-		&cli.StringFlag{Name: "payload", Usage: "JSON payload", Required: true},
-	},
+	Name:            "change-credentials",
+	Usage:           "Change OpenVPN credentials",
+	Flags:           payloadFlags("JSON payload"),
 	Action:          handleRMChangeCredentials,
 	HideHelpCommand: true,
 }
@@ -78,7 +76,11 @@ func handleRMChangeCredentials(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	res, err := client.PostJSON(ctx, "/bareMetals/v2/remoteManagement/changeCredentials", []byte(cmd.String("payload")))
+	body, err := loadPayload(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := client.PostJSON(ctx, "/bareMetals/v2/remoteManagement/changeCredentials", body)
 	if err != nil {
 		return err
 	}
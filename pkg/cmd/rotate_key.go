@@ -0,0 +1,259 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/tidwall/gjson"
+	"github.com/urfave/cli/v3"
+)
+
+var configRotateKeyCmd = cli.Command{
+	Name:  "rotate-key",
+	Usage: "Replace the API key of a profile with a new one and revoke the old key",
+	Description: "Creates a key with the name and capabilities of the current one, validates it, stores it\n" +
+		"where the profile reads its key from and then revokes the old key. If a step fails, the\n" +
+		"new key is deleted and the old one is put back; only a failure to revoke the old key keeps\n" +
+		"the new one, and names the old key to revoke by hand. If the API does not return the new\n" +
+		"key in a field lw knows, nothing is stored or revoked and the new key's ID is printed.\n\n" +
+		"A profile reading its key from a command needs --store-command, which is given the new key\n" +
+		"on stdin:\n\n" +
+		"   lw -p us config rotate-key --store-command 'pass insert -m leaseweb/us' --grace 10m",
+	Flags: []cli.Flag{
+		&cli.DurationFlag{Name: "grace", Usage: "Keep the old key valid for this long after the new one is stored, e.g. 10m"},
+		&cli.StringFlag{Name: "store-command", Usage: "Store the new key by piping it to `COMMAND`; required when the key comes from api_key_command"},
+	},
+	Action:          handleConfigRotateKey,
+	HideHelpCommand: true,
+}
+
+// rotatedKeyFields are the fields of an API key copied to its replacement.
+var rotatedKeyFields = []string{"name", "reference", "description", "capabilities", "ipWhitelist", "whiteListedIps"}
+
+// newKeyFields are the fields of a create response that may hold the new key.
+var newKeyFields = []string{"apiKey", "key", "secret"}
+
+func handleConfigRotateKey(ctx context.Context, cmd *cli.Command) error {
+	if cmd.Root().String("dry-run") != "" {
+		return fmt.Errorf("config rotate-key cannot be used with --dry-run")
+	}
+	name, err := resolveProfile(cmd)
+	if err != nil {
+		return err
+	}
+	cfg := loadConfig()
+	p, ok := cfg.Profiles[name]
+	if !ok {
+		return fmt.Errorf("profile %q not found", name)
+	}
+	oldKey, err := profileAPIKey(name, p)
+	if err != nil {
+		return err
+	}
+	if oldKey == "" {
+		return fmt.Errorf("%w for profile %q. Run 'lw config init --profile %s'", ErrNoAPIKey, name, name)
+	}
+	store, err := newKeyStore(cmd, name, p, oldKey)
+	if err != nil {
+		return err
+	}
+
+	settings := cfg.Settings.merge(p.Settings)
+	oldClient, err := newClient(cmd, name, settings, func() (string, error) { return oldKey, nil })
+	if err != nil {
+		return err
+	}
+	info, err := validateAPIKey(ctx, oldClient, oldKey)
+	if err != nil {
+		return fmt.Errorf("the current API key of profile %q failed validation: %w", name, err)
+	}
+	oldID := firstString(info, "id", "apiKeyId")
+	if oldID == "" {
+		return fmt.Errorf("the API did not return the ID of the current key")
+	}
+	current, err := oldClient.Get(ctx, "/auth/v2/apiKeys/"+oldID)
+	if err != nil {
+		return err
+	}
+	created, err := oldClient.PostJSON(ctx, "/auth/v2/apiKeys", newKeyBody(current))
+	if err != nil {
+		return fmt.Errorf("creating the new key: %w", err)
+	}
+	newID := firstString(created, "id", "apiKeyId")
+	fmt.Fprintf(os.Stderr, "Created API key %s\n", newID)
+
+	stored := false
+	// rollback runs its requests even when ctx was cancelled, e.g. by Ctrl-C
+	// during --grace, so that the new key is not left behind.
+	rollback := func(err error) error {
+		rbCtx := context.WithoutCancel(ctx)
+		var problems []string
+		if stored {
+			if rbErr := store.restore(); rbErr != nil {
+				problems = append(problems, fmt.Sprintf("restoring the old key in %s: %v", store.location, rbErr))
+			}
+		}
+		if newID != "" {
+			if _, rbErr := oldClient.Delete(rbCtx, "/auth/v2/apiKeys/"+newID); rbErr != nil {
+				problems = append(problems, fmt.Sprintf("deleting the new key %s: %v", newID, rbErr))
+			}
+		}
+		if len(problems) > 0 {
+			return fmt.Errorf("%w; rolling back failed: %s", err, strings.Join(problems, "; "))
+		}
+		return fmt.Errorf("%w; rolled back, profile %q still uses the old key", err, name)
+	}
+
+	// The key endpoints are not in the published API spec, so the new key is
+	// looked for in the fields they are known to use. If none holds it, lw
+	// cannot tell whether the key was created as intended: nothing is
+	// stored or revoked, and the created key is left to check by hand.
+	newKey := firstString(created, newKeyFields...)
+	if newKey == "" {
+		return fmt.Errorf("the API created key %s but returned it in none of the fields %s; nothing was stored or revoked, check the key and delete it with 'lw -p %s api-keys delete %s'",
+			newID, strings.Join(newKeyFields, ", "), name, newID)
+	}
+	newKeyClient, err := newClient(cmd, name, settings, func() (string, error) { return newKey, nil })
+	if err != nil {
+		return rollback(err)
+	}
+	if _, err := validateAPIKey(ctx, newKeyClient, newKey); err != nil {
+		return rollback(fmt.Errorf("validating the new key: %w", err))
+	}
+
+	stored = true
+	if err := store.save(newKey); err != nil {
+		return rollback(fmt.Errorf("storing the new key in %s: %w", store.location, err))
+	}
+	fmt.Fprintf(os.Stderr, "Stored the new key in %s\n", store.location)
+
+	if grace := cmd.Duration("grace"); grace > 0 {
+		fmt.Fprintf(os.Stderr, "Waiting %s before revoking the old key...\n", grace)
+		select {
+		case <-ctx.Done():
+			return rollback(ctx.Err())
+		case <-time.After(grace):
+		}
+	}
+	// The new key is valid and stored, so it is kept even when revoking the
+	// old one fails: a retried DELETE may report an error for a revocation
+	// that went through, and rolling back would then leave no valid key.
+	if _, err := newKeyClient.Delete(ctx, "/auth/v2/apiKeys/"+oldID); err != nil {
+		return fmt.Errorf("revoking the old key %s: %w; profile %q now uses the new key %s, revoke the old key with 'lw -p %s api-keys delete %s'",
+			oldID, err, name, newID, name, oldID)
+	}
+
+	fmt.Printf("Rotated the API key of profile %q: %s replaced %s\n", name, newID, oldID)
+	return nil
+}
+
+// newKeyBody returns the request body creating a key like current.
+func newKeyBody(current gjson.Result) []byte {
+	fields := map[string]json.RawMessage{}
+	for _, f := range rotatedKeyFields {
+		if v := current.Get(f); v.Exists() {
+			fields[f] = json.RawMessage(v.Raw)
+		}
+	}
+	b, _ := json.Marshal(fields)
+	return b
+}
+
+// keyStore writes an API key to where a profile reads it from, and puts the
+// previous one back.
+type keyStore struct {
+	location string
+	save     func(key string) error
+	restore  func() error
+}
+
+// newKeyStore returns the key store for profile name, whose key is oldKey. Keys from environment
+// variables cannot be written, and keys from commands need --store-command.
+func newKeyStore(cmd *cli.Command, name string, p ProfileConfig, oldKey string) (*keyStore, error) {
+	switch sources := p.keySources(); sources[0] {
+	case keySourceConfig:
+		setKey := func(key string) error {
+			cfg := loadConfig()
+			q := cfg.Profiles[name]
+			q.APIKey = key
+			cfg.Profiles[name] = q
+			return writeConfig(cfg)
+		}
+		return &keyStore{
+			location: getConfigPath(),
+			save:     setKey,
+			restore:  func() error { return setKey(p.APIKey) },
+		}, nil
+
+	case keySourceFile:
+		path := expandHome(p.APIKeyFile)
+		old, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		return &keyStore{
+			location: path,
+			save:     func(key string) error { return writeKeyFile(path, []byte(key+"\n")) },
+			restore:  func() error { return writeKeyFile(path, old) },
+		}, nil
+
+	case keySourceCommand:
+		storeCommand := cmd.String("store-command")
+		if storeCommand == "" {
+			return nil, fmt.Errorf("profile %q reads its API key from %q; pass --store-command with a command that stores the key it reads from stdin, e.g. --store-command 'pass insert -m leaseweb/%s'", name, p.APIKeyCommand, name)
+		}
+		return &keyStore{
+			location: fmt.Sprintf("%q", storeCommand),
+			save: func(key string) error {
+				if err := runStoreCommand(storeCommand, key); err != nil {
+					return err
+				}
+				if got, err := keyFromCommand(p.APIKeyCommand); err != nil || got != key {
+					return fmt.Errorf("%q does not print the new key after it was stored", p.APIKeyCommand)
+				}
+				return nil
+			},
+			restore: func() error { return runStoreCommand(storeCommand, oldKey) },
+		}, nil
+	}
+	return nil, fmt.Errorf("profile %q reads its API key from $%s, which lw cannot update; switch it to api_key, api_key_file or api_key_command first", name, p.APIKeyEnv)
+}
+
+// runStoreCommand runs command with key on its stdin.
+func runStoreCommand(command, key string) error {
+	c := shellCommand(command)
+	c.Stdin = strings.NewReader(key + "\n")
+	c.Stdout = os.Stderr
+	c.Stderr = os.Stderr
+	if err := c.Run(); err != nil {
+		return fmt.Errorf("%q failed: %w", command, err)
+	}
+	return nil
+}
+
+// writeKeyFile replaces the key file at path with data, readable only by
+// its owner.
+func writeKeyFile(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".lw-key-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if err := tmp.Chmod(0600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigRotateKey(t *testing.T) {
+	keys := map[string]string{"old-key": "k1"}
+	var created []string
+	var deleted []string
+	validNewKey := true
+	revokeFails := false
+	keyField := "apiKey"
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"POST /auth/v2/apiKeys/validate": func(w http.ResponseWriter, r *http.Request) {
+			var body struct {
+				APIKey string `json:"apiKey"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			id, ok := keys[body.APIKey]
+			if !ok || (body.APIKey != "old-key" && !validNewKey) {
+				jsonResponse(w, 401, map[string]any{"errorMessage": "Invalid API key"})
+				return
+			}
+			jsonResponse(w, 200, map[string]any{"id": id})
+		},
+		"GET /auth/v2/apiKeys/k1": func(w http.ResponseWriter, r *http.Request) {
+			jsonResponse(w, 200, map[string]any{"id": "k1", "name": "ci", "capabilities": []string{"servers.read"}, "createdAt": "2024-01-01"})
+		},
+		"POST /auth/v2/apiKeys": func(w http.ResponseWriter, r *http.Request) {
+			b, _ := io.ReadAll(r.Body)
+			created = append(created, string(b))
+			id := fmt.Sprintf("k%d", len(created)+1)
+			key := fmt.Sprintf("new-key-%d", len(created))
+			keys[key] = id
+			jsonResponse(w, 201, map[string]any{"id": id, keyField: key})
+		},
+		"DELETE /auth/v2/apiKeys/{id}": func(w http.ResponseWriter, r *http.Request) {
+			deleted = append(deleted, r.PathValue("id")+" by "+r.Header.Get("X-LSW-Auth"))
+			if revokeFails && r.PathValue("id") == "k1" {
+				jsonResponse(w, 500, map[string]any{"errorMessage": "Internal error"})
+				return
+			}
+			w.WriteHeader(204)
+		},
+	})
+	defer srv.Close()
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".config", "lw"), 0700))
+	configPath := filepath.Join(dir, ".config", "lw", "config.yaml")
+	keyPath := filepath.Join(dir, "key")
+	require.NoError(t, os.WriteFile(keyPath, []byte("old-key\n"), 0600))
+	require.NoError(t, os.WriteFile(configPath, []byte(fmt.Sprintf(`default_profile: us
+profiles:
+  us:
+    api_key: old-key # rotated by lw
+  ca:
+    api_key_file: %s
+  eu:
+    api_key_env: EU_KEY
+`, keyPath)), 0600))
+
+	stdout, _, err := runCLI(t, srv.URL, []string{"config", "rotate-key"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout, `Rotated the API key of profile "us": k2 replaced k1`)
+	assert.JSONEq(t, `{"name":"ci","capabilities":["servers.read"]}`, created[0])
+	assert.Equal(t, []string{"k1 by new-key-1"}, deleted)
+	b, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "api_key: new-key-1 # rotated by lw")
+
+	// The new key fails validation: it is deleted and the file is kept.
+	validNewKey = false
+	deleted = nil
+	_, _, err = runCLI(t, srv.URL, []string{"config", "rotate-key", "--profile", "ca"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "validating the new key")
+	assert.Contains(t, err.Error(), "rolled back")
+	assert.Equal(t, []string{"k3 by old-key"}, deleted)
+	b, err = os.ReadFile(keyPath)
+	require.NoError(t, err)
+	assert.Equal(t, "old-key\n", string(b))
+
+	validNewKey = true
+	deleted = nil
+	_, _, err = runCLI(t, srv.URL, []string{"-p", "ca", "config", "rotate-key"})
+	require.NoError(t, err)
+	b, err = os.ReadFile(keyPath)
+	require.NoError(t, err)
+	assert.Equal(t, "new-key-3\n", string(b))
+	assert.Equal(t, []string{"k1 by new-key-3"}, deleted)
+
+	// Revoking the old key fails once the new one is stored: the new key is
+	// kept and the old one is named for revoking by hand.
+	revokeFails = true
+	deleted = nil
+	require.NoError(t, os.WriteFile(keyPath, []byte("old-key\n"), 0600))
+	_, _, err = runCLI(t, srv.URL, []string{"-p", "ca", "--max-retries", "0", "config", "rotate-key"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `revoking the old key k1`)
+	assert.Contains(t, err.Error(), `revoke the old key with 'lw -p ca api-keys delete k1'`)
+	assert.Equal(t, []string{"k1 by new-key-4"}, deleted)
+	b, err = os.ReadFile(keyPath)
+	require.NoError(t, err)
+	assert.Equal(t, "new-key-4\n", string(b))
+	revokeFails = false
+
+	// Ctrl-C during --grace: the old key is put back and the new one deleted
+	// although the context is cancelled.
+	deleted = nil
+	require.NoError(t, os.WriteFile(keyPath, []byte("old-key\n"), 0600))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		for ctx.Err() == nil {
+			if b, _ := os.ReadFile(keyPath); string(b) == "new-key-5\n" {
+				cancel()
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}()
+	_, _, err = runCLIContext(t, ctx, srv.URL, []string{"-p", "ca", "config", "rotate-key", "--grace", "1h"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "context canceled; rolled back")
+	assert.Equal(t, []string{"k6 by old-key"}, deleted)
+	b, err = os.ReadFile(keyPath)
+	require.NoError(t, err)
+	assert.Equal(t, "old-key\n", string(b))
+
+	// The new key comes back in an unknown field: nothing is stored, revoked
+	// or deleted, and the created key is named.
+	keyField = "token"
+	deleted = nil
+	_, _, err = runCLI(t, srv.URL, []string{"-p", "ca", "config", "rotate-key"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "the API created key k7 but returned it in none of the fields")
+	assert.Contains(t, err.Error(), "'lw -p ca api-keys delete k7'")
+	assert.Empty(t, deleted)
+	b, err = os.ReadFile(keyPath)
+	require.NoError(t, err)
+	assert.Equal(t, "old-key\n", string(b))
+	keyField = "apiKey"
+
+	_, _, err = runCLI(t, srv.URL, []string{"-p", "eu", "config", "rotate-key"})
+	require.Error(t, err)
+}
@@ -0,0 +1,241 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/tidwall/pretty"
+	"github.com/urfave/cli/v3"
+)
+
+var schemaCmd = cli.Command{
+	Name:  "schema",
+	Usage: "Show the request body a command expects",
+	Description: `Prints the fields of the JSON request body sent by a command, as described
+by the Leaseweb OpenAPI spec, followed by an example body.
+
+Payloads passed with --payload or --data are checked against the same
+schema before they are sent; use --skip-validation to send them anyway.
+
+Examples:
+  lw schema domains dns-update-all
+  lw schema api-keys replace-whitelisted-ip-list
+  lw -o json schema ds lease-create`,
+	ArgsUsage:       "<command> [<subcommand>...]",
+	Action:          handleSchema,
+	HideHelpCommand: true,
+}
+
+func handleSchema(_ context.Context, cmd *cli.Command) error {
+	args := cmd.Args().Slice()
+	if len(args) == 0 {
+		return fmt.Errorf("command required\nUsage: lw schema <command> [<subcommand>...]")
+	}
+	target := cmd.Root()
+	for _, name := range args {
+		target = findCommand(target.Commands, name)
+		if target == nil {
+			return fmt.Errorf("unknown command 'lw %s'", strings.Join(args, " "))
+		}
+	}
+
+	ops := bodyOperations(target)
+	if len(ops) == 0 {
+		return fmt.Errorf("no request body schema is known for 'lw %s'", strings.Join(args, " "))
+	}
+
+	format := cmd.Root().String("output")
+	if strings.ToLower(format) != "auto" {
+		docs := make([]map[string]any, len(ops))
+		for i, op := range ops {
+			docs[i] = map[string]any{
+				"operation": op.ID,
+				"method":    op.Method,
+				"path":      op.Path,
+				"schema":    json.RawMessage(op.Schema),
+				"example":   json.RawMessage(exampleBody(op)),
+			}
+		}
+		var out any = docs
+		if len(docs) == 1 {
+			out = docs[0]
+		}
+		b, err := json.Marshal(out)
+		if err != nil {
+			return err
+		}
+		return ShowJSON(os.Stdout, string(b), format, cmd.Root().String("transform"))
+	}
+
+	for i, op := range ops {
+		if i > 0 {
+			fmt.Println()
+		}
+		showSchema(op)
+	}
+	return nil
+}
+
+// bodyOperations returns the spec operations with a request body schema
+// that cmd calls.
+func bodyOperations(cmd *cli.Command) []*specOperation {
+	var ids []string
+	if op, ok := generatedOperations[cmd]; ok {
+		ids = []string{op.ID}
+	} else {
+		ids = commandOperations[cmd]
+	}
+	var ops []*specOperation
+	for _, op := range specOperations {
+		if op.Schema != "" && slices.Contains(ids, op.ID) {
+			ops = append(ops, op)
+		}
+	}
+	return ops
+}
+
+func showSchema(op *specOperation) {
+	fmt.Printf("%s %s (%s)\n\n", op.Method, op.Path, op.ID)
+
+	var schema map[string]any
+	_ = json.Unmarshal([]byte(op.Schema), &schema)
+	table := NewTableWriter(os.Stdout, "FIELD", "TYPE", "REQUIRED", "DESCRIPTION")
+	table.TruncOrder = []int{3}
+	for _, f := range schemaFields(schema, "") {
+		required := ""
+		if f.required {
+			required = "yes"
+		}
+		table.AddRow(f.path, f.typ, required, f.description)
+	}
+	table.Render()
+
+	fmt.Println()
+	fmt.Println("Example:")
+	os.Stdout.Write(pretty.Pretty([]byte(exampleBody(op))))
+}
+
+type schemaField struct {
+	path        string
+	typ         string
+	required    bool
+	description string
+}
+
+// schemaFields flattens the properties of an object schema into dotted
+// paths, with [] marking array items.
+func schemaFields(s map[string]any, prefix string) []schemaField {
+	props := map[string]map[string]any{}
+	var required []string
+	for _, sub := range append([]map[string]any{s}, schemaList(s["allOf"])...) {
+		p, _ := sub["properties"].(map[string]any)
+		for name, v := range p {
+			if ps, ok := v.(map[string]any); ok {
+				props[name] = ps
+			}
+		}
+		required = append(required, stringList(sub["required"])...)
+	}
+	names := make([]string, 0, len(props))
+	for name := range props {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var fields []schemaField
+	for _, name := range names {
+		p := props[name]
+		path := prefix + name
+		desc, _ := p["description"].(string)
+		desc, _, _ = strings.Cut(strings.TrimSpace(desc), "\n")
+		if enum, ok := p["enum"].([]any); ok {
+			desc = strings.TrimSpace(desc + " (one of: " + formatEnum(enum) + ")")
+		}
+		fields = append(fields, schemaField{
+			path:        path,
+			typ:         schemaType(p),
+			required:    slices.Contains(required, name),
+			description: desc,
+		})
+		switch {
+		case p["properties"] != nil || p["allOf"] != nil:
+			fields = append(fields, schemaFields(p, path+".")...)
+		case p["items"] != nil:
+			if items, ok := p["items"].(map[string]any); ok {
+				fields = append(fields, schemaFields(items, path+"[].")...)
+			}
+		}
+	}
+	return fields
+}
+
+func schemaType(s map[string]any) string {
+	t := "any"
+	if v, ok := s["type"]; ok {
+		t = typeNames(v)
+	}
+	if t == "array" {
+		if items, ok := s["items"].(map[string]any); ok && items["type"] != nil {
+			t = "[]" + typeNames(items["type"])
+		}
+	}
+	if s["nullable"] == true {
+		t += "|null"
+	}
+	return t
+}
+
+// exampleBody returns the spec's example for op, or one built from its
+// schema when the spec has none.
+func exampleBody(op *specOperation) string {
+	if op.Example != "" {
+		return op.Example
+	}
+	var schema map[string]any
+	_ = json.Unmarshal([]byte(op.Schema), &schema)
+	b, _ := json.Marshal(exampleValue(schema))
+	return string(b)
+}
+
+func exampleValue(s map[string]any) any {
+	if v, ok := s["default"]; ok {
+		return v
+	}
+	if enum, ok := s["enum"].([]any); ok && len(enum) > 0 {
+		return enum[0]
+	}
+	for _, key := range []string{"anyOf", "oneOf"} {
+		if branches := schemaList(s[key]); len(branches) > 0 {
+			return exampleValue(branches[0])
+		}
+	}
+	t, _ := s["type"].(string)
+	switch {
+	case t == "object" || s["properties"] != nil || s["allOf"] != nil:
+		obj := map[string]any{}
+		for _, sub := range append([]map[string]any{s}, schemaList(s["allOf"])...) {
+			props, _ := sub["properties"].(map[string]any)
+			for name, v := range props {
+				if ps, ok := v.(map[string]any); ok {
+					obj[name] = exampleValue(ps)
+				}
+			}
+		}
+		return obj
+	case t == "array":
+		items, _ := s["items"].(map[string]any)
+		return []any{exampleValue(items)}
+	case t == "string":
+		return "string"
+	case t == "integer" || t == "number":
+		return 0
+	case t == "boolean":
+		return false
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaCommand(t *testing.T) {
+	stdout, _, err := runCLI(t, "", []string{"schema", "domains", "dns-update-all"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "PUT /hosting/v2/domains/{domainName}/resourceRecordSets (updateResourceRecordSets)")
+	assert.Contains(t, stdout, "resourceRecordSets[].ttl")
+	assert.Contains(t, stdout, "Example:")
+
+	stdout, _, err = runCLI(t, "", []string{"-o", "raw", "--transform", "operation", "schema", "api-keys", "replace-whitelisted-ip-list"})
+	require.NoError(t, err)
+	assert.Equal(t, `"replaceWhitelistedIpList"`+"\n", stdout)
+
+	_, _, err = runCLI(t, "", []string{"schema", "ds", "get"})
+	assert.ErrorContains(t, err, "no request body schema is known")
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/kernel/leaseweb-cli/pkg/leaseweb"
 	"github.com/tidwall/gjson"
 	"github.com/urfave/cli/v3"
 )
@@ -25,56 +26,34 @@ var servicesCmd = cli.Command{
 }
 
 var servicesListCmd = cli.Command{
-	Name:  "list",
-	Usage: "List services",
-	Flags: PaginationFlags,
+	Name:            "list",
+	Usage:           "List services",
+	Flags:           PaginationFlags,
 	Action:          handleServicesList,
 	HideHelpCommand: true,
 }
 
 func handleServicesList(ctx context.Context, cmd *cli.Command) error {
-	client, err := NewClient(cmd)
-	if err != nil {
-		return err
-	}
-	res, err := client.Get(ctx, "/services/v1/services?"+PaginationQuery(cmd))
-	if err != nil {
-		return err
-	}
-
-	format := cmd.Root().String("output")
-	if format != "auto" {
-		return ShowResult(os.Stdout, res, format, cmd.Root().String("transform"))
-	}
-
-	services := res.Get("services")
-	if !services.Exists() || len(services.Array()) == 0 {
-		fmt.Fprintln(os.Stderr, "No services found.")
-		return nil
-	}
-
-	table := NewTableWriter(os.Stdout, "ID", "REFERENCE", "PRODUCT", "STATUS", "START DATE", "END DATE")
-	table.TruncOrder = []int{2, 1}
-	services.ForEach(func(_, svc gjson.Result) bool {
-		table.AddRow(
-			svc.Get("id").String(),
-			svc.Get("reference").String(),
-			svc.Get("productId").String(),
-			svc.Get("status").String(),
-			svc.Get("startDate").String(),
-			svc.Get("endDate").String(),
-		)
-		return true
+	return runList(ctx, cmd, func(ctx context.Context, client *Client) ([]leaseweb.Service, gjson.Result, error) {
+		return ListPages(ctx, cmd, func(opts leaseweb.ListOptions) (*leaseweb.Page[leaseweb.Service], error) {
+			return client.API().Services.ListServices(ctx, &opts)
+		})
+	}, listView[leaseweb.Service]{
+		empty:      "No services found.",
+		headers:    []string{"ID", "REFERENCE", "PRODUCT", "STATUS", "START DATE", "END DATE"},
+		truncOrder: []int{2, 1},
+		columns:    []string{"id", "reference", "productId", "status", "startDate", "endDate"},
+		row: func(svc leaseweb.Service) []string {
+			return []string{svc.ID, svc.Reference, svc.ProductID, svc.Status, svc.StartDate, svc.EndDate}
+		},
 	})
-	table.Render()
-	return nil
 }
 
 var servicesGetCmd = cli.Command{
-	Name:      "get",
-	Usage:     "Get service details",
-	ArgsUsage: "<service-id>",
-	Action:    handleServicesGet,
+	Name:            "get",
+	Usage:           "Get service details",
+	ArgsUsage:       "<service-id>",
+	Action:          handleServicesGet,
 	HideHelpCommand: true,
 }
 
@@ -132,7 +111,7 @@ var servicesCancelCmd = cli.Command{
 		&cli.StringFlag{Name: "reason", Usage: "Cancellation reason", Required: true},
 		&cli.StringFlag{Name: "reason-detail", Usage: "Cancellation reason detail"},
 	},
-	Action:          handleServicesCancel,
+	Action:          confirm(destructive{"cancel service %s", "/services/v1/services/%s", serviceDetails}, handleServicesCancel),
 	HideHelpCommand: true,
 }
 
@@ -161,10 +140,10 @@ func handleServicesCancel(ctx context.Context, cmd *cli.Command) error {
 }
 
 var servicesUncancelCmd = cli.Command{
-	Name:      "uncancel",
-	Usage:     "Uncancel a service",
-	ArgsUsage: "<service-id>",
-	Action:    handleServicesUncancel,
+	Name:            "uncancel",
+	Usage:           "Uncancel a service",
+	ArgsUsage:       "<service-id>",
+	Action:          handleServicesUncancel,
 	HideHelpCommand: true,
 }
 
@@ -177,7 +156,7 @@ func handleServicesUncancel(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	_, err = client.Post(ctx, "/services/v1/services/"+args[0]+"/uncancel", "")
+	err = client.API().Services.UncancelService(ctx, args[0])
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,242 @@
+package cmd
+
+//go:generate go run ../../internal/specgen -spec ../../docs/leaseweb-openapi.json -out spec_gen.go -cmd . -sdk ../leaseweb -coverage ../../docs/api-coverage.md
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+)
+
+// specOperation is an operation of the OpenAPI spec, as generated into
+// spec_gen.go.
+type specOperation struct {
+	ID           string
+	Tag          string
+	Group        string
+	Name         string
+	Summary      string
+	Method       string
+	Path         string
+	PathParams   []string
+	Query        []specParam
+	Body         bool
+	BodyRequired bool
+	// Schema is the JSON schema of the request body with references
+	// resolved.
+	Schema string
+	// Example is the spec's example request body, if any.
+	Example string
+	// Status is the first success status of the operation and Response
+	// the spec's example body for it, if any.
+	Status   int
+	Response string
+}
+
+// specParam is a query parameter of an operation.
+type specParam struct {
+	Name        string
+	Flag        string
+	Type        string
+	Description string
+	Required    bool
+	Enum        []string
+}
+
+// generatedOperations maps the commands built by addGeneratedCommands to
+// their operation.
+var generatedOperations = map[*cli.Command]*specOperation{}
+
+// addGeneratedCommands adds a command for every spec operation that no
+// handwritten command calls. Operations are grouped by their spec tag,
+// joining the handwritten top-level command of the same name when there is
+// one; handwritten subcommands win on name clashes.
+func addGeneratedCommands(root *cli.Command) {
+	covered := map[string]bool{}
+	for _, ids := range commandOperations {
+		for _, id := range ids {
+			covered[id] = true
+		}
+	}
+
+	for _, op := range specOperations {
+		if covered[op.ID] {
+			continue
+		}
+		group := findCommand(root.Commands, op.Group)
+		if group == nil {
+			group = &cli.Command{
+				Name:            op.Group,
+				Usage:           op.Tag + " API",
+				HideHelpCommand: true,
+			}
+			root.Commands = append(root.Commands, group)
+		}
+		if findCommand(group.Commands, op.Name) != nil {
+			continue
+		}
+		c := operationCommand(op)
+		generatedOperations[c] = op
+		group.Commands = append(group.Commands, c)
+	}
+
+	slices.SortStableFunc(root.Commands, func(a, b *cli.Command) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+}
+
+func findCommand(cmds []*cli.Command, name string) *cli.Command {
+	for _, c := range cmds {
+		if c.Name == name || slices.Contains(c.Aliases, name) {
+			return c
+		}
+	}
+	return nil
+}
+
+// operationCommand builds the command for a spec operation. Path parameters
+// are positional arguments and query parameters are flags.
+func operationCommand(op *specOperation) *cli.Command {
+	var args []string
+	for _, p := range op.PathParams {
+		args = append(args, "<"+p+">")
+	}
+
+	var flags []cli.Flag
+	for _, q := range op.Query {
+		usage := q.Description
+		if len(q.Enum) > 0 {
+			usage += " (one of: " + strings.Join(q.Enum, ", ") + ")"
+		}
+		flags = append(flags, &cli.StringFlag{
+			Name:     q.Flag,
+			Usage:    strings.TrimSpace(usage),
+			Required: q.Required,
+		})
+	}
+	if op.Body {
+		flags = append(flags, bodyFlags(&cli.StringFlag{
+			Name:    "data",
+			Aliases: []string{"d"},
+			Usage:   "JSON request body",
+		})...)
+	}
+
+	action := operationAction(op)
+	if op.destructive() {
+		action = confirm(op.confirmation(), action)
+	}
+	return &cli.Command{
+		Name:            op.Name,
+		Usage:           op.Summary,
+		Description:     fmt.Sprintf("%s %s", op.Method, op.Path),
+		ArgsUsage:       strings.Join(args, " "),
+		Flags:           flags,
+		Action:          action,
+		HideHelpCommand: true,
+	}
+}
+
+// destructiveActions are the last path segments of POST operations that
+// wipe, stop or cut off a resource.
+var destructiveActions = []string{"null", "install", "reinstall", "terminate", "cancel", "powerOff"}
+
+// destructive reports whether op deletes or wipes a resource, so that its
+// command asks for confirmation.
+func (op *specOperation) destructive() bool {
+	if op.Method == http.MethodDelete {
+		return true
+	}
+	action := op.Path[strings.LastIndex(op.Path, "/")+1:]
+	return op.Method == http.MethodPost && slices.Contains(destructiveActions, action)
+}
+
+// confirmation describes op for the confirmation prompt by its summary and
+// path parameters, e.g. "delete a distribution (distribution_id 123)".
+func (op *specOperation) confirmation() destructive {
+	what := strings.ReplaceAll(strings.TrimSuffix(op.Summary, "."), "%", "%%")
+	if what == "" {
+		what = op.Method + " " + op.Path
+	} else {
+		what = strings.ToLower(what[:1]) + what[1:]
+	}
+	params := make([]string, len(op.PathParams))
+	for i, p := range op.PathParams {
+		params[i] = p + " %s"
+	}
+	if len(params) > 0 {
+		what += " (" + strings.Join(params, ", ") + ")"
+	}
+	return destructive{action: what}
+}
+
+func operationAction(op *specOperation) cli.ActionFunc {
+	return func(ctx context.Context, cmd *cli.Command) error {
+		path, err := op.expandPath(cmd.Args().Slice())
+		if err != nil {
+			return err
+		}
+		if q := op.query(cmd); len(q) > 0 {
+			path += "?" + q.Encode()
+		}
+
+		var body []byte
+		if op.Body {
+			body, err = loadBody(cmd, "data", os.Stdin)
+			if err != nil {
+				return err
+			}
+			if body == nil && op.BodyRequired {
+				return fmt.Errorf("request body required (use --data or --set)")
+			}
+		}
+		if body != nil && !cmd.Root().Bool("skip-validation") {
+			if err := validateBody(op, body); err != nil {
+				return err
+			}
+		}
+
+		client, err := NewClient(cmd)
+		if err != nil {
+			return err
+		}
+		resp, err := client.send(ctx, op.Method, path, body, nil)
+		if err != nil {
+			return err
+		}
+		return showResponseBody(cmd, resp.Body)
+	}
+}
+
+// expandPath fills the path template with the positional arguments.
+func (op *specOperation) expandPath(args []string) (string, error) {
+	if len(args) != len(op.PathParams) {
+		usage := op.Group + " " + op.Name
+		for _, p := range op.PathParams {
+			usage += " <" + p + ">"
+		}
+		return "", fmt.Errorf("expected %d argument(s), got %d\nUsage: lw %s", len(op.PathParams), len(args), usage)
+	}
+	path := op.Path
+	for i, p := range op.PathParams {
+		path = strings.Replace(path, "{"+p+"}", url.PathEscape(args[i]), 1)
+	}
+	return path, nil
+}
+
+// query collects the query parameters set through flags.
+func (op *specOperation) query(cmd *cli.Command) url.Values {
+	q := url.Values{}
+	for _, p := range op.Query {
+		if cmd.IsSet(p.Flag) {
+			q.Set(p.Name, cmd.String(p.Flag))
+		}
+	}
+	return q
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneratedCommands(t *testing.T) {
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"GET /apiKeys/v1/keys": func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "5", r.URL.Query().Get("limit"))
+			assert.False(t, r.URL.Query().Has("offset"))
+			jsonResponse(w, 200, map[string]any{"apiKeys": []any{}})
+		},
+		"PUT /apiKeys/v1/keys/abc/whiteListedIps": func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, map[string]any{"whiteListedIps": []any{"1.2.3.4"}}, body)
+			jsonResponse(w, 200, body)
+		},
+	})
+	defer srv.Close()
+
+	_, _, err := runCLI(t, srv.URL, []string{"-o", "raw", "api-keys", "get-api-key-list", "--limit", "5"})
+	require.NoError(t, err)
+
+	stdout, _, err := runCLI(t, srv.URL, []string{
+		"-o", "raw", "api-keys", "replace-whitelisted-ip-list", "abc",
+		"--data", `{"whiteListedIps":["1.2.3.4"]}`,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `{"whiteListedIps":["1.2.3.4"]}`+"\n", stdout)
+
+	_, _, err = runCLI(t, srv.URL, []string{"api-keys", "replace-whitelisted-ip-list"})
+	assert.ErrorContains(t, err, "expected 1 argument(s), got 0")
+}
@@ -108,12 +108,10 @@ func handleStorageVolumes(ctx context.Context, cmd *cli.Command) error {
 }
 
 var storageVolumeGrowCmd = cli.Command{
-	Name:      "volume-grow",
-	Usage:     "Grow a storage volume",
-	ArgsUsage: "<storage-vm-id> <volume-id>",
-	Flags: []cli.Flag{
-		&cli.StringFlag{Name: "payload", Usage: "JSON payload", Required: true},
-	},
+	Name:            "volume-grow",
+	Usage:           "Grow a storage volume",
+	ArgsUsage:       "<storage-vm-id> <volume-id>",
+	Flags:           payloadFlags("JSON payload"),
 	Action:          handleStorageVolumeGrow,
 	HideHelpCommand: true,
 }
@@ -127,7 +125,11 @@ func handleStorageVolumeGrow(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	res, err := client.PostJSON(ctx, fmt.Sprintf("/storage/v1/storageVMs/%s/volumes/%s/grow", args[0], args[1]), []byte(cmd.String("payload")))
+	body, err := loadPayload(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := client.PostJSON(ctx, fmt.Sprintf("/storage/v1/storageVMs/%s/volumes/%s/grow", args[0], args[1]), body)
 	if err != nil {
 		return err
 	}
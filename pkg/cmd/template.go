@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/tidwall/gjson"
+)
+
+// Besides the formats in OutputFormats, --output takes a format with an
+// argument: go-template=TEMPLATE and template-file=FILE render the result
+// with text/template, and custom-columns=HEADER:PATH,... shows a table of
+// the given fields.
+var templateFormats = []string{"go-template", "template-file", "custom-columns"}
+
+// checkOutputFormat returns an error when format is not a valid --output
+// value.
+func checkOutputFormat(format string) error {
+	kind, arg, ok := strings.Cut(format, "=")
+	if !ok {
+		for _, f := range OutputFormats {
+			if strings.EqualFold(format, f) {
+				return nil
+			}
+		}
+		return fmt.Errorf("output must be one of: %s, or go-template=TEMPLATE, template-file=FILE, custom-columns=HEADER:PATH,...",
+			strings.Join(OutputFormats, ", "))
+	}
+	switch strings.ToLower(kind) {
+	case "go-template":
+		_, err := parseOutputTemplate(arg)
+		return err
+	case "template-file":
+		_, err := readOutputTemplate(arg)
+		return err
+	case "custom-columns":
+		_, err := parseCustomColumns(arg)
+		return err
+	}
+	return fmt.Errorf("unknown output format %q; formats with an argument are %s", kind, strings.Join(templateFormats, ", "))
+}
+
+// showTemplated shows res in a format with an argument.
+func showTemplated(out io.Writer, res gjson.Result, kind, arg string) error {
+	switch strings.ToLower(kind) {
+	case "go-template":
+		tmpl, err := parseOutputTemplate(arg)
+		if err != nil {
+			return err
+		}
+		return executeOutputTemplate(out, tmpl, res)
+	case "template-file":
+		tmpl, err := readOutputTemplate(arg)
+		if err != nil {
+			return err
+		}
+		return executeOutputTemplate(out, tmpl, res)
+	case "custom-columns":
+		columns, err := parseCustomColumns(arg)
+		if err != nil {
+			return err
+		}
+		table := NewTableWriter(out, columnHeaders(columns)...)
+		for _, item := range delimitedItems(res) {
+			row := make([]string, len(columns))
+			for i, c := range columns {
+				if row[i] = cellValue(item.Get(c.path)); row[i] == "" {
+					row[i] = "<none>"
+				}
+			}
+			table.AddRow(row...)
+		}
+		table.Render()
+		return nil
+	}
+	return fmt.Errorf("invalid format: %s=, valid formats with an argument are: %s", kind, strings.Join(templateFormats, ", "))
+}
+
+// templateFuncs are the functions available in output templates besides
+// the text/template builtins.
+var templateFuncs = template.FuncMap{
+	// join joins the elements of a list: {{.nameServers | join ", "}}.
+	"join": func(sep string, v any) string {
+		list, ok := v.([]any)
+		if !ok {
+			return templateString(v)
+		}
+		parts := make([]string, len(list))
+		for i, e := range list {
+			parts[i] = templateString(e)
+		}
+		return strings.Join(parts, sep)
+	},
+	// default replaces a missing or empty value: {{.reference | default "-"}}.
+	"default": func(def, v any) any {
+		if v == nil {
+			return def
+		}
+		switch rv := reflect.ValueOf(v); rv.Kind() {
+		case reflect.String, reflect.Slice, reflect.Map:
+			if rv.Len() == 0 {
+				return def
+			}
+		}
+		return v
+	},
+	// timeAgo shows an RFC 3339 time relative to now, like "3 days ago".
+	"timeAgo": func(v any) string {
+		s := templateString(v)
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return s
+		}
+		return FormatTimeAgo(t)
+	},
+	// dateOnly drops the time from an RFC 3339 time.
+	"dateOnly": func(v any) string { return dateOnly(templateString(v)) },
+}
+
+// templateString renders a template value as text, with nothing for null.
+func templateString(v any) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+func parseOutputTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("output").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("parsing output template: %w", err)
+	}
+	return tmpl, nil
+}
+
+func readOutputTemplate(file string) (*template.Template, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("reading output template: %w", err)
+	}
+	return parseOutputTemplate(string(b))
+}
+
+// executeOutputTemplate renders res with tmpl. Numbers keep the digits the
+// API sent instead of becoming floats.
+func executeOutputTemplate(out io.Writer, tmpl *template.Template, res gjson.Result) error {
+	var data any
+	dec := json.NewDecoder(strings.NewReader(res.Raw))
+	dec.UseNumber()
+	if err := dec.Decode(&data); err != nil && res.Raw != "" {
+		return fmt.Errorf("decoding output: %w", err)
+	}
+	if err := tmpl.Execute(out, data); err != nil {
+		return fmt.Errorf("executing output template: %w", err)
+	}
+	return nil
+}
+
+// customColumn is a column of custom-columns output.
+type customColumn struct {
+	header string
+	path   string
+}
+
+// parseCustomColumns parses HEADER:PATH,... where each PATH is a dotted JSON
+// path into an item, optionally with a leading dot as in kubectl.
+func parseCustomColumns(spec string) ([]customColumn, error) {
+	var columns []customColumn
+	for _, part := range strings.Split(spec, ",") {
+		header, path, ok := strings.Cut(strings.TrimSpace(part), ":")
+		path = strings.TrimPrefix(strings.TrimSpace(path), ".")
+		if !ok || header == "" || path == "" {
+			return nil, fmt.Errorf("custom-columns must be HEADER:PATH pairs separated by commas, e.g. ID:id,IP:networkInterfaces.public.ip; got %q", part)
+		}
+		columns = append(columns, customColumn{header: header, path: path})
+	}
+	return columns, nil
+}
+
+func columnHeaders(columns []customColumn) []string {
+	headers := make([]string, len(columns))
+	for i, c := range columns {
+		headers[i] = c.header
+	}
+	return headers
+}
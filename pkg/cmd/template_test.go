@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateOutput(t *testing.T) {
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"GET /bareMetals/v2/servers": func(w http.ResponseWriter, r *http.Request) {
+			jsonResponse(w, 200, map[string]any{
+				"servers": []map[string]any{
+					{"id": "12345", "reference": "web-01", "networkInterfaces": map[string]any{"public": map[string]any{"ip": "192.0.2.10/24"}}},
+					{"id": "67890", "reference": "", "networkInterfaces": map[string]any{}},
+				},
+				"_metadata": map[string]any{"totalCount": 2},
+			})
+		},
+		"GET /hosting/v2/domains": func(w http.ResponseWriter, r *http.Request) {
+			jsonResponse(w, 200, map[string]any{
+				"domains": []map[string]any{{
+					"domainName":  "example.com",
+					"nameServers": []string{"ns1.example.net", "ns2.example.net"},
+					"createdAt":   "2024-03-01T10:00:00Z",
+				}},
+			})
+		},
+	})
+	defer srv.Close()
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+
+	stdout, _, err := runCLI(t, srv.URL, []string{"-o", `go-template={{range .servers}}{{.id}} {{.reference | default "-"}}{{"\n"}}{{end}}`, "ds", "list"})
+	require.NoError(t, err)
+	assert.Equal(t, "12345 web-01\n67890 -\n", stdout)
+
+	file := filepath.Join(dir, "domains.tmpl")
+	require.NoError(t, os.WriteFile(file, []byte(`{{range .domains}}{{.domainName}} {{join "," .nameServers}} {{dateOnly .createdAt}}{{end}}`), 0600))
+	stdout, _, err = runCLI(t, srv.URL, []string{"-o", "template-file=" + file, "domains", "list"})
+	require.NoError(t, err)
+	assert.Equal(t, "example.com ns1.example.net,ns2.example.net 2024-03-01", stdout)
+
+	stdout, _, err = runCLI(t, srv.URL, []string{"-o", "custom-columns=ID:id,IP:.networkInterfaces.public.ip", "ds", "list"})
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, []string{"ID", "IP"}, strings.Fields(lines[0]))
+	assert.Equal(t, []string{"12345", "192.0.2.10/24"}, strings.Fields(lines[1]))
+	assert.Equal(t, []string{"67890", "<none>"}, strings.Fields(lines[2]))
+
+	_, _, err = runCLI(t, srv.URL, []string{"-o", "go-template={{.id", "ds", "list"})
+	require.ErrorContains(t, err, "parsing output template")
+	_, _, err = runCLI(t, srv.URL, []string{"-o", "custom-columns=ID", "ds", "list"})
+	require.ErrorContains(t, err, "HEADER:PATH")
+}
@@ -24,7 +24,7 @@ var tpListCmd = cli.Command{Name: "list", Usage: "List traffic policies", Flags:
 	if err != nil {
 		return err
 	}
-	res, err := client.Get(ctx, "/trafficPolicy/v1/policies?"+PaginationQuery(cmd))
+	res, err := GetList(ctx, client, cmd, "/trafficPolicy/v1/policies?"+PaginationQuery(cmd))
 	if err != nil {
 		return err
 	}
@@ -56,7 +56,11 @@ var tpUpdateCmd = cli.Command{Name: "update", Usage: "Update traffic policy", Ar
 	if err != nil {
 		return err
 	}
-	res, err := client.PatchJSON(ctx, "/trafficPolicy/v1/policies/"+args[0], []byte(cmd.String("payload")))
+	body, err := loadPayload(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := client.PatchJSON(ctx, "/trafficPolicy/v1/policies/"+args[0], body)
 	if err != nil {
 		return err
 	}
@@ -72,7 +76,7 @@ var tpHistoryCmd = cli.Command{Name: "history", Usage: "List policy history", Ar
 	if err != nil {
 		return err
 	}
-	res, err := client.Get(ctx, "/trafficPolicy/v1/policies/"+args[0]+"/history?"+PaginationQuery(cmd))
+	res, err := GetList(ctx, client, cmd, "/trafficPolicy/v1/policies/"+args[0]+"/history?"+PaginationQuery(cmd))
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,364 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// ValidationError is returned when a request body does not match the
+// schema of its operation in the OpenAPI spec.
+type ValidationError struct {
+	Operation string
+	Problems  []SchemaProblem
+}
+
+// SchemaProblem is a single mismatch between a request body and its schema.
+// Pointer is the JSON pointer of the offending value.
+type SchemaProblem struct {
+	Pointer string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "request body does not match the %s schema (use --skip-validation to send it anyway):", e.Operation)
+	for _, p := range e.Problems {
+		ptr := p.Pointer
+		if ptr == "" {
+			ptr = "(root)"
+		}
+		fmt.Fprintf(&b, "\n  %s: %s", ptr, p.Message)
+	}
+	return b.String()
+}
+
+// operationForRequest returns the spec operation serving method and path,
+// preferring the template with the most literal segments, or nil when the
+// spec has none.
+func operationForRequest(method, path string) *specOperation {
+	path, _, _ = strings.Cut(path, "?")
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	var best *specOperation
+	bestLiterals := -1
+	for _, op := range specOperations {
+		if op.Method != method {
+			continue
+		}
+		template := strings.Split(strings.Trim(op.Path, "/"), "/")
+		if len(template) != len(segments) {
+			continue
+		}
+		literals := 0
+		for i, t := range template {
+			if strings.Contains(t, "{") {
+				continue
+			}
+			if t != segments[i] {
+				literals = -1
+				break
+			}
+			literals++
+		}
+		if literals > bestLiterals {
+			best, bestLiterals = op, literals
+		}
+	}
+	return best
+}
+
+// validateBody checks body against the request body schema of op.
+func validateBody(op *specOperation, body []byte) error {
+	if op.Schema == "" || len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+	var schema map[string]any
+	if err := json.Unmarshal([]byte(op.Schema), &schema); err != nil {
+		return fmt.Errorf("parsing %s schema: %w", op.ID, err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		msg := "invalid JSON: " + err.Error()
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			msg = fmt.Sprintf("invalid JSON at offset %d: %s", syntaxErr.Offset, syntaxErr.Error())
+		}
+		return &ValidationError{Operation: op.ID, Problems: []SchemaProblem{{Message: msg}}}
+	}
+
+	if problems := checkSchema(schema, v, "", false); len(problems) > 0 {
+		return &ValidationError{Operation: op.ID, Problems: problems}
+	}
+	return nil
+}
+
+// checkSchema validates v against a subset of JSON schema: type, nullable,
+// enum, allOf, anyOf, oneOf, object properties, arrays and the usual string
+// and number bounds. Objects declaring properties reject unknown ones unless
+// additionalProperties allows them; open is set for allOf members, whose
+// siblings may declare the remaining properties.
+func checkSchema(s map[string]any, v any, ptr string, open bool) []SchemaProblem {
+	var problems []SchemaProblem
+	add := func(format string, args ...any) {
+		problems = append(problems, SchemaProblem{Pointer: ptr, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if v == nil && s["nullable"] == true {
+		return nil
+	}
+	if t, ok := s["type"]; ok && !typeMatches(t, v) {
+		add("must be %s, got %s", typeNames(t), jsonType(v))
+		return problems
+	}
+	if enum, ok := s["enum"].([]any); ok && !enumContains(enum, v) {
+		add("must be one of %s, got %s", formatEnum(enum), compactJSON(v))
+	}
+
+	for _, sub := range schemaList(s["allOf"]) {
+		problems = append(problems, checkSchema(sub, v, ptr, true)...)
+	}
+	for _, key := range []string{"anyOf", "oneOf"} {
+		branches := schemaList(s[key])
+		if len(branches) == 0 {
+			continue
+		}
+		var closest []SchemaProblem
+		for i, sub := range branches {
+			p := checkSchema(sub, v, ptr, open)
+			if len(p) == 0 {
+				closest = nil
+				break
+			}
+			if i == 0 || len(p) < len(closest) {
+				closest = p
+			}
+		}
+		problems = append(problems, closest...)
+	}
+
+	switch v := v.(type) {
+	case map[string]any:
+		problems = append(problems, checkObject(s, v, ptr, open)...)
+	case []any:
+		if n, ok := schemaNumber(s["minItems"]); ok && float64(len(v)) < n {
+			add("must have at least %g items, got %d", n, len(v))
+		}
+		if n, ok := schemaNumber(s["maxItems"]); ok && float64(len(v)) > n {
+			add("must have at most %g items, got %d", n, len(v))
+		}
+		if items, ok := s["items"].(map[string]any); ok {
+			for i, item := range v {
+				problems = append(problems, checkSchema(items, item, fmt.Sprintf("%s/%d", ptr, i), false)...)
+			}
+		}
+	case string:
+		n := float64(utf8.RuneCountInString(v))
+		if min, ok := schemaNumber(s["minLength"]); ok && n < min {
+			add("must be at least %g characters long", min)
+		}
+		if max, ok := schemaNumber(s["maxLength"]); ok && n > max {
+			add("must be at most %g characters long", max)
+		}
+		if pattern, ok := s["pattern"].(string); ok {
+			if re, err := regexp.Compile(pattern); err == nil && !re.MatchString(v) {
+				add("must match pattern %s", pattern)
+			}
+		}
+	case json.Number:
+		f, _ := v.Float64()
+		if min, ok := schemaNumber(s["minimum"]); ok && f < min {
+			add("must be at least %g, got %s", min, v)
+		}
+		if max, ok := schemaNumber(s["maximum"]); ok && f > max {
+			add("must be at most %g, got %s", max, v)
+		}
+	}
+	return problems
+}
+
+func checkObject(s map[string]any, v map[string]any, ptr string, open bool) []SchemaProblem {
+	var problems []SchemaProblem
+	props, _ := s["properties"].(map[string]any)
+
+	for _, name := range stringList(s["required"]) {
+		if _, ok := v[name]; !ok {
+			problems = append(problems, SchemaProblem{Pointer: ptr + "/" + escapePointer(name), Message: "required property is missing"})
+		}
+	}
+
+	names := make([]string, 0, len(v))
+	for name := range v {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	known := knownProperties(s)
+	for _, name := range names {
+		child := ptr + "/" + escapePointer(name)
+		if sub, ok := props[name].(map[string]any); ok {
+			problems = append(problems, checkSchema(sub, v[name], child, false)...)
+			continue
+		}
+		switch extra := s["additionalProperties"].(type) {
+		case map[string]any:
+			problems = append(problems, checkSchema(extra, v[name], child, false)...)
+			continue
+		case bool:
+			if extra {
+				continue
+			}
+		}
+		if !open && known != nil && !known[name] {
+			problems = append(problems, SchemaProblem{Pointer: child, Message: "unknown property"})
+		}
+	}
+	return problems
+}
+
+// knownProperties returns the properties declared by s and its allOf
+// members, or nil when s declares none and so accepts any.
+func knownProperties(s map[string]any) map[string]bool {
+	var known map[string]bool
+	collect := func(s map[string]any) {
+		props, ok := s["properties"].(map[string]any)
+		if !ok {
+			return
+		}
+		if known == nil {
+			known = map[string]bool{}
+		}
+		for name := range props {
+			known[name] = true
+		}
+	}
+	collect(s)
+	for _, sub := range schemaList(s["allOf"]) {
+		collect(sub)
+	}
+	return known
+}
+
+func typeMatches(t any, v any) bool {
+	switch t := t.(type) {
+	case string:
+		switch t {
+		case "integer":
+			n, ok := v.(json.Number)
+			if !ok {
+				return false
+			}
+			f, err := n.Float64()
+			return err == nil && f == math.Trunc(f)
+		case "number":
+			_, ok := v.(json.Number)
+			return ok
+		default:
+			return jsonType(v) == t
+		}
+	case []any:
+		for _, alt := range t {
+			if typeMatches(alt, v) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func typeNames(t any) string {
+	if list, ok := t.([]any); ok {
+		names := make([]string, len(list))
+		for i, n := range list {
+			names[i] = fmt.Sprint(n)
+		}
+		return strings.Join(names, " or ")
+	}
+	return fmt.Sprint(t)
+}
+
+// jsonType names the JSON type of a value decoded with UseNumber.
+func jsonType(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number, float64, int64:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+func enumContains(enum []any, v any) bool {
+	got := compactJSON(v)
+	return slices.ContainsFunc(enum, func(e any) bool { return compactJSON(e) == got })
+}
+
+func formatEnum(enum []any) string {
+	values := make([]string, len(enum))
+	for i, e := range enum {
+		values[i] = compactJSON(e)
+	}
+	return strings.Join(values, ", ")
+}
+
+func compactJSON(v any) string {
+	if n, ok := v.(json.Number); ok {
+		return n.String()
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+func schemaList(v any) []map[string]any {
+	list, _ := v.([]any)
+	var res []map[string]any
+	for _, item := range list {
+		if s, ok := item.(map[string]any); ok {
+			res = append(res, s)
+		}
+	}
+	return res
+}
+
+func stringList(v any) []string {
+	list, _ := v.([]any)
+	var res []string
+	for _, item := range list {
+		if s, ok := item.(string); ok {
+			res = append(res, s)
+		}
+	}
+	return res
+}
+
+func schemaNumber(v any) (float64, bool) {
+	f, ok := v.(float64)
+	return f, ok
+}
+
+// escapePointer escapes a property name for use in a JSON pointer.
+func escapePointer(name string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(name, "~", "~0"), "/", "~1")
+}
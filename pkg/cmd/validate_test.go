@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPayloadValidation(t *testing.T) {
+	var calls int
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"PUT /hosting/v2/domains/example.com/resourceRecordSets": func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.WriteHeader(http.StatusNoContent)
+		},
+	})
+	defer srv.Close()
+
+	payload := `{"resourceRecordSets":[{"name":"www","type":"AA","ttl":"300","extra":1}]}`
+	_, _, err := runCLI(t, srv.URL, []string{"domains", "dns-update-all", "example.com", "--payload", payload})
+	require.Error(t, err)
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+	assert.Equal(t, "updateResourceRecordSets", verr.Operation)
+	assert.Equal(t, []SchemaProblem{
+		{Pointer: "/resourceRecordSets/0/content", Message: "required property is missing"},
+		{Pointer: "/resourceRecordSets/0/extra", Message: "unknown property"},
+		{Pointer: "/resourceRecordSets/0/ttl", Message: "must be integer, got string"},
+		{Pointer: "/resourceRecordSets/0/type", Message: `must be one of "A", "AAAA", "CAA", "CNAME", "MX", "NS", "SRV", "TXT", "SOA", "DS", "TLSA", got "AA"`},
+	}, verr.Problems)
+	assert.Equal(t, 0, calls)
+
+	_, _, err = runCLI(t, srv.URL, []string{"domains", "dns-update-all", "example.com", "--payload", `{"resourceRecordSets":[`})
+	assert.ErrorContains(t, err, "invalid JSON")
+
+	_, _, err = runCLI(t, srv.URL, []string{"--skip-validation", "domains", "dns-update-all", "example.com", "--payload", payload})
+	require.NoError(t, err)
+	assert.Equal(t, 1, calls)
+
+	valid := `{"resourceRecordSets":[{"name":"www","type":"A","ttl":300,"content":["1.2.3.4"]}]}`
+	_, _, err = runCLI(t, srv.URL, []string{"domains", "dns-update-all", "example.com", "--payload", valid})
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+
+	_, _, err = runCLI(t, srv.URL, []string{"domains", "dns-update-all", "example.com", "--payload", payload})
+	assert.ErrorAs(t, err, &verr)
+}
@@ -30,7 +30,7 @@ func handleVSList(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	res, err := client.Get(ctx, "/cloud/v2/virtualServers?"+PaginationQuery(cmd))
+	res, err := GetList(ctx, client, cmd, "/cloud/v2/virtualServers?"+PaginationQuery(cmd))
 	if err != nil {
 		return err
 	}
@@ -57,7 +57,7 @@ func handleVSGet(ctx context.Context, cmd *cli.Command) error {
 
 var vsUpdateCmd = cli.Command{
 	Name: "update", Usage: "Update a virtual server", ArgsUsage: "<id>",
-	Flags:  []cli.Flag{&cli.StringFlag{Name: "payload", Usage: "JSON payload", Required: true}},
+	Flags:  payloadFlag,
 	Action: handleVSUpdate, HideHelpCommand: true,
 }
 
@@ -70,7 +70,11 @@ func handleVSUpdate(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	res, err := client.PutJSON(ctx, "/cloud/v2/virtualServers/"+args[0], []byte(cmd.String("payload")))
+	body, err := loadPayload(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := client.PutJSON(ctx, "/cloud/v2/virtualServers/"+args[0], body)
 	if err != nil {
 		return err
 	}
@@ -96,7 +100,7 @@ func handleVSPowerOn(ctx context.Context, cmd *cli.Command) error {
 	return nil
 }
 
-var vsPowerOffCmd = cli.Command{Name: "power-off", Usage: "Power off", ArgsUsage: "<id>", Action: handleVSPowerOff, HideHelpCommand: true}
+var vsPowerOffCmd = cli.Command{Name: "power-off", Usage: "Power off", ArgsUsage: "<id>", Action: confirm(destructive{action: "power off virtual server %s"}, handleVSPowerOff), HideHelpCommand: true}
 
 func handleVSPowerOff(ctx context.Context, cmd *cli.Command) error {
 	args := cmd.Args().Slice()
@@ -136,8 +140,8 @@ func handleVSReboot(ctx context.Context, cmd *cli.Command) error {
 
 var vsReinstallCmd = cli.Command{
 	Name: "reinstall", Usage: "Reinstall", ArgsUsage: "<id>",
-	Flags:  []cli.Flag{&cli.StringFlag{Name: "payload", Usage: "JSON payload", Required: true}},
-	Action: handleVSReinstall, HideHelpCommand: true,
+	Flags:  payloadFlag,
+	Action: confirm(destructive{action: "reinstall virtual server %s, erasing its disk"}, handleVSReinstall), HideHelpCommand: true,
 }
 
 func handleVSReinstall(ctx context.Context, cmd *cli.Command) error {
@@ -149,7 +153,11 @@ func handleVSReinstall(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	res, err := client.PostJSON(ctx, "/cloud/v2/virtualServers/"+args[0]+"/reinstall", []byte(cmd.String("payload")))
+	body, err := loadPayload(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := client.PostJSON(ctx, "/cloud/v2/virtualServers/"+args[0]+"/reinstall", body)
 	if err != nil {
 		return err
 	}
@@ -158,7 +166,7 @@ func handleVSReinstall(ctx context.Context, cmd *cli.Command) error {
 
 var vsCredentialsUpdateCmd = cli.Command{
 	Name: "credentials-update", Usage: "Update credentials", ArgsUsage: "<id>",
-	Flags:  []cli.Flag{&cli.StringFlag{Name: "payload", Required: true}},
+	Flags:  payloadFlag,
 	Action: handleVSCredentialsUpdate, HideHelpCommand: true,
 }
 
@@ -171,7 +179,11 @@ func handleVSCredentialsUpdate(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	res, err := client.PutJSON(ctx, "/cloud/v2/virtualServers/"+args[0]+"/credentials", []byte(cmd.String("payload")))
+	body, err := loadPayload(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := client.PutJSON(ctx, "/cloud/v2/virtualServers/"+args[0]+"/credentials", body)
 	if err != nil {
 		return err
 	}
@@ -294,7 +306,7 @@ func handleVSSnapshotGet(ctx context.Context, cmd *cli.Command) error {
 	return ShowResult(os.Stdout, res, cmd.Root().String("output"), cmd.Root().String("transform"))
 }
 
-var vsSnapshotDeleteCmd = cli.Command{Name: "snapshot-delete", Usage: "Delete snapshot", ArgsUsage: "<id> <snapshot-id>", Action: handleVSSnapshotDelete, HideHelpCommand: true}
+var vsSnapshotDeleteCmd = cli.Command{Name: "snapshot-delete", Usage: "Delete snapshot", ArgsUsage: "<id> <snapshot-id>", Action: confirm(destructive{action: "delete snapshot %[2]s of virtual server %[1]s"}, handleVSSnapshotDelete), HideHelpCommand: true}
 
 func handleVSSnapshotDelete(ctx context.Context, cmd *cli.Command) error {
 	args := cmd.Args().Slice()
@@ -43,13 +43,15 @@ func handleVPSList(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	res, err := client.Get(ctx, "/publicCloud/v1/vps/?"+PaginationQuery(cmd))
+	res, err := GetList(ctx, client, cmd, "/publicCloud/v1/vps/?"+PaginationQuery(cmd))
 	if err != nil {
 		return err
 	}
 	format := cmd.Root().String("output")
 	if format != "auto" {
-		return ShowResult(os.Stdout, res, format, cmd.Root().String("transform"))
+		return ShowList(os.Stdout, res, format, cmd.Root().String("transform"), []string{
+			"id", "reference", "state", "region", "ips.#(version==4).ip",
+		})
 	}
 	vps := res.Get("vps")
 	if !vps.Exists() || len(vps.Array()) == 0 {
@@ -184,7 +186,7 @@ func handleVPSReboot(ctx context.Context, cmd *cli.Command) error {
 var vpsReinstallCmd = cli.Command{
 	Name: "reinstall", Usage: "Reinstall a VPS", ArgsUsage: "<vps-id>",
 	Flags:  []cli.Flag{&cli.StringFlag{Name: "image", Usage: "Image ID", Required: true}},
-	Action: handleVPSReinstall, HideHelpCommand: true,
+	Action: confirm(destructive{"reinstall VPS %s, erasing its disk", "/publicCloud/v1/vps/%s", instanceDetails}, handleVPSReinstall), HideHelpCommand: true,
 }
 
 func handleVPSReinstall(ctx context.Context, cmd *cli.Command) error {
@@ -304,7 +306,7 @@ func handleVPSCredentialStore(ctx context.Context, cmd *cli.Command) error {
 	return ShowResult(os.Stdout, res, cmd.Root().String("output"), cmd.Root().String("transform"))
 }
 
-var vpsCredentialDeleteAllCmd = cli.Command{Name: "credential-delete-all", Usage: "Delete all credentials", ArgsUsage: "<vps-id>", Action: handleVPSCredentialDeleteAll, HideHelpCommand: true}
+var vpsCredentialDeleteAllCmd = cli.Command{Name: "credential-delete-all", Usage: "Delete all credentials", ArgsUsage: "<vps-id>", Action: confirm(destructive{"delete all credentials of VPS %s", "/publicCloud/v1/vps/%s", instanceDetails}, handleVPSCredentialDeleteAll), HideHelpCommand: true}
 
 func handleVPSCredentialDeleteAll(ctx context.Context, cmd *cli.Command) error {
 	args := cmd.Args().Slice()
@@ -368,7 +370,7 @@ func handleVPSCredentialUpdate(ctx context.Context, cmd *cli.Command) error {
 	return ShowResult(os.Stdout, res, cmd.Root().String("output"), cmd.Root().String("transform"))
 }
 
-var vpsCredentialDeleteCmd = cli.Command{Name: "credential-delete", Usage: "Delete credential", ArgsUsage: "<vps-id> <type> <username>", Action: handleVPSCredentialDelete, HideHelpCommand: true}
+var vpsCredentialDeleteCmd = cli.Command{Name: "credential-delete", Usage: "Delete credential", ArgsUsage: "<vps-id> <type> <username>", Action: confirm(destructive{"delete the %[2]s credential %[3]s of VPS %[1]s", "/publicCloud/v1/vps/%s", instanceDetails}, handleVPSCredentialDelete), HideHelpCommand: true}
 
 func handleVPSCredentialDelete(ctx context.Context, cmd *cli.Command) error {
 	args := cmd.Args().Slice()
@@ -446,7 +448,7 @@ func handleVPSIPUpdate(ctx context.Context, cmd *cli.Command) error {
 	return ShowResult(os.Stdout, res, cmd.Root().String("output"), cmd.Root().String("transform"))
 }
 
-var vpsIPNullCmd = cli.Command{Name: "ip-null", Usage: "Null route IP", ArgsUsage: "<vps-id> <ip>", Action: handleVPSIPNull, HideHelpCommand: true}
+var vpsIPNullCmd = cli.Command{Name: "ip-null", Usage: "Null route IP", ArgsUsage: "<vps-id> <ip>", Action: confirm(destructive{"null route IP %[2]s of VPS %[1]s", "/publicCloud/v1/vps/%s", instanceDetails}, handleVPSIPNull), HideHelpCommand: true}
 
 func handleVPSIPNull(ctx context.Context, cmd *cli.Command) error {
 	args := cmd.Args().Slice()
@@ -557,7 +559,7 @@ func handleVPSSnapshotRestore(ctx context.Context, cmd *cli.Command) error {
 	return ShowResult(os.Stdout, res, cmd.Root().String("output"), cmd.Root().String("transform"))
 }
 
-var vpsSnapshotDeleteCmd = cli.Command{Name: "snapshot-delete", Usage: "Delete snapshot", ArgsUsage: "<vps-id> <snapshot-id>", Action: handleVPSSnapshotDelete, HideHelpCommand: true}
+var vpsSnapshotDeleteCmd = cli.Command{Name: "snapshot-delete", Usage: "Delete snapshot", ArgsUsage: "<vps-id> <snapshot-id>", Action: confirm(destructive{"delete snapshot %[2]s of VPS %[1]s", "/publicCloud/v1/vps/%s", instanceDetails}, handleVPSSnapshotDelete), HideHelpCommand: true}
 
 func handleVPSSnapshotDelete(ctx context.Context, cmd *cli.Command) error {
 	args := cmd.Args().Slice()
@@ -677,7 +679,7 @@ func handleVPSNotifGet(ctx context.Context, cmd *cli.Command) error {
 
 var vpsNotifDatatrafficCreateCmd = cli.Command{
 	Name: "notif-datatraffic-create", Usage: "Create notification", ArgsUsage: "<vps-id> <id>",
-	Flags: []cli.Flag{&cli.StringFlag{Name: "payload", Required: true}}, Action: handleVPSNotifCreate, HideHelpCommand: true,
+	Flags: payloadFlag, Action: handleVPSNotifCreate, HideHelpCommand: true,
 }
 
 func handleVPSNotifCreate(ctx context.Context, cmd *cli.Command) error {
@@ -689,7 +691,11 @@ func handleVPSNotifCreate(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	res, err := client.PostJSON(ctx, fmt.Sprintf("/publicCloud/v1/vps/%s/notificationSettings/dataTraffic/%s", args[0], args[1]), []byte(cmd.String("payload")))
+	body, err := loadPayload(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := client.PostJSON(ctx, fmt.Sprintf("/publicCloud/v1/vps/%s/notificationSettings/dataTraffic/%s", args[0], args[1]), body)
 	if err != nil {
 		return err
 	}
@@ -698,7 +704,7 @@ func handleVPSNotifCreate(ctx context.Context, cmd *cli.Command) error {
 
 var vpsNotifDatatrafficUpdateCmd = cli.Command{
 	Name: "notif-datatraffic-update", Usage: "Update notification", ArgsUsage: "<vps-id> <id>",
-	Flags: []cli.Flag{&cli.StringFlag{Name: "payload", Required: true}}, Action: handleVPSNotifUpdate, HideHelpCommand: true,
+	Flags: payloadFlag, Action: handleVPSNotifUpdate, HideHelpCommand: true,
 }
 
 func handleVPSNotifUpdate(ctx context.Context, cmd *cli.Command) error {
@@ -710,14 +716,18 @@ func handleVPSNotifUpdate(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	res, err := client.PutJSON(ctx, fmt.Sprintf("/publicCloud/v1/vps/%s/notificationSettings/dataTraffic/%s", args[0], args[1]), []byte(cmd.String("payload")))
+	body, err := loadPayload(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := client.PutJSON(ctx, fmt.Sprintf("/publicCloud/v1/vps/%s/notificationSettings/dataTraffic/%s", args[0], args[1]), body)
 	if err != nil {
 		return err
 	}
 	return ShowResult(os.Stdout, res, cmd.Root().String("output"), cmd.Root().String("transform"))
 }
 
-var vpsNotifDatatrafficDeleteCmd = cli.Command{Name: "notif-datatraffic-delete", Usage: "Delete notification", ArgsUsage: "<vps-id> <id>", Action: handleVPSNotifDelete, HideHelpCommand: true}
+var vpsNotifDatatrafficDeleteCmd = cli.Command{Name: "notif-datatraffic-delete", Usage: "Delete notification", ArgsUsage: "<vps-id> <id>", Action: confirm(destructive{"delete data traffic notification setting %[2]s of VPS %[1]s", "/publicCloud/v1/vps/%s", instanceDetails}, handleVPSNotifDelete), HideHelpCommand: true}
 
 func handleVPSNotifDelete(ctx context.Context, cmd *cli.Command) error {
 	args := cmd.Args().Slice()
@@ -26,7 +26,7 @@ var whListCmd = cli.Command{Name: "list", Usage: "List webhosting packages", Fla
 	if err != nil {
 		return err
 	}
-	res, err := client.Get(ctx, "/webhosting/v2/packages?"+PaginationQuery(cmd))
+	res, err := GetList(ctx, client, cmd, "/webhosting/v2/packages?"+PaginationQuery(cmd))
 	if err != nil {
 		return err
 	}
@@ -118,7 +118,11 @@ var whDomainAliasCreateCmd = cli.Command{Name: "domain-alias-create", ArgsUsage:
 	if err != nil {
 		return err
 	}
-	res, err := client.PostJSON(ctx, "/webhosting/v2/packages/"+args[0]+"/domainAliases", []byte(cmd.String("payload")))
+	body, err := loadPayload(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := client.PostJSON(ctx, "/webhosting/v2/packages/"+args[0]+"/domainAliases", body)
 	if err != nil {
 		return err
 	}
@@ -150,7 +154,11 @@ var whCatchAllUpdateCmd = cli.Command{Name: "catch-all-update", ArgsUsage: "<id>
 	if err != nil {
 		return err
 	}
-	res, err := client.PutJSON(ctx, "/webhosting/v2/packages/"+args[0]+"/catchAll", []byte(cmd.String("payload")))
+	body, err := loadPayload(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := client.PutJSON(ctx, "/webhosting/v2/packages/"+args[0]+"/catchAll", body)
 	if err != nil {
 		return err
 	}
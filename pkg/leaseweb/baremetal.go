@@ -0,0 +1,203 @@
+package leaseweb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+)
+
+// BareMetalService covers dedicated servers (/bareMetals/v2).
+type BareMetalService struct {
+	client *Client
+}
+
+// Server is a dedicated server.
+type Server struct {
+	ID                  string                  `json:"id"`
+	AssetID             string                  `json:"assetId"`
+	Reference           string                  `json:"reference"`
+	SerialNumber        string                  `json:"serialNumber"`
+	Contract            *ServerContract         `json:"contract,omitempty"`
+	FeatureAvailability map[string]bool         `json:"featureAvailability,omitempty"`
+	Location            ServerLocation          `json:"location"`
+	NetworkInterfaces   ServerNetworkInterfaces `json:"networkInterfaces"`
+	Specs               ServerSpecs             `json:"specs"`
+	PrivateNetworks     []ServerPrivateNetwork  `json:"privateNetworks,omitempty"`
+	// Raw is the response body as returned by the API, for a server fetched
+	// or updated on its own.
+	Raw json.RawMessage `json:"-"`
+}
+
+type ServerContract struct {
+	ID             string `json:"id"`
+	CustomerID     string `json:"customerId"`
+	DeliveryStatus string `json:"deliveryStatus"`
+	Reference      string `json:"reference"`
+	SalesOrgID     string `json:"salesOrgId"`
+}
+
+type ServerLocation struct {
+	Site  string `json:"site"`
+	Suite string `json:"suite"`
+	Rack  string `json:"rack"`
+	Unit  string `json:"unit"`
+}
+
+type ServerNetworkInterfaces struct {
+	Public           *NetworkInterface `json:"public,omitempty"`
+	Internal         *NetworkInterface `json:"internal,omitempty"`
+	RemoteManagement *NetworkInterface `json:"remoteManagement,omitempty"`
+}
+
+type NetworkInterface struct {
+	IP      string `json:"ip"`
+	Gateway string `json:"gateway"`
+	MAC     string `json:"mac"`
+}
+
+type ServerSpecs struct {
+	Chassis string      `json:"chassis"`
+	CPU     ServerCPU   `json:"cpu"`
+	RAM     ServerRAM   `json:"ram"`
+	HDD     []ServerHDD `json:"hdd"`
+}
+
+type ServerCPU struct {
+	Quantity int    `json:"quantity"`
+	Type     string `json:"type"`
+}
+
+type ServerRAM struct {
+	Size int    `json:"size"`
+	Unit string `json:"unit"`
+}
+
+type ServerHDD struct {
+	ID              string  `json:"id"`
+	Amount          int     `json:"amount"`
+	Size            float64 `json:"size"`
+	Unit            string  `json:"unit"`
+	Type            string  `json:"type"`
+	PerformanceType string  `json:"performanceType"`
+}
+
+type ServerPrivateNetwork struct {
+	ID        string `json:"id"`
+	LinkSpeed int    `json:"linkSpeed"`
+	Status    string `json:"status"`
+	Subnet    string `json:"subnet"`
+	VLANID    string `json:"vlanId"`
+}
+
+// PowerInfo is the power state reported by a server's IPMI and PDU.
+type PowerInfo struct {
+	IPMI struct {
+		Status string `json:"status"`
+	} `json:"ipmi"`
+	PDU struct {
+		Status string `json:"status"`
+	} `json:"pdu"`
+}
+
+// ListServersOptions filters the server list.
+type ListServersOptions struct {
+	ListOptions
+	Reference string
+	IP        string
+	Site      string
+}
+
+func (o *ListServersOptions) query() url.Values {
+	q := url.Values{}
+	if o == nil {
+		return q
+	}
+	o.ListOptions.apply(q)
+	if o.Reference != "" {
+		q.Set("reference", o.Reference)
+	}
+	if o.IP != "" {
+		q.Set("ip", o.IP)
+	}
+	if o.Site != "" {
+		q.Set("site", o.Site)
+	}
+	return q
+}
+
+// ListServers returns one page of dedicated servers.
+func (s *BareMetalService) ListServers(ctx context.Context, opts *ListServersOptions) (*Page[Server], error) {
+	return listPage[Server](ctx, s.client, "/bareMetals/v2/servers", opts.query(), "servers")
+}
+
+// AllServers iterates over every dedicated server matching opts; the
+// pagination fields of opts are ignored.
+func (s *BareMetalService) AllServers(ctx context.Context, opts *ListServersOptions) iter.Seq2[Server, error] {
+	q := opts.query()
+	q.Del("limit")
+	q.Del("offset")
+	return paginate[Server](ctx, s.client, "/bareMetals/v2/servers", q, "servers")
+}
+
+// GetServer returns a single dedicated server.
+func (s *BareMetalService) GetServer(ctx context.Context, id string) (*Server, error) {
+	resp, err := s.client.Do(ctx, &Request{Method: http.MethodGet, Path: "/bareMetals/v2/servers/" + url.PathEscape(id)})
+	if err != nil {
+		return nil, err
+	}
+	return decodeServer(resp.Body)
+}
+
+// UpdateServerReference sets the reference of a dedicated server. It returns
+// the updated server, or nil when the API answers without a body.
+func (s *BareMetalService) UpdateServerReference(ctx context.Context, id, reference string) (*Server, error) {
+	body, err := json.Marshal(map[string]string{"reference": reference})
+	if err != nil {
+		return nil, fmt.Errorf("encoding request: %w", err)
+	}
+	resp, err := s.client.Do(ctx, &Request{Method: http.MethodPut, Path: "/bareMetals/v2/servers/" + url.PathEscape(id), Body: body})
+	if err != nil {
+		return nil, err
+	}
+	if len(bytes.TrimSpace(resp.Body)) == 0 {
+		return nil, nil
+	}
+	return decodeServer(resp.Body)
+}
+
+func decodeServer(body []byte) (*Server, error) {
+	var server Server
+	if err := decode(body, &server); err != nil {
+		return nil, err
+	}
+	server.Raw = body
+	return &server, nil
+}
+
+// PowerOn powers on a dedicated server.
+func (s *BareMetalService) PowerOn(ctx context.Context, id string) error {
+	return s.client.send(ctx, http.MethodPost, "/bareMetals/v2/servers/"+url.PathEscape(id)+"/powerOn", nil, nil)
+}
+
+// PowerOff powers off a dedicated server.
+func (s *BareMetalService) PowerOff(ctx context.Context, id string) error {
+	return s.client.send(ctx, http.MethodPost, "/bareMetals/v2/servers/"+url.PathEscape(id)+"/powerOff", nil, nil)
+}
+
+// PowerCycle power cycles a dedicated server.
+func (s *BareMetalService) PowerCycle(ctx context.Context, id string) error {
+	return s.client.send(ctx, http.MethodPost, "/bareMetals/v2/servers/"+url.PathEscape(id)+"/powerCycle", nil, nil)
+}
+
+// GetPowerInfo returns the power state of a dedicated server.
+func (s *BareMetalService) GetPowerInfo(ctx context.Context, id string) (*PowerInfo, error) {
+	var info PowerInfo
+	if err := s.client.get(ctx, "/bareMetals/v2/servers/"+url.PathEscape(id)+"/powerInfo", &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
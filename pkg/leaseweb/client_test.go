@@ -0,0 +1,500 @@
+package leaseweb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newRetryTestClient returns a client whose sleeps are recorded and advance
+// a fake clock instead of being waited out.
+func newRetryTestClient(baseURL string, waits *[]time.Duration) *Client {
+	c := NewClient("test-key", WithBaseURL(baseURL), WithRetries(DefaultMaxRetries, 100*time.Millisecond))
+	now, advance := fakeClock()
+	c.limiter.now = now
+	c.sleep = func(_ context.Context, d time.Duration) error {
+		*waits = append(*waits, d)
+		advance(d)
+		return nil
+	}
+	return c
+}
+
+func TestRetryOnServiceUnavailable(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		jsonResponse(w, 200, map[string]any{"id": "12345"})
+	}))
+	defer srv.Close()
+
+	var waits []time.Duration
+	c := newRetryTestClient(srv.URL, &waits)
+	server, err := c.BareMetal.GetServer(context.Background(), "12345")
+	require.NoError(t, err)
+	assert.Equal(t, "12345", server.ID)
+	assert.Equal(t, int32(3), calls.Load())
+	require.Len(t, waits, 2)
+	assert.GreaterOrEqual(t, waits[0], 50*time.Millisecond)
+	assert.LessOrEqual(t, waits[0], 100*time.Millisecond)
+	assert.GreaterOrEqual(t, waits[1], 100*time.Millisecond)
+	assert.LessOrEqual(t, waits[1], 200*time.Millisecond)
+}
+
+func TestRetryAfterBeyondMaxRetryWait(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	var waits []time.Duration
+	c := newRetryTestClient(srv.URL, &waits)
+	c.maxRetryWait = time.Minute
+	_, err := c.Invoices.ListInvoices(context.Background(), nil)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrRateLimited)
+	assert.Contains(t, err.Error(), "asked to wait 1h0m0s, longer than the 1m0s limit")
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Empty(t, waits)
+
+	// The quota stays exhausted for an hour, so the next request fails
+	// without being sent.
+	_, err = c.Invoices.ListInvoices(context.Background(), nil)
+	assert.ErrorIs(t, err, ErrRateLimited)
+	assert.Contains(t, err.Error(), "the API quota resets in 1h0m0s")
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Empty(t, waits)
+}
+
+func TestRetryGivesUpAfterMaxRetries(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	var waits []time.Duration
+	c := newRetryTestClient(srv.URL, &waits)
+	c.maxRetries = 2
+	_, err := c.Invoices.ListInvoices(context.Background(), nil)
+	require.Error(t, err)
+
+	var apiErr *Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.ErrorIs(t, err, ErrServer)
+	assert.Equal(t, http.StatusBadGateway, apiErr.StatusCode)
+	assert.Equal(t, int32(3), calls.Load())
+	assert.Len(t, waits, 2)
+}
+
+func TestNoRetryForNonIdempotentServerError(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	var waits []time.Duration
+	c := newRetryTestClient(srv.URL, &waits)
+	_, err := c.Do(context.Background(), &Request{Method: "POST", Path: "/bareMetals/v2/servers/1/install", Body: []byte(`{}`)})
+	require.Error(t, err)
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Empty(t, waits)
+}
+
+func TestRetryPostOnRateLimitHonoursRetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	var bodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buf, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(buf))
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "7")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		jsonResponse(w, 201, map[string]any{"ok": true})
+	}))
+	defer srv.Close()
+
+	var waits []time.Duration
+	c := newRetryTestClient(srv.URL, &waits)
+	_, err := c.Do(context.Background(), &Request{Method: "POST", Path: "/floatingIps/v2/ranges", Body: []byte(`{"a":1}`)})
+	require.NoError(t, err)
+	assert.Equal(t, []time.Duration{7 * time.Second}, waits)
+	assert.Equal(t, []string{`{"a":1}`, `{"a":1}`}, bodies)
+}
+
+func TestRetryHonoursRateLimitReset(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", "4")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		jsonResponse(w, 200, map[string]any{})
+	}))
+	defer srv.Close()
+
+	var waits []time.Duration
+	c := newRetryTestClient(srv.URL, &waits)
+	_, err := c.IPMgmt.ListIPs(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, []time.Duration{4 * time.Second}, waits)
+}
+
+func TestDownloadPDFRetries(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusGatewayTimeout)
+			return
+		}
+		w.Header().Set("Content-Type", "application/pdf")
+		_, _ = w.Write([]byte("%PDF-1.4"))
+	}))
+	defer srv.Close()
+
+	var waits []time.Duration
+	c := newRetryTestClient(srv.URL, &waits)
+	data, err := c.Invoices.DownloadPDF(context.Background(), "1")
+	require.NoError(t, err)
+	assert.Equal(t, "%PDF-1.4", string(data))
+	assert.Len(t, waits, 1)
+}
+
+func TestRetryAfterParsing(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	h := http.Header{}
+	h.Set("Retry-After", now.Add(90*time.Second).Format(http.TimeFormat))
+	d, ok := retryAfter(h, now)
+	require.True(t, ok)
+	assert.Equal(t, 90*time.Second, d)
+
+	h = http.Header{}
+	h.Set("X-RateLimit-Remaining", "0")
+	h.Set("X-RateLimit-Reset", "1767268830")
+	d, ok = retryAfter(h, now)
+	require.True(t, ok)
+	assert.Equal(t, 30*time.Second, d)
+
+	h = http.Header{}
+	h.Set("X-RateLimit-Remaining", "5")
+	h.Set("X-RateLimit-Reset", "30")
+	_, ok = retryAfter(h, now)
+	assert.False(t, ok)
+}
+
+// fakeClock returns a time source that only moves when advanced.
+func fakeClock() (func() time.Time, func(time.Duration)) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	var mu sync.Mutex
+	return func() time.Time {
+			mu.Lock()
+			defer mu.Unlock()
+			return now
+		}, func(d time.Duration) {
+			mu.Lock()
+			defer mu.Unlock()
+			now = now.Add(d)
+		}
+}
+
+func TestRateLimiterTokenBucket(t *testing.T) {
+	l := newRateLimiter(10, 2)
+	now, advance := fakeClock()
+	l.now = now
+
+	assert.Equal(t, time.Duration(0), l.reserve())
+	assert.Equal(t, time.Duration(0), l.reserve())
+	assert.Equal(t, 100*time.Millisecond, l.reserve())
+	assert.Equal(t, 200*time.Millisecond, l.reserve())
+
+	advance(time.Second)
+	assert.Equal(t, time.Duration(0), l.reserve())
+}
+
+func TestRateLimiterSharedAcrossGoroutines(t *testing.T) {
+	l := newRateLimiter(5, 1)
+	now, _ := fakeClock()
+	l.now = now
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var waits []time.Duration
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			d := l.reserve()
+			mu.Lock()
+			waits = append(waits, d)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	slices.Sort(waits)
+	for i, d := range waits {
+		assert.Equal(t, time.Duration(i)*200*time.Millisecond, d)
+	}
+}
+
+func TestRateLimiterAdaptsToHeaders(t *testing.T) {
+	l := newRateLimiter(0, 0)
+	now, advance := fakeClock()
+	l.now = now
+
+	h := http.Header{}
+	h.Set("X-RateLimit-Limit", "100")
+	h.Set("X-RateLimit-Remaining", "80")
+	h.Set("X-RateLimit-Reset", "60")
+	l.observe(200, h)
+	assert.Equal(t, time.Duration(0), l.reserve())
+	assert.Equal(t, time.Duration(0), l.reserve())
+
+	h.Set("X-RateLimit-Remaining", "5")
+	l.observe(200, h)
+	assert.Equal(t, time.Duration(0), l.reserve())
+	assert.Equal(t, 12*time.Second, l.reserve())
+
+	h.Set("X-RateLimit-Remaining", "0")
+	h.Set("X-RateLimit-Reset", "30")
+	l.observe(200, h)
+	advance(24 * time.Second)
+	assert.Equal(t, 6*time.Second, l.reserve())
+}
+
+func TestRateLimitDelayIsLogged(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", "2")
+		}
+		jsonResponse(w, 200, map[string]any{})
+	}))
+	defer srv.Close()
+
+	var waits []time.Duration
+	c := newRetryTestClient(srv.URL, &waits)
+	WithDebug(true)(c)
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	_, err := c.Do(context.Background(), &Request{Method: "GET", Path: "/ipMgmt/v2/ips"})
+	require.NoError(t, err)
+	_, err = c.Do(context.Background(), &Request{Method: "GET", Path: "/ipMgmt/v2/ips"})
+	require.NoError(t, err)
+
+	require.Len(t, waits, 1)
+	assert.Equal(t, 2*time.Second, waits[0])
+	assert.Contains(t, logs.String(), "Rate limit: delaying GET /ipMgmt/v2/ips")
+}
+
+func jsonResponse(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func TestAllServersIteratesPages(t *testing.T) {
+	var offsets []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/bareMetals/v2/servers", r.URL.Path)
+		assert.Equal(t, "AMS-01", r.URL.Query().Get("site"))
+		offset := r.URL.Query().Get("offset")
+		offsets = append(offsets, offset)
+		servers := []map[string]any{{"id": "1", "specs": map[string]any{"ram": map[string]any{"size": 32, "unit": "GB"}}}}
+		if offset != "0" {
+			servers = []map[string]any{{"id": "2", "location": map[string]any{"site": "AMS-01"}}}
+		}
+		jsonResponse(w, 200, map[string]any{
+			"_metadata": map[string]any{"totalCount": 2, "limit": 1, "offset": len(offsets) - 1},
+			"servers":   servers,
+		})
+	}))
+	defer srv.Close()
+
+	c := NewClient("test-key", WithBaseURL(srv.URL))
+	var servers []Server
+	for s, err := range c.BareMetal.AllServers(context.Background(), &ListServersOptions{Site: "AMS-01"}) {
+		require.NoError(t, err)
+		servers = append(servers, s)
+	}
+	require.Len(t, servers, 2)
+	assert.Equal(t, 32, servers[0].Specs.RAM.Size)
+	assert.Equal(t, "AMS-01", servers[1].Location.Site)
+	assert.Equal(t, []string{"0", "1"}, offsets)
+}
+
+func TestPages(t *testing.T) {
+	// The API caps pages at 2 items; totalCount keeps the walk going.
+	collection := []int{1, 2, 3, 4, 5}
+	var requested []ListOptions
+	fetch := func(opts ListOptions) (*Page[int], error) {
+		requested = append(requested, opts)
+		end := min(opts.Offset+2, len(collection))
+		return &Page[int]{Items: collection[opts.Offset:end], Metadata: Metadata{TotalCount: len(collection)}}, nil
+	}
+	var items []int
+	for page, err := range Pages(10, fetch) {
+		require.NoError(t, err)
+		items = append(items, page.Items...)
+	}
+	assert.Equal(t, collection, items)
+	assert.Equal(t, []ListOptions{{10, 0}, {10, 2}, {10, 4}}, requested)
+
+	// Without totalCount a short page is the last one.
+	requested = nil
+	for _, err := range Pages(0, func(opts ListOptions) (*Page[int], error) {
+		requested = append(requested, opts)
+		return &Page[int]{Items: make([]int, min(DefaultPageSize, 70-opts.Offset))}, nil
+	}) {
+		require.NoError(t, err)
+	}
+	assert.Equal(t, []ListOptions{{DefaultPageSize, 0}, {DefaultPageSize, DefaultPageSize}}, requested)
+}
+
+func TestAllServersToleratesMalformedMetadata(t *testing.T) {
+	var offsets []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset := r.URL.Query().Get("offset")
+		offsets = append(offsets, offset)
+		servers := []map[string]any{{"id": "1"}}
+		if offset != "0" {
+			servers = []map[string]any{{"id": "2"}}
+		}
+		jsonResponse(w, 200, map[string]any{
+			"_metadata": map[string]any{"totalCount": "2", "limit": "one", "offset": nil},
+			"servers":   servers,
+		})
+	}))
+	defer srv.Close()
+
+	c := NewClient("test-key", WithBaseURL(srv.URL))
+	page, err := c.BareMetal.ListServers(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, Metadata{TotalCount: 2}, page.Metadata)
+
+	offsets = nil
+	var ids []string
+	for s, err := range c.BareMetal.AllServers(context.Background(), nil) {
+		require.NoError(t, err)
+		ids = append(ids, s.ID)
+	}
+	assert.Equal(t, []string{"1", "2"}, ids)
+	assert.Equal(t, []string{"0", "1"}, offsets)
+}
+
+func TestErrorsAreTyped(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "test-key", r.Header.Get("X-LSW-Auth"))
+		jsonResponse(w, 404, map[string]any{"errorMessage": "Server not found"})
+	}))
+	defer srv.Close()
+
+	c := NewClient("test-key", WithBaseURL(srv.URL))
+	_, err := c.BareMetal.GetServer(context.Background(), "missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.NotErrorIs(t, err, ErrServer)
+
+	var apiErr *Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, 404, apiErr.StatusCode)
+	assert.Contains(t, apiErr.Body, "Server not found")
+	assert.Equal(t, "Server not found", apiErr.Message)
+}
+
+func TestUpdateServerReference(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		jsonResponse(w, 200, map[string]any{"id": "12345", "reference": body["reference"], "rack": map[string]any{"id": "r1"}})
+	}))
+	defer srv.Close()
+
+	c := NewClient("test-key", WithBaseURL(srv.URL))
+	server, err := c.BareMetal.UpdateServerReference(context.Background(), "12345", "web-01")
+	require.NoError(t, err)
+	assert.Equal(t, "web-01", server.Reference)
+	assert.JSONEq(t, `{"id":"12345","reference":"web-01","rack":{"id":"r1"}}`, string(server.Raw))
+}
+
+func TestErrorBodyIsParsed(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jsonResponse(w, 400, map[string]any{
+			"errorCode":     "APP00800",
+			"errorMessage":  "The supplied data is invalid.",
+			"correlationId": "8f3c0a3e-9f8b-4f1c-9e6f-3b1f0c2d4e5a",
+			"errorDetails": map[string]any{
+				"reference": []string{"This value is too long."},
+				"type":      "This value is not valid.",
+			},
+		})
+	}))
+	defer srv.Close()
+
+	c := NewClient("test-key", WithBaseURL(srv.URL))
+	_, err := c.BareMetal.UpdateServerReference(context.Background(), "12345", "web-01")
+	assert.ErrorIs(t, err, ErrBadRequest)
+
+	var apiErr *Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "APP00800", apiErr.Code)
+	assert.Equal(t, "The supplied data is invalid.", apiErr.Message)
+	assert.Equal(t, "8f3c0a3e-9f8b-4f1c-9e6f-3b1f0c2d4e5a", apiErr.CorrelationID)
+	assert.Equal(t, map[string][]string{
+		"reference": {"This value is too long."},
+		"type":      {"This value is not valid."},
+	}, apiErr.Details)
+	assert.Equal(t, "PUT "+srv.URL+"/bareMetals/v2/servers/12345: 400 Bad Request: The supplied data is invalid.", err.Error())
+}
+
+func TestDryRunHoldsBackMutatingRequests(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		jsonResponse(w, 200, map[string]any{"id": "12345"})
+	}))
+	defer srv.Close()
+
+	var shown []string
+	c := NewClient("test-key", WithBaseURL(srv.URL), WithDryRun(func(req *http.Request, body []byte) error {
+		shown = append(shown, req.Method+" "+req.URL.Path+" "+string(body))
+		return nil
+	}))
+
+	_, err := c.BareMetal.GetServer(context.Background(), "12345")
+	require.NoError(t, err)
+	_, err = c.BareMetal.UpdateServerReference(context.Background(), "12345", "web-01")
+	assert.ErrorIs(t, err, ErrDryRun)
+
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Equal(t, []string{`PUT /bareMetals/v2/servers/12345 {"reference":"web-01"}`}, shown)
+}
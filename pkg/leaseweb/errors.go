@@ -0,0 +1,114 @@
+package leaseweb
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors matched by *Error through errors.Is.
+var (
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrNotFound     = errors.New("not found")
+	ErrBadRequest   = errors.New("bad request")
+	ErrRateLimited  = errors.New("rate limited")
+	ErrServer       = errors.New("server error")
+)
+
+// ErrDryRun is returned for requests held back by WithDryRun.
+var ErrDryRun = errors.New("dry run: request not sent")
+
+// Error is returned for API responses with a status of 400 or above. Code,
+// Message, CorrelationID and Details are filled from the errorCode,
+// errorMessage, correlationId and errorDetails fields of the body when the
+// API sends them.
+type Error struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Status     string
+	Body       string
+
+	Code          string
+	Message       string
+	CorrelationID string
+	// Details maps a field name to the problems found with it.
+	Details map[string][]string
+}
+
+func newError(method, url string, resp *http.Response, body []byte) *Error {
+	e := &Error{
+		Method:     method,
+		URL:        url,
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Body:       string(body),
+	}
+	var res struct {
+		ErrorCode     json.RawMessage `json:"errorCode"`
+		ErrorMessage  string          `json:"errorMessage"`
+		CorrelationID string          `json:"correlationId"`
+		ErrorDetails  json.RawMessage `json:"errorDetails"`
+	}
+	if err := json.Unmarshal(body, &res); err != nil {
+		return e
+	}
+	e.Code = rawText(res.ErrorCode)
+	e.Message = res.ErrorMessage
+	e.CorrelationID = res.CorrelationID
+
+	var details map[string]json.RawMessage
+	if err := json.Unmarshal(res.ErrorDetails, &details); err == nil && len(details) > 0 {
+		e.Details = make(map[string][]string, len(details))
+		for field, raw := range details {
+			var list []string
+			if err := json.Unmarshal(raw, &list); err != nil {
+				list = []string{rawText(raw)}
+			}
+			e.Details[field] = list
+		}
+	}
+	return e
+}
+
+// rawText returns a JSON string unquoted and any other value as written.
+func rawText(raw json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	if string(raw) == "null" {
+		return ""
+	}
+	return strings.TrimSpace(string(raw))
+}
+
+func (e *Error) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("%s %s: %s: %s", e.Method, e.URL, e.Status, e.Message)
+	}
+	return fmt.Sprintf("%s %s: %s", e.Method, e.URL, e.Status)
+}
+
+// Is reports whether the error belongs to the class of target, so callers
+// can write errors.Is(err, leaseweb.ErrNotFound).
+func (e *Error) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrBadRequest:
+		return e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusUnprocessableEntity
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServer:
+		return e.StatusCode >= 500
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package leaseweb
+
+import (
+	"context"
+	"iter"
+	"net/url"
+)
+
+// HostingService covers domains and DNS (/hosting/v2).
+type HostingService struct {
+	client *Client
+}
+
+// Domain is a hosted domain.
+type Domain struct {
+	DomainName        string   `json:"domainName"`
+	Status            string   `json:"status"`
+	Suspended         bool     `json:"suspended"`
+	DNSOnly           bool     `json:"dnsOnly"`
+	NameServers       []string `json:"nameServers,omitempty"`
+	ContractStartDate string   `json:"contractStartDate"`
+	ContractEndDate   string   `json:"contractEndDate"`
+}
+
+// ResourceRecordSet is a DNS record set of a domain.
+type ResourceRecordSet struct {
+	Name     string   `json:"name"`
+	Type     string   `json:"type"`
+	Content  []string `json:"content"`
+	TTL      int      `json:"ttl"`
+	Editable bool     `json:"editable"`
+}
+
+// ListDomains returns one page of domains.
+func (s *HostingService) ListDomains(ctx context.Context, opts *ListOptions) (*Page[Domain], error) {
+	q := url.Values{}
+	if opts != nil {
+		opts.apply(q)
+	}
+	return listPage[Domain](ctx, s.client, "/hosting/v2/domains", q, "domains")
+}
+
+// AllDomains iterates over every domain.
+func (s *HostingService) AllDomains(ctx context.Context) iter.Seq2[Domain, error] {
+	return paginate[Domain](ctx, s.client, "/hosting/v2/domains", nil, "domains")
+}
+
+// GetDomain returns a single domain.
+func (s *HostingService) GetDomain(ctx context.Context, name string) (*Domain, error) {
+	var d Domain
+	if err := s.client.get(ctx, "/hosting/v2/domains/"+url.PathEscape(name), &d); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// ListResourceRecordSets returns the DNS record sets of a domain.
+func (s *HostingService) ListResourceRecordSets(ctx context.Context, domain string) (*Page[ResourceRecordSet], error) {
+	return listPage[ResourceRecordSet](ctx, s.client, "/hosting/v2/domains/"+url.PathEscape(domain)+"/resourceRecordSets", nil, "resourceRecordSets")
+}
@@ -0,0 +1,68 @@
+package leaseweb
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+)
+
+// InvoicesService covers invoices (/invoices/v1).
+type InvoicesService struct {
+	client *Client
+}
+
+// Invoice is an invoice summary.
+type Invoice struct {
+	ID                      string  `json:"id"`
+	Date                    string  `json:"date"`
+	DueDate                 string  `json:"dueDate"`
+	TaxAmount               float64 `json:"taxAmount"`
+	NetAmount               float64 `json:"netAmount"`
+	Total                   float64 `json:"total"`
+	OpenAmount              float64 `json:"openAmount"`
+	Currency                string  `json:"currency"`
+	IsPartialPaymentAllowed bool    `json:"isPartialPaymentAllowed"`
+	Status                  string  `json:"status"`
+}
+
+// ListInvoices returns one page of invoices.
+func (s *InvoicesService) ListInvoices(ctx context.Context, opts *ListOptions) (*Page[Invoice], error) {
+	q := url.Values{}
+	if opts != nil {
+		opts.apply(q)
+	}
+	return listPage[Invoice](ctx, s.client, "/invoices/v1/invoices", q, "invoices")
+}
+
+// AllInvoices iterates over every invoice.
+func (s *InvoicesService) AllInvoices(ctx context.Context) iter.Seq2[Invoice, error] {
+	return paginate[Invoice](ctx, s.client, "/invoices/v1/invoices", nil, "invoices")
+}
+
+// GetInvoice returns a single invoice.
+func (s *InvoicesService) GetInvoice(ctx context.Context, id string) (*Invoice, error) {
+	var inv Invoice
+	if err := s.client.get(ctx, "/invoices/v1/invoices/"+url.PathEscape(id), &inv); err != nil {
+		return nil, err
+	}
+	return &inv, nil
+}
+
+// DownloadPDF returns the PDF document of an invoice.
+func (s *InvoicesService) DownloadPDF(ctx context.Context, id string) ([]byte, error) {
+	resp, err := s.client.Do(ctx, &Request{Method: http.MethodGet, Path: "/invoices/v1/invoices/" + url.PathEscape(id) + "/pdf"})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// ExportCSV returns all invoices as CSV.
+func (s *InvoicesService) ExportCSV(ctx context.Context) ([]byte, error) {
+	resp, err := s.client.Do(ctx, &Request{Method: http.MethodGet, Path: "/invoices/v1/invoices/export/csv"})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
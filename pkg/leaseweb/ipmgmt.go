@@ -0,0 +1,81 @@
+package leaseweb
+
+import (
+	"context"
+	"iter"
+	"net/url"
+)
+
+// IPMgmtService covers IP address management (/ipMgmt/v2).
+type IPMgmtService struct {
+	client *Client
+}
+
+// IP is an IP address assigned to the account.
+type IP struct {
+	IP               string `json:"ip"`
+	Version          int    `json:"version"`
+	Type             string `json:"type"`
+	PrefixLength     int    `json:"prefixLength"`
+	Primary          bool   `json:"primary"`
+	FloatingIP       bool   `json:"floatingIp"`
+	ReverseLookup    string `json:"reverseLookup"`
+	NullRouted       bool   `json:"nullRouted"`
+	UnnullingAllowed bool   `json:"unnullingAllowed"`
+	EquipmentID      string `json:"equipmentId"`
+	Subnet           *struct {
+		ID           string `json:"id"`
+		NetworkIP    string `json:"networkIp"`
+		PrefixLength int    `json:"prefixLength"`
+		Gateway      string `json:"gateway"`
+	} `json:"subnet,omitempty"`
+}
+
+// ListIPsOptions filters the IP list.
+type ListIPsOptions struct {
+	ListOptions
+	Version    string
+	Type       string
+	NullRouted string
+}
+
+func (o *ListIPsOptions) query() url.Values {
+	q := url.Values{}
+	if o == nil {
+		return q
+	}
+	o.ListOptions.apply(q)
+	if o.Version != "" {
+		q.Set("version", o.Version)
+	}
+	if o.Type != "" {
+		q.Set("type", o.Type)
+	}
+	if o.NullRouted != "" {
+		q.Set("nullRouted", o.NullRouted)
+	}
+	return q
+}
+
+// ListIPs returns one page of IP addresses.
+func (s *IPMgmtService) ListIPs(ctx context.Context, opts *ListIPsOptions) (*Page[IP], error) {
+	return listPage[IP](ctx, s.client, "/ipMgmt/v2/ips", opts.query(), "ips")
+}
+
+// AllIPs iterates over every IP address matching opts; the pagination
+// fields of opts are ignored.
+func (s *IPMgmtService) AllIPs(ctx context.Context, opts *ListIPsOptions) iter.Seq2[IP, error] {
+	q := opts.query()
+	q.Del("limit")
+	q.Del("offset")
+	return paginate[IP](ctx, s.client, "/ipMgmt/v2/ips", q, "ips")
+}
+
+// GetIP returns a single IP address.
+func (s *IPMgmtService) GetIP(ctx context.Context, ip string) (*IP, error) {
+	var res IP
+	if err := s.client.get(ctx, "/ipMgmt/v2/ips/"+url.PathEscape(ip), &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
@@ -0,0 +1,353 @@
+// Package leaseweb is a Go client for the Leaseweb API.
+//
+// A Client is created with an API key and exposes one service per API area:
+//
+//	client := leaseweb.NewClient(os.Getenv("LEASEWEB_API_KEY"))
+//	server, err := client.BareMetal.GetServer(ctx, "12345")
+//
+// Collections can be fetched a page at a time with the List methods, or
+// walked in full with the iterator returned by the All methods:
+//
+//	for server, err := range client.BareMetal.AllServers(ctx, nil) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(server.ID, server.Reference)
+//	}
+//
+// Requests that fail with a rate limit or a transient server error are
+// retried with exponential backoff, and API errors are returned as *Error.
+package leaseweb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httputil"
+	"strings"
+	"time"
+)
+
+// DefaultBaseURL is the production Leaseweb API endpoint.
+const DefaultBaseURL = "https://api.leaseweb.com"
+
+// Client sends authenticated requests to the Leaseweb API. A Client is safe
+// for concurrent use; all goroutines share its rate limiter.
+type Client struct {
+	http      *http.Client
+	baseURL   string
+	apiKey    string
+	userAgent string
+	debug     bool
+
+	// maxRetries is the number of additional attempts made after a
+	// retryable failure. Zero disables retries.
+	maxRetries int
+	// retryWait is the base delay for exponential backoff.
+	retryWait time.Duration
+	// maxRetryWait caps the delays the API asks for through Retry-After and
+	// the rate-limit headers. Zero means no limit.
+	maxRetryWait time.Duration
+	// sleep waits between attempts; replaced in tests.
+	sleep func(ctx context.Context, d time.Duration) error
+	// limiter throttles requests; shared by all goroutines using the client.
+	limiter *rateLimiter
+	// dryRun, when set, receives mutating requests instead of the API.
+	dryRun func(req *http.Request, body []byte) error
+	// timeout limits each attempt, including reading the response body.
+	// Zero means no limit.
+	timeout time.Duration
+
+	BareMetal   *BareMetalService
+	PublicCloud *PublicCloudService
+	IPMgmt      *IPMgmtService
+	Invoices    *InvoicesService
+	Hosting     *HostingService
+	Services    *ServicesService
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithBaseURL overrides the API endpoint, e.g. to point at a test server.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) { c.baseURL = strings.TrimRight(baseURL, "/") }
+}
+
+// WithHTTPClient sets the underlying HTTP client.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) { c.http = hc }
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(ua string) Option {
+	return func(c *Client) { c.userAgent = ua }
+}
+
+// WithDebug logs every request and response through the standard logger.
+func WithDebug(debug bool) Option {
+	return func(c *Client) { c.debug = debug }
+}
+
+// WithRetries sets how many times retryable failures are retried and the
+// base delay for exponential backoff.
+func WithRetries(maxRetries int, wait time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = max(maxRetries, 0)
+		c.retryWait = wait
+	}
+}
+
+// WithMaxRetryWait sets the longest delay the API may ask for before a
+// request is retried or sent. A request that would have to wait longer fails
+// instead, with an error matching ErrRateLimited for rate limits. Zero
+// removes the limit.
+func WithMaxRetryWait(d time.Duration) Option {
+	return func(c *Client) { c.maxRetryWait = max(d, 0) }
+}
+
+// WithRateLimit limits the client to rate requests per second with the
+// given burst. A rate of zero only adapts to the API's rate-limit headers.
+func WithRateLimit(rate float64, burst int) Option {
+	return func(c *Client) { c.limiter = newRateLimiter(rate, burst) }
+}
+
+// WithDryRun stops every request other than GET and HEAD before it is sent
+// and passes it to show instead, with its body. Do then returns ErrDryRun,
+// or the error from show. Reads still reach the API.
+func WithDryRun(show func(req *http.Request, body []byte) error) Option {
+	return func(c *Client) { c.dryRun = show }
+}
+
+// WithTimeout limits each attempt of a request to d, including reading the
+// response. Attempts that time out are retried like other network errors.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) { c.timeout = d }
+}
+
+// NewClient returns a client authenticating with apiKey.
+func NewClient(apiKey string, opts ...Option) *Client {
+	c := &Client{
+		http:         http.DefaultClient,
+		baseURL:      DefaultBaseURL,
+		apiKey:       apiKey,
+		userAgent:    "leaseweb-go",
+		maxRetries:   DefaultMaxRetries,
+		retryWait:    DefaultRetryWait,
+		maxRetryWait: DefaultMaxRetryWait,
+		sleep:        sleepContext,
+		limiter:      newRateLimiter(0, 0),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.BareMetal = &BareMetalService{client: c}
+	c.PublicCloud = &PublicCloudService{client: c}
+	c.IPMgmt = &IPMgmtService{client: c}
+	c.Invoices = &InvoicesService{client: c}
+	c.Hosting = &HostingService{client: c}
+	c.Services = &ServicesService{client: c}
+	return c
+}
+
+// BaseURL returns the API endpoint the client sends requests to.
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// Request is a raw API request. Path is relative to the base URL and may
+// include a query string.
+type Request struct {
+	Method string
+	Path   string
+	Body   []byte
+	// Header is added to, or overrides, the default headers.
+	Header http.Header
+}
+
+// Response is a raw API response with its body fully read.
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// Do performs a raw request, retrying retryable failures. Responses with a
+// status of 400 or above are returned as *Error.
+func (c *Client) Do(ctx context.Context, r *Request) (*Response, error) {
+	url := c.baseURL + r.Path
+
+	for attempt := 0; ; attempt++ {
+		var reqBody io.Reader
+		if r.Body != nil {
+			reqBody = bytes.NewReader(r.Body)
+		}
+		req, err := http.NewRequestWithContext(ctx, r.Method, url, reqBody)
+		if err != nil {
+			return nil, fmt.Errorf("creating request: %w", err)
+		}
+
+		req.Header.Set("X-LSW-Auth", c.apiKey)
+		req.Header.Set("User-Agent", c.userAgent)
+		if r.Body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		for k, v := range r.Header {
+			req.Header[k] = v
+		}
+
+		if c.dryRun != nil && req.Method != http.MethodGet && req.Method != http.MethodHead {
+			if err := c.dryRun(req, r.Body); err != nil {
+				return nil, err
+			}
+			return nil, ErrDryRun
+		}
+
+		if c.limiter != nil {
+			if d := c.limiter.reserve(); d > 0 {
+				if c.maxRetryWait > 0 && d > c.maxRetryWait {
+					return nil, fmt.Errorf("%s %s: %w: the API quota resets in %s, longer than the %s limit",
+						r.Method, url, ErrRateLimited, d.Round(time.Second), c.maxRetryWait)
+				}
+				if c.debug {
+					log.Printf("Rate limit: delaying %s %s by %s\n", r.Method, r.Path, d.Round(time.Millisecond))
+				}
+				if err := c.sleep(ctx, d); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		if c.debug {
+			if dump, err := httputil.DumpRequest(req, true); err == nil {
+				log.Printf("Request:\n%s\n", dump)
+			}
+		}
+
+		resp, respBody, err := c.roundTrip(req)
+		if resp == nil {
+			if attempt < c.maxRetries && isIdempotent(r.Method) && ctx.Err() == nil {
+				if err := c.wait(ctx, attempt, c.backoff(attempt), err.Error()); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			return nil, fmt.Errorf("executing request: %w", err)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading response: %w", err)
+		}
+
+		if c.limiter != nil {
+			c.limiter.observe(resp.StatusCode, resp.Header)
+		}
+
+		if c.debug {
+			if dump, err := httputil.DumpResponse(resp, false); err == nil {
+				log.Printf("Response:\n%s\n%s\n", dump, debugBody(resp.Header.Get("Content-Type"), respBody))
+			}
+		}
+
+		if attempt < c.maxRetries && shouldRetry(r.Method, resp.StatusCode) {
+			d, ok := retryAfter(resp.Header, time.Now())
+			if !ok {
+				d = c.backoff(attempt)
+			}
+			if c.maxRetryWait > 0 && d > c.maxRetryWait {
+				return nil, fmt.Errorf("%w; not retried since the API asked to wait %s, longer than the %s limit",
+					newError(r.Method, url, resp, respBody), d.Round(time.Second), c.maxRetryWait)
+			}
+			if err := c.wait(ctx, attempt, d, resp.Status); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if resp.StatusCode >= 400 {
+			return nil, newError(r.Method, url, resp, respBody)
+		}
+
+		return &Response{
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header,
+			Body:       respBody,
+		}, nil
+	}
+}
+
+// roundTrip sends req and reads the whole response body within the
+// client's timeout. The response is nil when the request could not be sent.
+func (c *Client) roundTrip(req *http.Request) (*http.Response, []byte, error) {
+	if c.timeout > 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), c.timeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	return resp, body, err
+}
+
+// debugBody returns the body for debug logging, eliding binary payloads
+// such as invoice PDFs.
+func debugBody(contentType string, body []byte) string {
+	if contentType == "" || strings.Contains(contentType, "json") || strings.HasPrefix(contentType, "text/") {
+		return string(body)
+	}
+	return fmt.Sprintf("<%d bytes of %s>", len(body), contentType)
+}
+
+func (c *Client) wait(ctx context.Context, attempt int, d time.Duration, reason string) error {
+	if c.debug {
+		log.Printf("Retrying in %s (attempt %d of %d): %s\n", d, attempt+1, c.maxRetries, reason)
+	}
+	return c.sleep(ctx, d)
+}
+
+// get decodes the JSON response of a GET request into v.
+func (c *Client) get(ctx context.Context, path string, v any) error {
+	resp, err := c.Do(ctx, &Request{Method: http.MethodGet, Path: path})
+	if err != nil {
+		return err
+	}
+	return decode(resp.Body, v)
+}
+
+// send encodes in as the JSON body (unless nil) and decodes the response
+// into out (unless nil).
+func (c *Client) send(ctx context.Context, method, path string, in, out any) error {
+	req := &Request{Method: method, Path: path}
+	if in != nil {
+		body, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("encoding request: %w", err)
+		}
+		req.Body = body
+	}
+	resp, err := c.Do(ctx, req)
+	if err != nil {
+		return err
+	}
+	if out == nil {
+		return nil
+	}
+	return decode(resp.Body, out)
+}
+
+func decode(body []byte, v any) error {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+	return nil
+}
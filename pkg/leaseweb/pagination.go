@@ -0,0 +1,153 @@
+package leaseweb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/url"
+	"strconv"
+)
+
+// DefaultPageSize is the page size used by the All iterators and by Pages
+// when no page size is given.
+const DefaultPageSize = 50
+
+// ListOptions selects a page of a collection.
+type ListOptions struct {
+	Limit  int
+	Offset int
+}
+
+func (o ListOptions) apply(q url.Values) {
+	if o.Limit > 0 {
+		q.Set("limit", strconv.Itoa(o.Limit))
+	}
+	if o.Limit > 0 || o.Offset > 0 {
+		q.Set("offset", strconv.Itoa(o.Offset))
+	}
+}
+
+// Metadata describes the position of a page within its collection.
+type Metadata struct {
+	TotalCount int `json:"totalCount"`
+	Limit      int `json:"limit"`
+	Offset     int `json:"offset"`
+}
+
+// Page is one page of a collection.
+type Page[T any] struct {
+	Items    []T
+	Metadata Metadata
+	// Raw is the response body as returned by the API.
+	Raw json.RawMessage
+}
+
+// listPage fetches one page of the collection stored under key.
+func listPage[T any](ctx context.Context, c *Client, path string, q url.Values, key string) (*Page[T], error) {
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+	resp, err := c.Do(ctx, &Request{Method: "GET", Path: path})
+	if err != nil {
+		return nil, err
+	}
+
+	var body map[string]json.RawMessage
+	if err := json.Unmarshal(resp.Body, &body); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+	page := &Page[T]{Raw: resp.Body}
+	if raw, ok := body[key]; ok {
+		if err := json.Unmarshal(raw, &page.Items); err != nil {
+			return nil, fmt.Errorf("decoding %s: %w", key, err)
+		}
+	}
+	page.Metadata = decodeMetadata(body["_metadata"])
+	return page, nil
+}
+
+// decodeMetadata reads the _metadata of a page. The metadata only steers
+// pagination, so it is read leniently: numbers given as strings are
+// accepted and fields that are not numbers are left at zero, which makes
+// paginate fall back to the number of items on a page.
+func decodeMetadata(raw json.RawMessage) Metadata {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return Metadata{}
+	}
+	number := func(name string) int {
+		var n json.Number
+		if err := json.Unmarshal(fields[name], &n); err != nil {
+			return 0
+		}
+		i, err := strconv.Atoi(n.String())
+		if err != nil {
+			return 0
+		}
+		return i
+	}
+	return Metadata{
+		TotalCount: number("totalCount"),
+		Limit:      number("limit"),
+		Offset:     number("offset"),
+	}
+}
+
+// Pages returns an iterator over the pages of a collection, fetched lazily
+// with fetch from the start in pages of pageSize items, or DefaultPageSize
+// when pageSize is not positive. Iteration stops after the last page or the
+// first error.
+func Pages[T any](pageSize int, fetch func(ListOptions) (*Page[T], error)) iter.Seq2[*Page[T], error] {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	return func(yield func(*Page[T], error) bool) {
+		offset := 0
+		for {
+			page, err := fetch(ListOptions{Limit: pageSize, Offset: offset})
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(page, nil) {
+				return
+			}
+			offset += len(page.Items)
+			// Trust totalCount when given, since the API may cap the page
+			// size below the requested limit; without it, a short page is
+			// the last one.
+			total := page.Metadata.TotalCount
+			if len(page.Items) == 0 || (total > 0 && offset >= total) || (total == 0 && len(page.Items) < pageSize) {
+				return
+			}
+		}
+	}
+}
+
+// paginate returns an iterator over every item of a collection, fetching
+// pages lazily. Iteration stops after the first error.
+func paginate[T any](ctx context.Context, c *Client, path string, q url.Values, key string) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		pages := Pages(DefaultPageSize, func(opts ListOptions) (*Page[T], error) {
+			pq := url.Values{}
+			for k, v := range q {
+				pq[k] = v
+			}
+			opts.apply(pq)
+			return listPage[T](ctx, c, path, pq, key)
+		})
+		for page, err := range pages {
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, item := range page.Items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package leaseweb
+
+import (
+	"context"
+	"encoding/json"
+	"iter"
+	"net/http"
+	"net/url"
+)
+
+// PublicCloudService covers public cloud instances (/publicCloud/v1).
+type PublicCloudService struct {
+	client *Client
+}
+
+// Instance is a public cloud instance.
+type Instance struct {
+	ID                  string        `json:"id"`
+	Reference           string        `json:"reference"`
+	Type                InstanceType  `json:"type"`
+	Region              string        `json:"region"`
+	State               string        `json:"state"`
+	Image               InstanceImage `json:"image"`
+	IPs                 []InstanceIP  `json:"ips"`
+	RootDiskSize        int           `json:"rootDiskSize"`
+	RootDiskStorageType string        `json:"rootDiskStorageType"`
+	StartedAt           string        `json:"startedAt"`
+}
+
+// PublicIPv4 returns the first IPv4 address of the instance, if any.
+func (i *Instance) PublicIPv4() string {
+	for _, ip := range i.IPs {
+		if ip.Version == 4 {
+			return ip.IP
+		}
+	}
+	return ""
+}
+
+// InstanceType is the instance type name. The API returns it either as a
+// plain string or as an object with a name.
+type InstanceType struct {
+	Name string `json:"name"`
+}
+
+func (t *InstanceType) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		return json.Unmarshal(data, &t.Name)
+	}
+	type plain InstanceType
+	return json.Unmarshal(data, (*plain)(t))
+}
+
+type InstanceImage struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Family  string `json:"family"`
+	Flavour string `json:"flavour"`
+	Custom  bool   `json:"custom"`
+}
+
+type InstanceIP struct {
+	IP            string `json:"ip"`
+	PrefixLength  string `json:"prefixLength"`
+	Version       int    `json:"version"`
+	NullRouted    bool   `json:"nullRouted"`
+	ReverseLookup string `json:"reverseLookup"`
+	MainIP        bool   `json:"mainIp"`
+	NetworkType   string `json:"networkType"`
+}
+
+// Region is a public cloud region.
+type Region struct {
+	Name     string `json:"name"`
+	Location string `json:"location"`
+}
+
+// ListInstancesOptions filters the instance list.
+type ListInstancesOptions struct {
+	ListOptions
+	Region string
+	State  string
+}
+
+func (o *ListInstancesOptions) query() url.Values {
+	q := url.Values{}
+	if o == nil {
+		return q
+	}
+	o.ListOptions.apply(q)
+	if o.Region != "" {
+		q.Set("region", o.Region)
+	}
+	if o.State != "" {
+		q.Set("state", o.State)
+	}
+	return q
+}
+
+// ListInstances returns one page of instances.
+func (s *PublicCloudService) ListInstances(ctx context.Context, opts *ListInstancesOptions) (*Page[Instance], error) {
+	return listPage[Instance](ctx, s.client, "/publicCloud/v1/instances", opts.query(), "instances")
+}
+
+// AllInstances iterates over every instance matching opts; the pagination
+// fields of opts are ignored.
+func (s *PublicCloudService) AllInstances(ctx context.Context, opts *ListInstancesOptions) iter.Seq2[Instance, error] {
+	q := opts.query()
+	q.Del("limit")
+	q.Del("offset")
+	return paginate[Instance](ctx, s.client, "/publicCloud/v1/instances", q, "instances")
+}
+
+// GetInstance returns a single instance.
+func (s *PublicCloudService) GetInstance(ctx context.Context, id string) (*Instance, error) {
+	var inst Instance
+	if err := s.client.get(ctx, "/publicCloud/v1/instances/"+url.PathEscape(id), &inst); err != nil {
+		return nil, err
+	}
+	return &inst, nil
+}
+
+// StartInstance starts a stopped instance.
+func (s *PublicCloudService) StartInstance(ctx context.Context, id string) error {
+	return s.client.send(ctx, http.MethodPost, "/publicCloud/v1/instances/"+url.PathEscape(id)+"/start", nil, nil)
+}
+
+// StopInstance stops a running instance.
+func (s *PublicCloudService) StopInstance(ctx context.Context, id string) error {
+	return s.client.send(ctx, http.MethodPost, "/publicCloud/v1/instances/"+url.PathEscape(id)+"/stop", nil, nil)
+}
+
+// RebootInstance reboots an instance.
+func (s *PublicCloudService) RebootInstance(ctx context.Context, id string) error {
+	return s.client.send(ctx, http.MethodPost, "/publicCloud/v1/instances/"+url.PathEscape(id)+"/reboot", nil, nil)
+}
+
+// TerminateInstance terminates an instance.
+func (s *PublicCloudService) TerminateInstance(ctx context.Context, id string) error {
+	return s.client.send(ctx, http.MethodDelete, "/publicCloud/v1/instances/"+url.PathEscape(id), nil, nil)
+}
+
+// ListRegions returns the available public cloud regions.
+func (s *PublicCloudService) ListRegions(ctx context.Context) (*Page[Region], error) {
+	return listPage[Region](ctx, s.client, "/publicCloud/v1/regions", nil, "regions")
+}
@@ -0,0 +1,129 @@
+package leaseweb
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// rateLimiter is a token bucket shared by all requests made through a Client,
+// including requests issued concurrently from several goroutines.
+//
+// Besides the configured rate it adapts to the API's rate-limit headers: when
+// the remaining quota runs low, requests are paced so the quota lasts until
+// the reset time, and once it is exhausted (or a 429 is received) every
+// request waits until the quota resets.
+type rateLimiter struct {
+	mu  sync.Mutex
+	now func() time.Time
+
+	// limit is the configured rate in requests per second; zero means no
+	// client-side limit.
+	limit  float64
+	burst  float64
+	tokens float64
+	last   time.Time
+
+	// adaptive is a rate derived from rate-limit headers, valid until
+	// adaptiveUntil.
+	adaptive      float64
+	adaptiveUntil time.Time
+
+	// blockedUntil holds back every request until the quota resets.
+	blockedUntil time.Time
+}
+
+func newRateLimiter(limit float64, burst int) *rateLimiter {
+	if limit < 0 {
+		limit = 0
+	}
+	if burst < 1 {
+		burst = max(1, int(limit))
+	}
+	return &rateLimiter{
+		now:    time.Now,
+		limit:  limit,
+		burst:  float64(burst),
+		tokens: float64(burst),
+	}
+}
+
+func (l *rateLimiter) rate(now time.Time) float64 {
+	r := l.limit
+	if l.adaptive > 0 && now.Before(l.adaptiveUntil) && (r == 0 || l.adaptive < r) {
+		r = l.adaptive
+	}
+	return r
+}
+
+// reserve takes a token and returns how long the caller must wait before
+// sending its request.
+func (l *rateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	var wait time.Duration
+	if r := l.rate(now); r > 0 {
+		if !l.last.IsZero() {
+			l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*r)
+		}
+		l.last = now
+		l.tokens--
+		if l.tokens < 0 {
+			wait = time.Duration(-l.tokens / r * float64(time.Second))
+		}
+	}
+	if blocked := l.blockedUntil.Sub(now); blocked > wait {
+		wait = blocked
+	}
+	return wait
+}
+
+// observe updates the limiter from a response's rate-limit headers.
+func (l *rateLimiter) observe(statusCode int, h http.Header) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if statusCode == http.StatusTooManyRequests {
+		if d, ok := retryAfter(h, now); ok {
+			l.block(now.Add(d))
+		}
+	}
+
+	remaining, err := strconv.Atoi(strings.TrimSpace(h.Get("X-RateLimit-Remaining")))
+	if err != nil {
+		return
+	}
+	reset, ok := rateLimitReset(h, now)
+	if !ok || reset <= 0 {
+		return
+	}
+	if remaining <= 0 {
+		l.block(now.Add(reset))
+		return
+	}
+
+	// Only pace once the quota runs low; otherwise let requests through at
+	// the configured rate.
+	threshold := 10
+	if quota, err := strconv.Atoi(strings.TrimSpace(h.Get("X-RateLimit-Limit"))); err == nil && quota/10 > threshold {
+		threshold = quota / 10
+	}
+	if remaining > threshold {
+		l.adaptive = 0
+		return
+	}
+	l.adaptive = float64(remaining) / reset.Seconds()
+	l.adaptiveUntil = now.Add(reset)
+	l.tokens = min(l.tokens, 1)
+}
+
+func (l *rateLimiter) block(until time.Time) {
+	if until.After(l.blockedUntil) {
+		l.blockedUntil = until
+	}
+}
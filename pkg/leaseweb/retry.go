@@ -0,0 +1,112 @@
+package leaseweb
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultMaxRetries is the number of retries made when none is configured.
+	DefaultMaxRetries = 3
+	// DefaultRetryWait is the default base delay for exponential backoff.
+	DefaultRetryWait = time.Second
+	// DefaultMaxRetryWait is the longest delay the API may ask for before
+	// a request is retried or sent.
+	DefaultMaxRetryWait = 5 * time.Minute
+
+	maxBackoff = 30 * time.Second
+)
+
+// isIdempotent reports whether a request with the given method can safely be
+// repeated after a server error or dropped connection.
+func isIdempotent(method string) bool {
+	switch strings.ToUpper(method) {
+	case "GET", "HEAD", "OPTIONS", "PUT", "DELETE":
+		return true
+	}
+	return false
+}
+
+// shouldRetry reports whether a response status warrants another attempt.
+// Rate limiting is retried for every method since the request was rejected
+// before being processed; server errors only for idempotent methods.
+func shouldRetry(method string, statusCode int) bool {
+	if statusCode == http.StatusTooManyRequests {
+		return true
+	}
+	switch statusCode {
+	case http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(method)
+	}
+	return false
+}
+
+// backoff returns the exponential backoff delay for the given attempt with
+// full jitter applied.
+func (c *Client) backoff(attempt int) time.Duration {
+	base := c.retryWait
+	if base <= 0 {
+		return 0
+	}
+	d := base << attempt
+	if d <= 0 || d > maxBackoff {
+		d = maxBackoff
+	}
+	return d/2 + rand.N(d/2+1)
+}
+
+// retryAfter extracts the server-requested delay from Retry-After, or from
+// X-RateLimit-Reset when X-RateLimit-Remaining shows the quota is exhausted.
+func retryAfter(h http.Header, now time.Time) (time.Duration, bool) {
+	if v := strings.TrimSpace(h.Get("Retry-After")); v != "" {
+		if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+			return time.Duration(secs) * time.Second, true
+		}
+		if t, err := http.ParseTime(v); err == nil {
+			return max(t.Sub(now), 0), true
+		}
+	}
+	if h.Get("X-RateLimit-Remaining") == "0" {
+		if d, ok := rateLimitReset(h, now); ok {
+			return d, true
+		}
+	}
+	return 0, false
+}
+
+// rateLimitReset parses X-RateLimit-Reset, which is either a delay in seconds
+// or a Unix timestamp.
+func rateLimitReset(h http.Header, now time.Time) (time.Duration, bool) {
+	v := strings.TrimSpace(h.Get("X-RateLimit-Reset"))
+	if v == "" {
+		return 0, false
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || n < 0 {
+		return 0, false
+	}
+	// Values this large can only be epoch timestamps.
+	if n > 1_000_000_000 {
+		return max(time.Unix(n, 0).Sub(now), 0), true
+	}
+	return time.Duration(n) * time.Second, true
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
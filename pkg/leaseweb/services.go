@@ -0,0 +1,71 @@
+package leaseweb
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+)
+
+// ServicesService covers contracted services (/services/v1).
+type ServicesService struct {
+	client *Client
+}
+
+// Service is a contracted service.
+type Service struct {
+	ID                  string  `json:"id"`
+	ContractID          string  `json:"contractId"`
+	ProductID           string  `json:"productId"`
+	Reference           string  `json:"reference"`
+	Status              string  `json:"status"`
+	Cancellable         bool    `json:"cancellable"`
+	Uncancellable       bool    `json:"uncancellable"`
+	StartDate           string  `json:"startDate"`
+	EndDate             string  `json:"endDate"`
+	ContractTerm        string  `json:"contractTerm"`
+	ContractTermEndDate string  `json:"contractTermEndDate"`
+	BillingCycle        string  `json:"billingCycle"`
+	PricePerFrequency   float64 `json:"pricePerFrequency"`
+	Currency            string  `json:"currency"`
+	EquipmentID         string  `json:"equipmentId"`
+}
+
+// CancelServiceRequest is the body of a cancellation.
+type CancelServiceRequest struct {
+	Reason     string `json:"reason,omitempty"`
+	ReasonCode string `json:"reasonCode,omitempty"`
+}
+
+// ListServices returns one page of services.
+func (s *ServicesService) ListServices(ctx context.Context, opts *ListOptions) (*Page[Service], error) {
+	q := url.Values{}
+	if opts != nil {
+		opts.apply(q)
+	}
+	return listPage[Service](ctx, s.client, "/services/v1/services", q, "services")
+}
+
+// AllServices iterates over every service.
+func (s *ServicesService) AllServices(ctx context.Context) iter.Seq2[Service, error] {
+	return paginate[Service](ctx, s.client, "/services/v1/services", nil, "services")
+}
+
+// GetService returns a single service.
+func (s *ServicesService) GetService(ctx context.Context, id string) (*Service, error) {
+	var svc Service
+	if err := s.client.get(ctx, "/services/v1/services/"+url.PathEscape(id), &svc); err != nil {
+		return nil, err
+	}
+	return &svc, nil
+}
+
+// CancelService cancels a service at the end of its contract term.
+func (s *ServicesService) CancelService(ctx context.Context, id string, req *CancelServiceRequest) error {
+	return s.client.send(ctx, http.MethodPost, "/services/v1/services/"+url.PathEscape(id)+"/cancel", req, nil)
+}
+
+// UncancelService revokes a pending cancellation.
+func (s *ServicesService) UncancelService(ctx context.Context, id string) error {
+	return s.client.send(ctx, http.MethodPost, "/services/v1/services/"+url.PathEscape(id)+"/uncancel", nil, nil)
+}